@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"github.com/laevitas/cli/internal/config"
+)
+
+// expandAlias replaces a leading alias (config alias set) with its command,
+// keeping the rest of args. Expansion happens once, so an alias can't loop.
+func expandAlias(args []string) []string {
+	if len(args) == 0 {
+		return args
+	}
+	command, ok := config.Aliases()[args[0]]
+	if !ok {
+		return args
+	}
+	return append(splitArgs(command), args[1:]...)
+}
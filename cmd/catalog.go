@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/laevitas/cli/internal/cmdutil"
+	"github.com/laevitas/cli/internal/completer"
+	"github.com/laevitas/cli/internal/output"
+)
+
+var catalogCmd = &cobra.Command{
+	Use:   "catalog",
+	Short: "Manage the local instrument catalog cache",
+	Long: `Instrument catalogs used for tab-completion and search are cached in
+~/.config/laevitas/catalogs.json and refreshed in the background once they
+are older than a few hours.`,
+}
+
+var catalogRefreshCmd = &cobra.Command{
+	Use:     "refresh",
+	Short:   "Re-download all instrument catalogs",
+	Example: `  laevitas catalog refresh`,
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// In the REPL, refresh the session completer so completion picks up
+		// the new catalogs without a restart.
+		c := replCompleter
+		if c == nil {
+			client, _ := cmdutil.MustClient()
+			if client == nil {
+				return fmt.Errorf("no API client available")
+			}
+			c = completer.New(client, nil)
+		}
+
+		counts := c.Refresh()
+		if len(counts) == 0 {
+			return fmt.Errorf("could not fetch any catalog")
+		}
+
+		cats := make([]string, 0, len(counts))
+		for cat := range counts {
+			cats = append(cats, cat)
+		}
+		sort.Strings(cats)
+		for _, cat := range cats {
+			output.Successf("%s: %s instruments", cat, output.FormatNumber(fmt.Sprint(counts[cat])))
+		}
+		return nil
+	},
+}
+
+func init() {
+	catalogCmd.AddCommand(catalogRefreshCmd)
+}
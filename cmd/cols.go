@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/chzyer/readline"
+
+	"github.com/laevitas/cli/internal/cmdutil"
+	"github.com/laevitas/cli/internal/output"
+)
+
+// ─── REPL column picker ─────────────────────────────────────────────────────
+
+// handleColsCommand re-renders the last REPL result with a subset of its
+// columns, without fetching again: cols [<n|name>...]. With no arguments
+// it lists the columns and asks for a selection.
+func handleColsCommand(args []string, rl *readline.Instance) error {
+	if cmdutil.LastResponse == nil {
+		return errNoLastResult
+	}
+	rows, err := cmdutil.ResponseRows(cmdutil.LastResponse)
+	if err != nil {
+		return fmt.Errorf("last result: %w", err)
+	}
+	columns := output.Columns(rows)
+	if len(columns) == 0 {
+		return fmt.Errorf("the last result has no columns to pick from")
+	}
+
+	if len(args) == 0 {
+		dim := "\033[2m"
+		reset := "\033[0m"
+		for i, c := range columns {
+			fmt.Printf("  %s%2d%s  %s\n", dim, i+1, reset, c)
+		}
+		rl.SetPrompt("  columns (numbers or names, blank for all): ")
+		line, err := rl.Readline()
+		rl.SetPrompt(replPrompt)
+		if err != nil {
+			return nil // Ctrl+C / Ctrl+D: leave the result as it was
+		}
+		args = strings.Fields(strings.ReplaceAll(line, ",", " "))
+	}
+
+	keep, err := pickColumns(columns, args)
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if rec, ok := row.(map[string]interface{}); ok {
+			for k := range rec {
+				if !keep[k] {
+					delete(rec, k)
+				}
+			}
+		}
+	}
+	data, err := json.Marshal(map[string]interface{}{"data": rows})
+	if err != nil {
+		return err
+	}
+	return cmdutil.MustPrinter().Print(data)
+}
+
+// pickColumns resolves a selection of 1-based column numbers and column
+// names (case-insensitive). No selection, or "all", keeps every column.
+func pickColumns(columns []string, sel []string) (map[string]bool, error) {
+	keep := make(map[string]bool, len(columns))
+	if len(sel) == 0 || (len(sel) == 1 && strings.EqualFold(sel[0], "all")) {
+		for _, c := range columns {
+			keep[c] = true
+		}
+		return keep, nil
+	}
+	for _, s := range sel {
+		if n, err := strconv.Atoi(s); err == nil {
+			if n < 1 || n > len(columns) {
+				return nil, fmt.Errorf("no column %d (1-%d)", n, len(columns))
+			}
+			keep[columns[n-1]] = true
+			continue
+		}
+		found := false
+		for _, c := range columns {
+			if strings.EqualFold(c, s) {
+				keep[c] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown column %q (have: %s)", s, strings.Join(columns, ", "))
+		}
+	}
+	return keep, nil
+}
@@ -0,0 +1,117 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/laevitas/cli/internal/cmdutil"
+	internalConfig "github.com/laevitas/cli/internal/config"
+	"github.com/laevitas/cli/internal/output"
+)
+
+var aliasCmd = &cobra.Command{
+	Use:   "alias",
+	Short: "Manage command aliases (short names for commands you type often)",
+	Long: `An alias replaces the first word of a command line with a longer command,
+in the shell and in the REPL. Anything after the alias is appended, so
+flags can still be added.`,
+	Example: `  laevitas config alias set bf perps carry BTC-PERPETUAL
+  laevitas bf -r 1d -n 30
+  laevitas config alias list
+  laevitas config alias unset bf`,
+}
+
+var aliasSetCmd = &cobra.Command{
+	Use:   "set <name> <command...>",
+	Short: "Create or replace an alias",
+	// Everything after the name is the aliased command, flags included
+	DisableFlagParsing: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 2 {
+			return cmd.Help()
+		}
+		name := args[0]
+		if shadowsCommand(cmd.Root(), name) {
+			return fmt.Errorf("%s is a laevitas command and can't be an alias", name)
+		}
+		command := strings.Join(args[1:], " ")
+		replaced, err := internalConfig.SetAlias(name, command)
+		if err != nil {
+			return err
+		}
+		if replaced {
+			output.Successf("Updated alias %s → %s", name, command)
+		} else {
+			output.Successf("Added alias %s → %s", name, command)
+		}
+		return nil
+	},
+}
+
+var aliasListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List aliases",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		aliases := internalConfig.Aliases()
+		if output.Resolve(cmdutil.OutputFormat) == output.FormatJSON {
+			if aliases == nil {
+				aliases = map[string]string{}
+			}
+			data, err := json.Marshal(aliases)
+			if err != nil {
+				return err
+			}
+			return output.NewPrinter("json").Print(data)
+		}
+		if len(aliases) == 0 {
+			fmt.Println("No aliases. Add one with: laevitas config alias set <name> <command...>")
+			return nil
+		}
+		names := make([]string, 0, len(aliases))
+		width := 0
+		for name := range aliases {
+			names = append(names, name)
+			width = max(width, len(name))
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Printf("  \033[1m%-*s\033[0m  \033[2m→\033[0m %s\n", width, name, aliases[name])
+		}
+		return nil
+	},
+}
+
+var aliasUnsetCmd = &cobra.Command{
+	Use:   "unset <name>",
+	Short: "Remove an alias",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := internalConfig.UnsetAlias(args[0]); err != nil {
+			return err
+		}
+		output.Successf("Removed alias %s", args[0])
+		return nil
+	},
+}
+
+// shadowsCommand reports whether an alias called name would hide one of
+// root's commands.
+func shadowsCommand(root *cobra.Command, name string) bool {
+	for _, c := range root.Commands() {
+		if c.Name() == name || c.HasAlias(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	aliasCmd.AddCommand(aliasSetCmd)
+	aliasCmd.AddCommand(aliasListCmd)
+	aliasCmd.AddCommand(aliasUnsetCmd)
+}
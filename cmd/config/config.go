@@ -2,8 +2,10 @@ package config
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -19,7 +21,7 @@ import (
 var Cmd = &cobra.Command{
 	Use:   "config",
 	Short: "Manage CLI configuration",
-	Long:  "Configure API key, default exchange, output format, and base URL.",
+	Long:  "Configure API key, default exchange, output format, base URL, and profiles.",
 }
 
 var initCmd = &cobra.Command{
@@ -43,23 +45,35 @@ var initCmd = &cobra.Command{
 		key, _ := reader.ReadString('\n')
 		key = strings.TrimSpace(key)
 		if key != "" {
-			cfg.APIKey = key
+			normalized, err := internalConfig.NormalizeSecret("api_key", key)
+			if err != nil {
+				return err
+			}
+			cfg.APIKey = normalized
 		}
 
 		// Default exchange
-		fmt.Printf("Default exchange (deribit/binance) [%s]: ", cfg.Exchange)
+		fmt.Printf("Default exchange (%s) [%s]: ", strings.Join(internalConfig.Exchanges, "/"), cfg.Exchange)
 		ex, _ := reader.ReadString('\n')
 		ex = strings.TrimSpace(ex)
 		if ex != "" {
-			cfg.Exchange = ex
+			normalized, err := internalConfig.NormalizeExchange(ex)
+			if err != nil {
+				return err
+			}
+			cfg.Exchange = normalized
 		}
 
 		// Output format
-		fmt.Printf("Default output format (auto/json/table/csv) [%s]: ", cfg.Output)
+		fmt.Printf("Default output format (%s) [%s]: ", strings.Join(internalConfig.Outputs, "/"), cfg.Output)
 		out, _ := reader.ReadString('\n')
 		out = strings.TrimSpace(out)
 		if out != "" {
-			cfg.Output = out
+			normalized, err := internalConfig.NormalizeOutput(out)
+			if err != nil {
+				return err
+			}
+			cfg.Output = normalized
 		}
 
 		// Wallet key (x402 payments)
@@ -71,7 +85,11 @@ var initCmd = &cobra.Command{
 		wk, _ := reader.ReadString('\n')
 		wk = strings.TrimSpace(wk)
 		if wk != "" {
-			cfg.WalletKey = wk
+			normalized, err := internalConfig.NormalizeSecret("wallet_key", wk)
+			if err != nil {
+				return err
+			}
+			cfg.WalletKey = normalized
 		}
 
 		// Auth type
@@ -91,7 +109,11 @@ var initCmd = &cobra.Command{
 		url, _ := reader.ReadString('\n')
 		url = strings.TrimSpace(url)
 		if url != "" {
-			cfg.BaseURL = url
+			normalized, err := internalConfig.NormalizeBaseURL(url)
+			if err != nil {
+				return err
+			}
+			cfg.BaseURL = normalized
 		}
 
 		if err := internalConfig.Save(cfg); err != nil {
@@ -105,7 +127,7 @@ var initCmd = &cobra.Command{
 		if cfg.APIKey != "" {
 			fmt.Print("Verifying API key... ")
 			client := api.NewClient(cfg)
-			_, err := client.Get(api.Health, nil)
+			_, err := client.Get(context.Background(), api.Health, nil)
 			if err != nil {
 				fmt.Println("✗")
 				output.Warnf("API key verification failed: %v", err)
@@ -138,12 +160,33 @@ var showCmd = &cobra.Command{
 			authDisplay = "auto"
 		}
 
+		profileDisplay := cfg.Profile
+		if profileDisplay == "" {
+			profileDisplay = internalConfig.DefaultProfile
+		}
+
+		fmt.Printf("Profile:    %s\n", profileDisplay)
 		fmt.Printf("API Key:    %s\n", keyDisplay)
 		fmt.Printf("Base URL:   %s\n", cfg.BaseURL)
 		fmt.Printf("Exchange:   %s\n", cfg.Exchange)
 		fmt.Printf("Output:     %s\n", cfg.Output)
 		fmt.Printf("Auth:       %s\n", authDisplay)
 
+		secretsDisplay := cfg.Secrets
+		if secretsDisplay == "" {
+			secretsDisplay = internalConfig.SecretsFile
+		}
+		fmt.Printf("Secrets:    %s\n", secretsDisplay)
+		if cfg.Pager != "" {
+			fmt.Printf("Pager:      %s\n", cfg.Pager)
+		}
+		if cfg.UserAgent != "" {
+			fmt.Printf("User-Agent: %s\n", cfg.UserAgent)
+		}
+		if cfg.RetryOn != "" {
+			fmt.Printf("Retry On:   %s\n", cfg.RetryOn)
+		}
+
 		// x402 payment info
 		if cfg.WalletKey != "" {
 			pc, err := x402.NewPaymentClient(cfg.WalletKey)
@@ -152,6 +195,16 @@ var showCmd = &cobra.Command{
 			} else {
 				fmt.Printf("Wallet:     %s\n", pc.Address())
 			}
+			if cfg.MaxPaymentUSD > 0 {
+				fmt.Printf("Max Pay:    %s USDC per request\n", strconv.FormatFloat(cfg.MaxPaymentUSD, 'f', -1, 64))
+			} else {
+				fmt.Printf("Max Pay:    (no cap)\n")
+			}
+			if threshold := cfg.LowCreditsThreshold(); threshold > 0 {
+				fmt.Printf("Low Credit: warn below %d\n", threshold)
+			} else {
+				fmt.Printf("Low Credit: (no warning)\n")
+			}
 			token := internalConfig.LoadCreditToken()
 			if token != "" {
 				fmt.Printf("x402 Token: %s...%s\n", token[:10], token[len(token)-6:])
@@ -166,7 +219,7 @@ var showCmd = &cobra.Command{
 
 var setCmd = &cobra.Command{
 	Use:   "set <key> <value>",
-	Short: "Set a config value (api_key, exchange, output, base_url, wallet_key, auth)",
+	Short: "Set a config value (api_key, exchange, output, base_url, wallet_key, auth, max_payment_usd, low_credits, pager, secrets, user_agent, retry_on)",
 	Args:  cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cfg, err := internalConfig.Load()
@@ -176,16 +229,43 @@ var setCmd = &cobra.Command{
 
 		key, value := args[0], args[1]
 
+		// Switching the secrets backend migrates every profile's keys
+		if strings.ToLower(key) == "secrets" {
+			value = strings.ToLower(value)
+			if err := internalConfig.SetSecretsBackend(value); err != nil {
+				return err
+			}
+			output.Successf("Set secrets = %s", value)
+			return nil
+		}
+
 		switch strings.ToLower(key) {
 		case "api_key", "apikey", "key":
+			if value, err = internalConfig.NormalizeSecret("api_key", value); err != nil {
+				return err
+			}
 			cfg.APIKey = value
 		case "exchange":
-			cfg.Exchange = value
+			ex, err := internalConfig.NormalizeExchange(value)
+			if err != nil {
+				return err
+			}
+			cfg.Exchange = ex
+			value = ex
 		case "output":
+			if value, err = internalConfig.NormalizeOutput(value); err != nil {
+				return err
+			}
 			cfg.Output = value
 		case "base_url", "baseurl", "url":
+			if value, err = internalConfig.NormalizeBaseURL(value); err != nil {
+				return err
+			}
 			cfg.BaseURL = value
 		case "wallet_key", "walletkey", "wallet":
+			if value, err = internalConfig.NormalizeSecret("wallet_key", value); err != nil {
+				return err
+			}
 			cfg.WalletKey = value
 		case "auth", "auth_type":
 			switch strings.ToLower(value) {
@@ -200,8 +280,33 @@ var setCmd = &cobra.Command{
 			default:
 				return fmt.Errorf("invalid auth type: %s (valid: auto, api-key, x402)", value)
 			}
+		case "max_payment_usd", "max_payment":
+			maxUSD, err := internalConfig.NormalizeMaxPayment(value)
+			if err != nil {
+				return err
+			}
+			cfg.MaxPaymentUSD = maxUSD
+			value = strconv.FormatFloat(maxUSD, 'f', -1, 64)
+		case "low_credits":
+			threshold, err := internalConfig.NormalizeLowCredits(value)
+			if err != nil {
+				return err
+			}
+			cfg.LowCredits = threshold
+			if threshold < 0 {
+				value = "off"
+			}
+		case "pager":
+			cfg.Pager = strings.TrimSpace(value)
+		case "user_agent", "user-agent":
+			cfg.UserAgent = strings.TrimSpace(value)
+		case "retry_on", "retry-on":
+			if value, err = internalConfig.NormalizeRetryOn(value); err != nil {
+				return err
+			}
+			cfg.RetryOn = value
 		default:
-			return fmt.Errorf("unknown config key: %s (valid: api_key, exchange, output, base_url, wallet_key, auth)", key)
+			return fmt.Errorf("unknown config key: %s (valid: api_key, exchange, output, base_url, wallet_key, auth, max_payment_usd, low_credits, pager, secrets, user_agent, retry_on)", key)
 		}
 
 		if err := internalConfig.Save(cfg); err != nil {
@@ -229,7 +334,7 @@ var setCmd = &cobra.Command{
 
 var unsetCmd = &cobra.Command{
 	Use:   "unset <key>",
-	Short: "Clear a config value (api_key, wallet_key)",
+	Short: "Clear a config value (api_key, wallet_key, max_payment_usd, low_credits, pager, user_agent, retry_on)",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cfg, err := internalConfig.Load()
@@ -245,8 +350,18 @@ var unsetCmd = &cobra.Command{
 		case "wallet_key", "walletkey", "wallet":
 			cfg.WalletKey = ""
 			internalConfig.ClearCreditToken()
+		case "max_payment_usd", "max_payment":
+			cfg.MaxPaymentUSD = 0
+		case "low_credits":
+			cfg.LowCredits = 0
+		case "pager":
+			cfg.Pager = ""
+		case "user_agent", "user-agent":
+			cfg.UserAgent = ""
+		case "retry_on", "retry-on":
+			cfg.RetryOn = ""
 		default:
-			return fmt.Errorf("unknown config key: %s (valid: api_key, wallet_key)", key)
+			return fmt.Errorf("unknown config key: %s (valid: api_key, wallet_key, max_payment_usd, low_credits, pager, user_agent, retry_on)", key)
 		}
 
 		if err := internalConfig.Save(cfg); err != nil {
@@ -276,4 +391,12 @@ func init() {
 	Cmd.AddCommand(setCmd)
 	Cmd.AddCommand(unsetCmd)
 	Cmd.AddCommand(pathCmd)
+	Cmd.AddCommand(exportCmd)
+	Cmd.AddCommand(importCmd)
+	Cmd.AddCommand(profileCmd)
+	Cmd.AddCommand(aliasCmd)
+	Cmd.AddCommand(doctorCmd)
+	Cmd.AddCommand(walletBalanceCmd)
+	Cmd.AddCommand(paymentsCmd)
+	Cmd.AddCommand(creditsCmd)
 }
@@ -0,0 +1,101 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/laevitas/cli/internal/api"
+	internalConfig "github.com/laevitas/cli/internal/config"
+	"github.com/laevitas/cli/internal/output"
+	"github.com/laevitas/cli/internal/x402"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check configuration, credentials, and connectivity",
+	Long: `Run a checklist of common setup problems: config file, API key,
+API reachability, wallet key, x402 credit token, and terminal detection.
+Exits non-zero if a critical check fails.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		failed := 0
+
+		// Config file
+		path, err := internalConfig.CheckFile()
+		switch {
+		case err == nil:
+			output.Successf("Config file %s is valid", path)
+		case os.IsNotExist(err):
+			output.Warnf("No config file at %s (run: laevitas config init)", path)
+		default:
+			output.Errorf("Config file %s: %s", path, err)
+			failed++
+		}
+
+		cfg, err := internalConfig.Load()
+		if err != nil {
+			output.Errorf("Loading config: %s", err)
+			return fmt.Errorf("%d check(s) failed", failed+1)
+		}
+		if cfg.Profile != "" {
+			output.Successf("Using profile %s", cfg.Profile)
+		}
+
+		// API key
+		switch {
+		case cfg.APIKey == "" && cfg.WalletKey == "":
+			output.Errorf("No API key or wallet key configured (run: laevitas config init)")
+			failed++
+		case cfg.APIKey == "":
+			output.Warnf("No API key configured (wallet key will be used for x402 payments)")
+		case strings.TrimSpace(cfg.APIKey) != cfg.APIKey || strings.ContainsAny(cfg.APIKey, " \t\r\n"):
+			output.Errorf("API key %s contains whitespace (re-run: laevitas config set api_key <key>)", internalConfig.MaskKey(cfg.APIKey))
+			failed++
+		case len(cfg.APIKey) < 16:
+			output.Warnf("API key %s looks too short", internalConfig.MaskKey(cfg.APIKey))
+		default:
+			output.Successf("API key %s is set", internalConfig.MaskKey(cfg.APIKey))
+		}
+
+		// Reachability
+		client := api.NewClient(cfg)
+		if _, err := client.Get(context.Background(), api.Health, nil); err != nil {
+			output.Errorf("API at %s is not reachable: %s", cfg.BaseURL, err)
+			failed++
+		} else {
+			output.Successf("API at %s is reachable", cfg.BaseURL)
+		}
+
+		// Wallet key and credit token (x402)
+		if cfg.WalletKey != "" {
+			pc, err := x402.NewPaymentClient(cfg.WalletKey)
+			if err != nil {
+				output.Errorf("Wallet key is invalid: %s", err)
+				failed++
+			} else {
+				output.Successf("Wallet key resolves to %s", pc.Address())
+			}
+			if internalConfig.LoadCreditToken() != "" {
+				output.Successf("x402 credit token present")
+			} else {
+				output.Warnf("No x402 credit token yet (one is issued on the first paid request)")
+			}
+		}
+
+		// Terminal
+		if output.IsTTY() {
+			output.Successf("Terminal detected (auto output: table with colors)")
+		} else {
+			output.Warnf("Stdout is not a terminal (auto output: json)")
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d check(s) failed", failed)
+		}
+		return nil
+	},
+}
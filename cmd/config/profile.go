@@ -0,0 +1,86 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/laevitas/cli/internal/cmdutil"
+	internalConfig "github.com/laevitas/cli/internal/config"
+	"github.com/laevitas/cli/internal/output"
+)
+
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage named config profiles (e.g. prod, staging)",
+	Long: `Profiles keep separate API keys, wallet keys, exchanges, output formats,
+and base URLs in config.json. The top-level settings form the "default"
+profile; a named profile overrides them when active.
+
+While a profile is current, "config set" writes into that profile.
+"config unset" (or setting 0, off or "") clears a value in the profile
+only, e.g. no payment cap or no pager even when the default has one.
+Use --profile <name> to select a profile for a single command.`,
+	Example: `  laevitas config profile use staging
+  laevitas config set base_url https://staging.example.com
+  laevitas config profile use default
+  laevitas futures snapshot --profile staging`,
+}
+
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List profiles (current marked with *)",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		names, current := internalConfig.ProfileNames()
+		for _, name := range names {
+			if name == current {
+				fmt.Printf("* \033[1m%s\033[0m\n", name)
+			} else {
+				fmt.Printf("  %s\n", name)
+			}
+		}
+	},
+}
+
+var profileUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Switch the current profile, creating it if needed",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		created, err := internalConfig.UseProfile(name)
+		if err != nil {
+			return err
+		}
+
+		// Reset shared client so the next command uses the new profile
+		cmdutil.SharedClient = nil
+
+		if created {
+			output.Successf("Created profile %s (inherits default settings until you run config set)", name)
+		}
+		output.Successf("Using profile %s", name)
+		return nil
+	},
+}
+
+var profileDeleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Delete a named profile",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := internalConfig.DeleteProfile(args[0]); err != nil {
+			return err
+		}
+		cmdutil.SharedClient = nil
+		output.Successf("Deleted profile %s", args[0])
+		return nil
+	},
+}
+
+func init() {
+	profileCmd.AddCommand(profileListCmd)
+	profileCmd.AddCommand(profileUseCmd)
+	profileCmd.AddCommand(profileDeleteCmd)
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/laevitas/cli/internal/cmdutil"
+	internalConfig "github.com/laevitas/cli/internal/config"
+	"github.com/laevitas/cli/internal/output"
+)
+
+var exportIncludeSecrets bool
+
+var exportCmd = &cobra.Command{
+	Use:   "export <file>",
+	Short: "Write the whole configuration to a file, for moving to another machine",
+	Long: `Write every profile, alias and color rule to a file in the config.json
+format. api_key and wallet_key are left out unless --include-secrets is
+given, so the file is safe to share; keys kept in the keychain are read out
+when they are included.`,
+	Example: `  laevitas config export laevitas-config.json
+  laevitas config export backup.json --include-secrets`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := internalConfig.ExportConfig(exportIncludeSecrets)
+		if err != nil {
+			return err
+		}
+		if err := internalConfig.WriteConfigFile(args[0], cfg); err != nil {
+			return fmt.Errorf("writing %s: %w", args[0], err)
+		}
+		output.Successf("Exported config (%d profiles, %d aliases) to %s", len(cfg.Profiles), len(cfg.Aliases), args[0])
+		if exportIncludeSecrets {
+			output.Warnf("%s contains your API and wallet keys — keep it private", args[0])
+		} else {
+			output.Warnf("api_key and wallet_key were left out (use --include-secrets to export them)")
+		}
+		return nil
+	},
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Merge a configuration written by \"config export\"",
+	Long: `Merge a file written by "config export" (or another machine's config.json)
+into the local configuration. Each setting the file has replaces the local
+one, at the top level and per profile; settings it leaves out — such as
+redacted keys — keep their local values. Aliases and color rules are merged
+by name. Keys are stored in the local secrets backend.`,
+	Example: `  laevitas config import laevitas-config.json
+  laevitas config import laevitas-config.json && laevitas config set api_key YOUR_KEY`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		in, err := internalConfig.ReadConfigFile(args[0])
+		if err != nil {
+			return err
+		}
+		for _, name := range slices.Sorted(maps.Keys(in.Aliases)) {
+			if shadowsCommand(cmd.Root(), name) {
+				output.Warnf("Skipped alias %s: it would hide the laevitas command of that name", name)
+				delete(in.Aliases, name)
+			}
+		}
+		if err := internalConfig.ImportConfig(in); err != nil {
+			return fmt.Errorf("saving: %w", err)
+		}
+
+		// Keys or the base URL may have changed
+		cmdutil.SharedClient = nil
+
+		output.Successf("Imported %s (%d profiles, %d aliases)", args[0], len(in.Profiles), len(in.Aliases))
+		return nil
+	},
+}
+
+func init() {
+	exportCmd.Flags().BoolVar(&exportIncludeSecrets, "include-secrets", false, "Include api_key and wallet_key in the file")
+}
@@ -0,0 +1,171 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/laevitas/cli/internal/api"
+	"github.com/laevitas/cli/internal/cmdutil"
+	internalConfig "github.com/laevitas/cli/internal/config"
+	"github.com/laevitas/cli/internal/output"
+	"github.com/laevitas/cli/internal/x402"
+)
+
+// ─── wallet-balance ─────────────────────────────────────────────────────────
+
+var walletBalanceRPC string
+
+var walletBalanceCmd = &cobra.Command{
+	Use:   "wallet-balance",
+	Short: "Show the USDC balance on Base of the configured x402 wallet",
+	Long: `Look up the USDC balance on Base of the wallet configured with
+"config set wallet_key", so you can top up before x402 payments fail.`,
+	Example: `  laevitas config wallet-balance
+  laevitas config wallet-balance -o json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := internalConfig.Load()
+		if err != nil {
+			return err
+		}
+		if cfg.WalletKey == "" {
+			output.Warnf("No wallet configured. Set one with `laevitas config set wallet_key <key>`.")
+			return nil
+		}
+		pc, err := x402.NewPaymentClient(cfg.WalletKey)
+		if err != nil {
+			return err
+		}
+
+		ctx, stop := cmdutil.SignalContext()
+		defer stop()
+		balance, err := x402.USDCBalance(ctx, walletBalanceRPC, pc.Address())
+		if err != nil {
+			return fmt.Errorf("fetching balance: %w", err)
+		}
+
+		if output.Resolve(cmdutil.OutputFormat) == output.FormatJSON {
+			return output.NewPrinter("json").Print(map[string]string{
+				"address":      pc.Address(),
+				"network":      "base",
+				"balance_usdc": x402.FormatUSDC(balance),
+			})
+		}
+		fmt.Printf("Wallet:   %s\n", pc.Address())
+		fmt.Printf("Network:  Base\n")
+		fmt.Printf("Balance:  %s USDC\n", x402.FormatUSDC(balance))
+		if balance.Sign() == 0 {
+			output.Warnf("Wallet is empty — x402 payments will fail until it holds USDC on Base.")
+		}
+		return nil
+	},
+}
+
+// ─── payments ───────────────────────────────────────────────────────────────
+
+var paymentsCmd = &cobra.Command{
+	Use:   "payments",
+	Short: "List the on-chain x402 payments made by this CLI",
+	Long: `Print payments.log, the record of every on-chain x402 payment: time,
+endpoint, amount, wallet, and the settlement transaction or credit token.`,
+	Example: `  laevitas config payments
+  laevitas config payments -o csv > payments.csv`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		records, err := internalConfig.LoadPayments()
+		if err != nil {
+			return err
+		}
+		if len(records) == 0 {
+			path, _ := internalConfig.PaymentsPath()
+			output.Warnf("No payments recorded yet (%s).", path)
+			return nil
+		}
+
+		// Encode first so table/csv render the records like API rows
+		data, err := json.Marshal(records)
+		if err != nil {
+			return err
+		}
+		printer := cmdutil.MustPrinter()
+		if err := printer.Print(data); err != nil {
+			return err
+		}
+		if printer.Format == output.FormatTable && !output.Quiet {
+			total := new(big.Rat)
+			for _, r := range records {
+				if amount, ok := new(big.Rat).SetString(r.AmountUSDC); ok {
+					total.Add(total, amount)
+				}
+			}
+			fmt.Printf("Total: %s USDC\n", total.FloatString(6))
+		}
+		return nil
+	},
+}
+
+// ─── credits ────────────────────────────────────────────────────────────────
+
+var creditsCmd = &cobra.Command{
+	Use:   "credits",
+	Short: "Show the remaining x402 credits",
+	Long: `Make a free health request and print the x402 credit balance the API
+reports. If the API doesn't report one, the last balance seen is shown.`,
+	Example: `  laevitas config credits
+  laevitas config credits -o json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := internalConfig.Load()
+		if err != nil {
+			return err
+		}
+		if cfg.WalletKey == "" {
+			output.Warnf("No wallet configured — credits only apply to x402 payments. Set one with `laevitas config set wallet_key <key>`.")
+			return nil
+		}
+
+		client, _ := cmdutil.MustClient()
+		if client == nil {
+			return nil
+		}
+		ctx, stop := cmdutil.SignalContext()
+		defer stop()
+		if _, err := client.Get(ctx, api.Health, nil); err != nil {
+			return err
+		}
+
+		seen := internalConfig.CreditsSeen{Remaining: client.LastMeta().Credits, SeenAt: time.Now().UTC()}
+		live := seen.Remaining != ""
+		if !live {
+			var ok bool
+			if seen, ok = internalConfig.LoadCreditsSeen(); !ok {
+				output.Warnf("No credit balance reported yet — credits are issued with the first on-chain payment.")
+				return nil
+			}
+		}
+
+		if output.Resolve(cmdutil.OutputFormat) == output.FormatJSON {
+			return output.NewPrinter("json").Print(map[string]interface{}{
+				"credits_remaining": json.Number(seen.Remaining),
+				"seen_at":           seen.SeenAt,
+				"live":              live,
+			})
+		}
+		fmt.Printf("Credits:  %s remaining\n", output.FormatNumber(seen.Remaining))
+		if !live {
+			fmt.Printf("As of:    %s (last response that reported credits)\n", seen.SeenAt.Local().Format("2006-01-02 15:04:05"))
+		}
+		if threshold := cfg.LowCreditsThreshold(); threshold > 0 {
+			fmt.Printf("Warn at:  below %d\n", threshold)
+		}
+		return nil
+	},
+}
+
+func init() {
+	walletBalanceCmd.Flags().StringVar(&walletBalanceRPC, "rpc", x402.BaseRPCURL, "Base JSON-RPC endpoint")
+}
@@ -0,0 +1,285 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/laevitas/cli/internal/api"
+	"github.com/laevitas/cli/internal/cmdutil"
+	"github.com/laevitas/cli/internal/config"
+	"github.com/laevitas/cli/internal/output"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <command> [args...] -- <command> [args...]",
+	Short: "Compare two queries row by row and show numeric deltas",
+	Long: `Diff runs two queries, aligns their rows by instrument_name and prints
+the change (second minus first) for every numeric column.
+
+Instruments that only appear in the second result are marked "new",
+instruments that disappeared are marked "removed". Rows and columns with
+no change are omitted. This is a one-shot version of watch mode's change
+highlighting.
+
+Each side takes its own command flags, --exchange included. -o and
+--explain may be written on either side and apply to the whole diff.`,
+	Example: `  laevitas diff futures snapshot --currency BTC --date 2026-01-14 -- futures snapshot --currency BTC
+  laevitas diff perps snapshot --currency BTC --exchange binance -- perps snapshot --currency BTC --exchange bybit`,
+	DisableFlagParsing: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Handle --help / -h manually since DisableFlagParsing is true
+		for _, a := range args {
+			if a == "--" {
+				break
+			}
+			if a == "--help" || a == "-h" {
+				return cmd.Help()
+			}
+		}
+		return runDiff(args)
+	},
+}
+
+// diffKeyColumn is the column rows are aligned on when present.
+const diffKeyColumn = "instrument_name"
+
+// diffRow is one row of a query result, keyed for alignment.
+type diffRow struct {
+	key    string
+	values map[string]string
+}
+
+func runDiff(args []string) error {
+	// Flag parsing is disabled, so --explain and -o are picked out here,
+	// on either side; they apply to the diff as a whole
+	rest := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		name, value, hasValue := strings.Cut(args[i], "=")
+		switch name {
+		case "--explain":
+			cmdutil.Explain = true
+		case "-o", "--output":
+			if !hasValue {
+				if i+1 >= len(args) {
+					return fmt.Errorf("flag needs an argument: %s", name)
+				}
+				i++
+				value = args[i]
+			}
+			format, err := config.NormalizeOutput(value)
+			if err != nil {
+				return err
+			}
+			cmdutil.OutputFormat = format
+		default:
+			rest = append(rest, args[i])
+		}
+	}
+	args = rest
+
+	sep := -1
+	for i, a := range args {
+		if a == "--" {
+			sep = i
+			break
+		}
+	}
+	if sep <= 0 || sep == len(args)-1 {
+		return fmt.Errorf("usage: diff <command> [args...] -- <command> [args...]")
+	}
+	argsA, argsB := args[:sep], args[sep+1:]
+
+	endpointA, paramsA, err := resolveDiffCommand(argsA)
+	if err != nil {
+		return err
+	}
+	endpointB, paramsB, err := resolveDiffCommand(argsB)
+	if err != nil {
+		return err
+	}
+
+	client, _ := cmdutil.MustClient()
+	if client == nil {
+		return fmt.Errorf("no API client available")
+	}
+	if cmdutil.Explain {
+		cmdutil.ExplainRequest(client, endpointA, paramsA)
+		cmdutil.ExplainRequest(client, endpointB, paramsB)
+		return nil
+	}
+
+	cmdutil.StartSpinner()
+	ctx, stop := cmdutil.SignalContext()
+	defer stop()
+	dataA, errA := client.Get(ctx, endpointA, paramsA)
+	var dataB []byte
+	var errB error
+	if errA == nil {
+		dataB, errB = client.Get(ctx, endpointB, paramsB)
+	}
+	cmdutil.StopSpinner()
+	if cmdutil.IsCancelled(errA) || cmdutil.IsCancelled(errB) {
+		return context.Canceled
+	}
+	if errA != nil {
+		return fmt.Errorf("%s: %w", strings.Join(argsA, " "), errA)
+	}
+	if errB != nil {
+		return fmt.Errorf("%s: %w", strings.Join(argsB, " "), errB)
+	}
+
+	result, unchanged := diffResults(watchParseJSON(dataA), watchParseJSON(dataB))
+
+	p := cmdutil.MustPrinter()
+	if len(result) == 0 {
+		if p.Format == output.FormatTable {
+			fmt.Println("No differences.")
+			return nil
+		}
+		return p.Print([]map[string]interface{}{})
+	}
+	if err := p.Print(result); err != nil {
+		return err
+	}
+	if unchanged > 0 && p.Format == output.FormatTable && !output.Quiet {
+		fmt.Fprintf(os.Stderr, "%s%d unchanged rows hidden%s\n", wDim, unchanged, wReset)
+	}
+	return nil
+}
+
+// resolveDiffCommand resolves one side of a diff to its endpoint and params.
+func resolveDiffCommand(args []string) (string, *api.RequestParams, error) {
+	endpoint, params, err := resolveWatchCommand(args)
+	if err != nil {
+		return "", nil, fmt.Errorf("cannot diff %q: %s", strings.Join(args, " "), err)
+	}
+	return endpoint, params, nil
+}
+
+// diffResults aligns two parsed result grids (as produced by watchParseJSON)
+// and returns one record per changed row plus the number of unchanged rows.
+func diffResults(rowsA, rowsB [][]string) ([]map[string]interface{}, int) {
+	a := diffIndexRows(rowsA)
+	b := diffIndexRows(rowsB)
+
+	// Numeric columns are those numeric on both sides, excluding timestamps
+	numericA := diffNumericColumns(rowsA)
+	numericB := diffNumericColumns(rowsB)
+	var columns []string
+	if len(rowsB) > 0 {
+		for _, h := range rowsB[0] {
+			if numericA[h] && numericB[h] && h != diffKeyColumn && !diffIsTimeColumn(h) {
+				columns = append(columns, h)
+			}
+		}
+	}
+
+	inB := make(map[string]bool, len(b))
+	for _, row := range b {
+		inB[row.key] = true
+	}
+	byKeyA := make(map[string]diffRow, len(a))
+	for _, row := range a {
+		byKeyA[row.key] = row
+	}
+
+	var result []map[string]interface{}
+	unchanged := 0
+
+	for _, rowB := range b {
+		rowA, found := byKeyA[rowB.key]
+		if !found {
+			result = append(result, map[string]interface{}{
+				diffKeyColumn: rowB.key,
+				"status":      "new",
+			})
+			continue
+		}
+
+		rec := map[string]interface{}{diffKeyColumn: rowB.key}
+		for _, col := range columns {
+			prev, okA := rowA.values[col]
+			curr, okB := rowB.values[col]
+			if !okA || !okB || watchCompare(curr, prev) == 0 {
+				continue
+			}
+			c, _ := strconv.ParseFloat(curr, 64)
+			pv, _ := strconv.ParseFloat(prev, 64)
+			rec[col+"_change"] = c - pv
+		}
+		if len(rec) == 1 {
+			unchanged++
+			continue
+		}
+		result = append(result, rec)
+	}
+
+	for _, rowA := range a {
+		if !inB[rowA.key] {
+			result = append(result, map[string]interface{}{
+				diffKeyColumn: rowA.key,
+				"status":      "removed",
+			})
+		}
+	}
+
+	return result, unchanged
+}
+
+// diffIndexRows keys each data row by instrument_name, falling back to the
+// row position (like watch mode) when the column is missing.
+func diffIndexRows(rows [][]string) []diffRow {
+	if len(rows) < 2 {
+		return nil
+	}
+	headers := rows[0]
+	keyCol := -1
+	for i, h := range headers {
+		if h == diffKeyColumn {
+			keyCol = i
+			break
+		}
+	}
+
+	out := make([]diffRow, 0, len(rows)-1)
+	for r, row := range rows[1:] {
+		key := strconv.Itoa(r)
+		if keyCol >= 0 && keyCol < len(row) && row[keyCol] != "" {
+			key = row[keyCol]
+		}
+		values := make(map[string]string, len(headers))
+		for c, cell := range row {
+			if c < len(headers) && cell != "" {
+				values[headers[c]] = cell
+			}
+		}
+		out = append(out, diffRow{key: key, values: values})
+	}
+	if keyCol >= 0 {
+		sort.SliceStable(out, func(i, j int) bool { return out[i].key < out[j].key })
+	}
+	return out
+}
+
+func diffNumericColumns(rows [][]string) map[string]bool {
+	m := map[string]bool{}
+	if len(rows) < 2 {
+		return m
+	}
+	for i, numeric := range watchDetectNumeric(rows[0], rows[1:]) {
+		m[rows[0][i]] = numeric
+	}
+	return m
+}
+
+func diffIsTimeColumn(h string) bool {
+	h = strings.ToLower(h)
+	return strings.Contains(h, "time") || strings.Contains(h, "date") ||
+		strings.HasSuffix(h, "_at") || h == "minute" || strings.HasPrefix(h, "expir")
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestDiffPeriod checks each side of a diff gets the window its own
+// --period asks for, as when the command runs on its own.
+func TestDiffPeriod(t *testing.T) {
+	queries := serveQueries(t)
+
+	rootCmd.SetArgs([]string{"diff",
+		"perps", "carry", "BTC-PERPETUAL", "-p", "24h", "--",
+		"perps", "carry", "BTC-PERPETUAL", "--period", "3d"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("diff: %v", err)
+	}
+	t.Cleanup(resetFlags)
+
+	got := queries()
+	if len(got) != 2 {
+		t.Fatalf("got %d requests, want 2", len(got))
+	}
+	if w := queryWindow(t, got[0]); w != 24*time.Hour {
+		t.Errorf("first side: window %s, want 24h", w)
+	}
+	if w := queryWindow(t, got[1]); w != 72*time.Hour {
+		t.Errorf("second side: window %s, want 72h", w)
+	}
+}
+
+func TestDiffValidatesTimeFlags(t *testing.T) {
+	queries := serveQueries(t)
+
+	rootCmd.SetArgs([]string{"diff",
+		"perps", "carry", "BTC-PERPETUAL", "--since", "2h", "--start", "2026-01-01", "--",
+		"perps", "carry", "BTC-PERPETUAL"})
+	err := rootCmd.Execute()
+	t.Cleanup(resetFlags)
+	if err == nil || !strings.Contains(err.Error(), "--since") {
+		t.Fatalf("got %v, want a --since/--start conflict error", err)
+	}
+	if n := len(queries()); n != 0 {
+		t.Errorf("sent %d requests for an invalid diff", n)
+	}
+}
+
+// TestDiffExchangePerSide checks each side's own --exchange reaches its
+// request; flags after the command name are never seen by the root command.
+func TestDiffExchangePerSide(t *testing.T) {
+	queries := serveQueries(t)
+
+	rootCmd.SetArgs([]string{"diff",
+		"perps", "carry", "BTC-PERPETUAL", "--exchange", "binance", "--",
+		"perps", "carry", "BTC-PERPETUAL", "--exchange", "bybit"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("diff: %v", err)
+	}
+	t.Cleanup(resetFlags)
+
+	got := queries()
+	if len(got) != 2 {
+		t.Fatalf("got %d requests, want 2", len(got))
+	}
+	if ex := got[0].Get("exchange"); ex != "binance" {
+		t.Errorf("first side: exchange = %q, want binance", ex)
+	}
+	if ex := got[1].Get("exchange"); ex != "bybit" {
+		t.Errorf("second side: exchange = %q, want bybit", ex)
+	}
+}
+
+// TestDiffOutputFlag checks -o written inside a side applies to the diff
+// instead of being parsed away with that side's flags.
+func TestDiffOutputFlag(t *testing.T) {
+	serveQueries(t)
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	rootCmd.SetArgs([]string{"diff",
+		"perps", "carry", "BTC-PERPETUAL", "--",
+		"perps", "carry", "BTC-PERPETUAL", "-o", "table"})
+	err = rootCmd.Execute()
+	os.Stdout = stdout
+	w.Close()
+	t.Cleanup(resetFlags)
+	if err != nil {
+		t.Fatalf("diff: %v", err)
+	}
+
+	out, _ := io.ReadAll(r)
+	// Piped stdout would default to JSON; -o table prints the message
+	if got := strings.TrimSpace(string(out)); got != "No differences." {
+		t.Errorf("got %q, want the table-mode message", got)
+	}
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/laevitas/cli/internal/api"
+	"github.com/laevitas/cli/internal/cmdutil"
+	"github.com/laevitas/cli/internal/output"
+)
+
+// exchangeMarkets are the markets `exchanges` knows, in display order, with
+// the catalog each one is discovered from.
+var exchangeMarkets = []struct{ name, endpoint string }{
+	{"futures", api.FuturesCatalog},
+	{"perps", api.PerpsCatalog},
+	{"options", api.OptionsCatalog},
+}
+
+var exchangesCmd = &cobra.Command{
+	Use:   "exchanges [futures|perps|options]",
+	Short: "List the exchanges that have data for each market",
+	Long: `List the exchanges found in each market's instrument catalog, with how
+many instruments each one lists — the values --exchange accepts for that
+market's commands.`,
+	Example: `  laevitas exchanges
+  laevitas exchanges options
+  laevitas exchanges perps -o json`,
+	ValidArgs: []string{"futures", "perps", "options"},
+	Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, _ := cmdutil.MustClient()
+		if client == nil {
+			return fmt.Errorf("no API client available")
+		}
+		if cmdutil.Explain {
+			for _, m := range exchangeMarkets {
+				if len(args) == 0 || args[0] == m.name {
+					cmdutil.ExplainRequest(client, m.endpoint, &api.RequestParams{})
+				}
+			}
+			return nil
+		}
+
+		ctx, stop := cmdutil.SignalContext()
+		defer stop()
+
+		type exchangeRow struct {
+			Market      string `json:"market"`
+			Exchange    string `json:"exchange"`
+			Instruments int    `json:"instruments"`
+		}
+		rows := []exchangeRow{}
+		for _, m := range exchangeMarkets {
+			if len(args) == 1 && args[0] != m.name {
+				continue
+			}
+			data, err := client.Get(ctx, m.endpoint, &api.RequestParams{})
+			if err != nil {
+				return fmt.Errorf("%s catalog: %w", m.name, err)
+			}
+			counts, err := catalogExchanges(data)
+			if err != nil {
+				return fmt.Errorf("%s catalog: %w", m.name, err)
+			}
+			if len(counts) == 0 {
+				output.Warnf("%s catalog lists no exchange field", m.name)
+				continue
+			}
+			names := make([]string, 0, len(counts))
+			for name := range counts {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				rows = append(rows, exchangeRow{m.name, name, counts[name]})
+			}
+		}
+
+		out, err := json.Marshal(rows)
+		if err != nil {
+			return err
+		}
+		if err := cmdutil.MustPrinter().Print(out); err != nil {
+			return err
+		}
+		// Scripts get an empty array, and a non-zero exit to notice it by
+		if len(rows) == 0 {
+			return fmt.Errorf("no exchanges found in the catalogs")
+		}
+		return nil
+	},
+}
+
+// catalogExchanges counts a catalog's instruments per exchange. The catalog
+// is either a bare array or wrapped in {"data": [...]}.
+func catalogExchanges(data []byte) (map[string]int, error) {
+	type entry struct {
+		Exchange string `json:"exchange"`
+	}
+	var entries []entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		var wrapper struct {
+			Data []entry `json:"data"`
+		}
+		if err := json.Unmarshal(data, &wrapper); err != nil {
+			return nil, fmt.Errorf("parsing response: %w", err)
+		}
+		entries = wrapper.Data
+	}
+	counts := map[string]int{}
+	for _, e := range entries {
+		if e.Exchange != "" {
+			counts[e.Exchange]++
+		}
+	}
+	return counts, nil
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestExchangesNoneFound checks catalogs without an exchange field give an
+// empty JSON array, not null, and an error for the exit status.
+func TestExchangesNoneFound(t *testing.T) {
+	serveQueries(t)
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	rootCmd.SetArgs([]string{"exchanges", "perps", "-o", "json"})
+	err = rootCmd.Execute()
+	os.Stdout = stdout
+	w.Close()
+	t.Cleanup(resetFlags)
+
+	if err == nil {
+		t.Error("no error when no exchange was found")
+	}
+	out, _ := io.ReadAll(r)
+	if got := strings.TrimSpace(string(out)); got != "[]" {
+		t.Errorf("got %q, want []", got)
+	}
+}
@@ -10,7 +10,7 @@ import (
 var Cmd = &cobra.Command{
 	Use:   "futures",
 	Short: "Dated futures data — catalog, OHLCVT, OI, carry, trades",
-	Long: `Access dated futures data from Deribit and Binance.
+	Long: `Access dated futures data from Deribit, Binance, Bybit, and OKX.
 
 Examples:
   laevitas futures catalog
@@ -23,15 +23,18 @@ Examples:
 
 // ─── catalog ────────────────────────────────────────────────────────────────
 
+var catalogFlags cmdutil.CatalogFlags
+
 var catalogCmd = &cobra.Command{
 	Use:   "catalog",
 	Short: "List all available dated futures instruments",
 	Example: `  laevitas futures catalog
-  laevitas futures catalog --exchange binance`,
+  laevitas futures catalog --exchange binance
+  laevitas futures catalog --currency BTC --expires-before 30d`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client, _ := cmdutil.MustClient()
 		params := &api.RequestParams{Exchange: cmdutil.Exchange}
-		cmdutil.RunAndPrint(client, api.FuturesCatalog, params)
+		cmdutil.RunCatalog(client, api.FuturesCatalog, params, &catalogFlags)
 	},
 }
 
@@ -46,7 +49,8 @@ var snapshotCmd = &cobra.Command{
 	Use:   "snapshot",
 	Short: "Full market snapshot of ALL dated futures at a point in time",
 	Example: `  laevitas futures snapshot --currency BTC
-  laevitas futures snapshot --currency ETH --date 2025-02-01T12:00:00Z`,
+  laevitas futures snapshot --currency ETH --date 2025-02-01T12:00:00Z
+  laevitas futures snapshot --currency BTC,ETH`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client, _ := cmdutil.MustClient()
 		params := &api.RequestParams{
@@ -66,13 +70,13 @@ var ohlcvCmd = &cobra.Command{
 	Use:     "ohlcvt <instrument>",
 	Aliases: []string{"ohlcv"},
 	Short:   "OHLCVT candle data from trades",
-	Args:    cobra.ExactArgs(1),
+	Args:    cmdutil.InstrumentArgs,
 	Example: `  laevitas futures ohlcvt BTC-27MAR26 -p 24h
   laevitas futures ohlcvt BTC-27MAR26 -p 3d -r 1h -n 50`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client, _ := cmdutil.MustClient()
 		params := ohlcvFlags.ToParams()
-		params.InstrumentName = args[0]
+		params.InstrumentName = cmdutil.InstrumentArg(args)
 		cmdutil.RunAndPrint(client, api.FuturesOHLCVT, params)
 	},
 }
@@ -85,13 +89,13 @@ var oiCmd = &cobra.Command{
 	Use:     "oi <instrument>",
 	Aliases: []string{"open-interest"},
 	Short:   "Open interest data over time",
-	Args:    cobra.ExactArgs(1),
+	Args:    cmdutil.InstrumentArgs,
 	Example: `  laevitas futures oi BTC-27MAR26 -p 7d
   laevitas futures oi BTC-27MAR26 -p 30d -r 1d`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client, _ := cmdutil.MustClient()
 		params := oiFlags.ToParams()
-		params.InstrumentName = args[0]
+		params.InstrumentName = cmdutil.InstrumentArg(args)
 		cmdutil.RunAndPrint(client, api.FuturesOpenInterest, params)
 	},
 }
@@ -104,13 +108,13 @@ var carryCmd = &cobra.Command{
 	Use:     "carry <instrument>",
 	Aliases: []string{"basis"},
 	Short:   "Basis and annualized carry data",
-	Args:    cobra.ExactArgs(1),
+	Args:    cmdutil.InstrumentArgs,
 	Example: `  laevitas futures carry BTC-27MAR26 -p 24h
   laevitas futures carry BTC-27MAR26 -p 7d -r 1h`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client, _ := cmdutil.MustClient()
 		params := carryFlags.ToParams()
-		params.InstrumentName = args[0]
+		params.InstrumentName = cmdutil.InstrumentArg(args)
 		cmdutil.RunAndPrint(client, api.FuturesCarry, params)
 	},
 }
@@ -165,13 +169,13 @@ var volumeFlags cmdutil.CommonFlags
 var volumeCmd = &cobra.Command{
 	Use:   "volume <instrument>",
 	Short: "24h rolling volume data",
-	Args:  cobra.ExactArgs(1),
+	Args:  cmdutil.InstrumentArgs,
 	Example: `  laevitas futures volume BTC-27MAR26 -p 24h
   laevitas futures volume BTC-27MAR26 -p 7d -r 1h`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client, _ := cmdutil.MustClient()
 		params := volumeFlags.ToParams()
-		params.InstrumentName = args[0]
+		params.InstrumentName = cmdutil.InstrumentArg(args)
 		cmdutil.RunAndPrint(client, api.FuturesVolume, params)
 	},
 }
@@ -183,13 +187,13 @@ var level1Flags cmdutil.CommonFlags
 var level1Cmd = &cobra.Command{
 	Use:   "level1 <instrument>",
 	Short: "Best bid/ask (L1) data over time",
-	Args:  cobra.ExactArgs(1),
+	Args:  cmdutil.InstrumentArgs,
 	Example: `  laevitas futures level1 BTC-27MAR26 -p 24h
   laevitas futures level1 BTC-27MAR26 -p 3d -r 1h`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client, _ := cmdutil.MustClient()
 		params := level1Flags.ToParams()
-		params.InstrumentName = args[0]
+		params.InstrumentName = cmdutil.InstrumentArg(args)
 		cmdutil.RunAndPrint(client, api.FuturesLevel1, params)
 	},
 }
@@ -201,17 +205,35 @@ var orderbookFlags cmdutil.CommonFlags
 var orderbookCmd = &cobra.Command{
 	Use:   "orderbook <instrument>",
 	Short: "L2 orderbook depth metrics",
-	Args:  cobra.ExactArgs(1),
+	Args:  cmdutil.InstrumentArgs,
 	Example: `  laevitas futures orderbook BTC-27MAR26 -p 24h
   laevitas futures orderbook BTC-27MAR26 -p 7d -r 1h`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client, _ := cmdutil.MustClient()
 		params := orderbookFlags.ToParams()
-		params.InstrumentName = args[0]
+		params.InstrumentName = cmdutil.InstrumentArg(args)
 		cmdutil.RunAndPrint(client, api.FuturesOrderbook, params)
 	},
 }
 
+// ─── orderbook-raw ──────────────────────────────────────────────────────────
+
+var orderbookRawFlags cmdutil.CommonFlags
+
+var orderbookRawCmd = &cobra.Command{
+	Use:   "orderbook-raw <instrument>",
+	Short: "Raw L2 orderbook snapshots",
+	Args:  cmdutil.InstrumentArgs,
+	Example: `  laevitas futures orderbook-raw BTC-27MAR26 -p 1h
+  laevitas futures orderbook-raw BTC-27MAR26 -n 10`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client, _ := cmdutil.MustClient()
+		params := orderbookRawFlags.ToParams()
+		params.InstrumentName = cmdutil.InstrumentArg(args)
+		cmdutil.RunAndPrint(client, api.FuturesOrderbookRaw, params)
+	},
+}
+
 // ─── ticker ─────────────────────────────────────────────────────────────────
 
 var tickerFlags cmdutil.CommonFlags
@@ -219,13 +241,13 @@ var tickerFlags cmdutil.CommonFlags
 var tickerCmd = &cobra.Command{
 	Use:   "ticker <instrument>",
 	Short: "Historical ticker snapshots (mark price, OI, bid/ask, funding)",
-	Args:  cobra.ExactArgs(1),
+	Args:  cmdutil.InstrumentArgs,
 	Example: `  laevitas futures ticker BTC-27MAR26 -p 24h
   laevitas futures ticker BTC-27MAR26 -p 7d -r 1h`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client, _ := cmdutil.MustClient()
 		params := tickerFlags.ToParams()
-		params.InstrumentName = args[0]
+		params.InstrumentName = cmdutil.InstrumentArg(args)
 		cmdutil.RunAndPrint(client, api.FuturesTickerHistory, params)
 	},
 }
@@ -237,13 +259,13 @@ var refPriceFlags cmdutil.CommonFlags
 var refPriceCmd = &cobra.Command{
 	Use:   "ref-price <instrument>",
 	Short: "Mark price and index price OHLC",
-	Args:  cobra.ExactArgs(1),
+	Args:  cmdutil.InstrumentArgs,
 	Example: `  laevitas futures ref-price BTC-27MAR26 -p 24h
   laevitas futures ref-price BTC-27MAR26 -p 7d -r 1h`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client, _ := cmdutil.MustClient()
 		params := refPriceFlags.ToParams()
-		params.InstrumentName = args[0]
+		params.InstrumentName = cmdutil.InstrumentArg(args)
 		cmdutil.RunAndPrint(client, api.FuturesReferencePrice, params)
 	},
 }
@@ -253,12 +275,12 @@ var refPriceCmd = &cobra.Command{
 var metadataCmd = &cobra.Command{
 	Use:   "metadata <instrument>",
 	Short: "Data availability info for a dated futures instrument",
-	Args:  cobra.ExactArgs(1),
+	Args:  cmdutil.InstrumentArgs,
 	Example: `  laevitas futures metadata BTC-27MAR26`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client, _ := cmdutil.MustClient()
 		params := &api.RequestParams{
-			InstrumentName: args[0],
+			InstrumentName: cmdutil.InstrumentArg(args),
 			Exchange:       cmdutil.Exchange,
 		}
 		cmdutil.RunAndPrint(client, api.FuturesMetadata, params)
@@ -364,7 +386,7 @@ and most active instruments — all in a single call.`,
 }
 
 func init() {
-	snapshotCmd.Flags().StringVar(&snapshotFlags.Currency, "currency", "", "Filter by currency (BTC, ETH)")
+	snapshotCmd.Flags().StringVar(&snapshotFlags.Currency, "currency", "", "Filter by currency (BTC, ETH; comma-separate for several)")
 	snapshotCmd.Flags().StringVar(&snapshotFlags.Date, "date", "", "Snapshot datetime (ISO 8601)")
 
 	cmdutil.AddCommonFlags(ohlcvCmd, &ohlcvFlags)
@@ -384,6 +406,7 @@ func init() {
 	cmdutil.AddCommonFlags(volumeCmd, &volumeFlags)
 	cmdutil.AddCommonFlags(level1Cmd, &level1Flags)
 	cmdutil.AddCommonFlags(orderbookCmd, &orderbookFlags)
+	cmdutil.AddCommonFlags(orderbookRawCmd, &orderbookRawFlags)
 	cmdutil.AddCommonFlags(tickerCmd, &tickerFlags)
 	cmdutil.AddCommonFlags(refPriceCmd, &refPriceFlags)
 
@@ -410,7 +433,13 @@ func init() {
 	flowCmd.Flags().IntVar(&flowFlags.TopN, "top-n", 10, "Number of notable trades / active instruments")
 	_ = flowCmd.MarkFlagRequired("currency")
 
+	cmdutil.AddCatalogFlags(catalogCmd, &catalogFlags, true, "linear, inverse")
+
+	cmdutil.AllowInstrumentsFile(ohlcvCmd, oiCmd, carryCmd, tradesCmd, volumeCmd, level1Cmd,
+		orderbookCmd, orderbookRawCmd, tickerCmd, refPriceCmd, metadataCmd)
+
 	Cmd.AddCommand(catalogCmd)
+	cmdutil.SuggestTimeout(snapshotCmd, cmdutil.SnapshotTimeout)
 	Cmd.AddCommand(snapshotCmd)
 	Cmd.AddCommand(ohlcvCmd)
 	Cmd.AddCommand(oiCmd)
@@ -419,6 +448,7 @@ func init() {
 	Cmd.AddCommand(volumeCmd)
 	Cmd.AddCommand(level1Cmd)
 	Cmd.AddCommand(orderbookCmd)
+	Cmd.AddCommand(orderbookRawCmd)
 	Cmd.AddCommand(tickerCmd)
 	Cmd.AddCommand(refPriceCmd)
 	Cmd.AddCommand(metadataCmd)
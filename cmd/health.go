@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/laevitas/cli/internal/api"
+	"github.com/laevitas/cli/internal/cmdutil"
+	internalConfig "github.com/laevitas/cli/internal/config"
+	"github.com/laevitas/cli/internal/output"
+)
+
+var healthCmd = &cobra.Command{
+	Use:   "health",
+	Short: "Check that the API is up and your credentials are accepted",
+	Long: `Call the API health endpoint and print its latency and how the request
+was authenticated. Works without an API key. Exits non-zero (5 when the
+API is unreachable, 2 when the key is rejected) if the check fails.`,
+	Example: `  laevitas health
+  laevitas health -o json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Not MustClient: no key is needed, so no onboarding prompt
+		cfg, err := internalConfig.Load()
+		if err != nil {
+			return err
+		}
+		if cmdutil.UserAgent != "" {
+			cfg.UserAgent = cmdutil.UserAgent
+		}
+		if cmdutil.RetryOn != "" {
+			cfg.RetryOn = cmdutil.RetryOn
+		}
+		client := api.NewClient(cfg)
+		client.Verbose = cmdutil.Verbose
+		client.Quiet = output.Quiet
+		client.MaxRetries = cmdutil.MaxRetries
+		client.Timeout = cmdutil.Timeout
+		client.Log = cmdutil.Log
+		if cmdutil.ExplainRequest(client, api.Health, nil) {
+			return nil
+		}
+
+		ctx, stop := cmdutil.SignalContext()
+		defer stop()
+		if _, err := client.Get(ctx, api.Health, nil); err != nil {
+			return err
+		}
+		meta := client.LastMeta()
+
+		auth := meta.PaymentMethod
+		switch {
+		case auth != "":
+		case client.HasWallet():
+			auth = "x402 wallet"
+		default:
+			auth = "none"
+		}
+
+		if output.Resolve(cmdutil.OutputFormat) == output.FormatJSON {
+			result := map[string]interface{}{
+				"ok":         true,
+				"url":        cfg.BaseURL,
+				"latency_ms": meta.Duration.Milliseconds(),
+				"auth":       auth,
+			}
+			if meta.Credits != "" {
+				result["credits_remaining"] = meta.Credits
+			}
+			return output.NewPrinter("json").Print(result)
+		}
+		fmt.Printf("✓ API is up\n")
+		fmt.Printf("URL:      %s\n", cfg.BaseURL)
+		fmt.Printf("Latency:  %s\n", meta.Duration.Round(time.Millisecond))
+		fmt.Printf("Auth:     %s\n", auth)
+		if meta.Credits != "" {
+			fmt.Printf("Credits:  %s remaining\n", output.FormatNumber(meta.Credits))
+		}
+		return nil
+	},
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+)
+
+// serveQueries points the client at a server answering every request with
+// an empty array, and returns the query strings it received.
+func serveQueries(t *testing.T) func() []url.Values {
+	t.Helper()
+	var mu sync.Mutex
+	var queries []url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		queries = append(queries, r.URL.Query())
+		mu.Unlock()
+		w.Write([]byte("[]"))
+	}))
+	t.Cleanup(srv.Close)
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("LAEVITAS_BASE_URL", srv.URL)
+	t.Setenv("LAEVITAS_API_KEY", "test-key")
+	return func() []url.Values {
+		mu.Lock()
+		defer mu.Unlock()
+		return queries
+	}
+}
+
+func queryWindow(t *testing.T, q url.Values) time.Duration {
+	t.Helper()
+	start, err := time.Parse(time.RFC3339, q.Get("start"))
+	if err != nil {
+		t.Fatalf("start %q: %v", q.Get("start"), err)
+	}
+	end, err := time.Parse(time.RFC3339, q.Get("end"))
+	if err != nil {
+		t.Fatalf("end %q: %v", q.Get("end"), err)
+	}
+	return end.Sub(start)
+}
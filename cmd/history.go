@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ─── REPL history ───────────────────────────────────────────────────────────
+//
+// Ctrl+R searches the history incrementally (readline's reverse search);
+// history lists the entries with numbers that !N runs again.
+
+// historyLimit is how many entries the history file keeps.
+const historyLimit = 1000
+
+// historyEntries reads the REPL history file, oldest first.
+func historyEntries() ([]string, error) {
+	f, err := os.Open(historyFilePath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			entries = append(entries, line)
+		}
+	}
+	return entries, scanner.Err()
+}
+
+// handleHistoryCommand prints the most recent history entries, numbered
+// for !N: history [N] (default 20).
+func handleHistoryCommand(args []string) error {
+	n := 20
+	if len(args) > 0 {
+		v, err := strconv.Atoi(args[0])
+		if err != nil || v < 1 {
+			return fmt.Errorf("invalid count %q: usage history [N]", args[0])
+		}
+		n = v
+	}
+	entries, err := historyEntries()
+	if err != nil {
+		return fmt.Errorf("reading history: %w", err)
+	}
+
+	dim := "\033[2m"
+	reset := "\033[0m"
+	for i := max(len(entries)-n, 0); i < len(entries); i++ {
+		fmt.Printf("  %s%4d%s  %s\n", dim, i+1, reset, entries[i])
+	}
+	if len(entries) > 0 {
+		fmt.Printf("  %s!N runs entry N again · Ctrl+R searches%s\n", dim, reset)
+	}
+	return nil
+}
+
+// expandHistoryRef returns the command for a !N (or !! for the previous
+// command) history reference.
+func expandHistoryRef(ref string) (string, error) {
+	entries, err := historyEntries()
+	if err != nil {
+		return "", fmt.Errorf("reading history: %w", err)
+	}
+	// The !ref line itself is already the newest entry
+	if len(entries) > 0 && entries[len(entries)-1] == "!"+ref {
+		entries = entries[:len(entries)-1]
+	}
+	if ref == "!" {
+		for i := len(entries) - 1; i >= 0; i-- {
+			if !strings.HasPrefix(entries[i], "!") {
+				return entries[i], nil
+			}
+		}
+		return "", fmt.Errorf("no previous command in history")
+	}
+	n, err := strconv.Atoi(ref)
+	if err != nil || n < 1 || n > len(entries) {
+		return "", fmt.Errorf("no history entry %q (see history)", ref)
+	}
+	if strings.HasPrefix(entries[n-1], "!") {
+		return "", fmt.Errorf("history entry %d is itself a history reference (%s)", n, entries[n-1])
+	}
+	return entries[n-1], nil
+}
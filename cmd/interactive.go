@@ -39,10 +39,19 @@ func printBanner() {
 	fmt.Fprintf(os.Stdout, "  Type %s'help'%s for commands, %s'quit'%s to exit\n\n", bold, reset, bold, reset)
 }
 
+// replPrompt is the REPL's input prompt, restored after sub-prompts such
+// as the cols picker.
+const replPrompt = "\033[36mLAEVITAS\033[0m > "
+
 // replCompleter is the session-scoped completer with catalog caching.
 var replCompleter *completer.Completer
 
+// replProfile is the --profile given when starting the REPL; it stays in
+// effect for every command in the session.
+var replProfile string
+
 func runInteractive() error {
+	replProfile = profile
 	printBanner()
 
 	// Load config and create a persistent API client
@@ -108,22 +117,33 @@ func runInteractive() error {
 		return sq.Names()
 	}
 
-	prompt := "\033[36mLAEVITAS\033[0m > "
-
 	rl, err := readline.NewEx(&readline.Config{
-		Prompt:          prompt,
-		HistoryFile:     historyFilePath(),
-		AutoComplete:    replCompleter,
-		InterruptPrompt: "^C",
-		EOFPrompt:       "quit",
+		Prompt:            replPrompt,
+		HistoryFile:       historyFilePath(),
+		HistoryLimit:      historyLimit,
+		HistorySearchFold: true, // case-insensitive Ctrl+R search
+		AutoComplete:      replCompleter,
+		InterruptPrompt:   "^C",
+		EOFPrompt:         "quit",
 	})
 	if err != nil {
 		return fmt.Errorf("initializing readline: %w", err)
 	}
 	defer rl.Close()
 
+	// Let the completer rewrite the line for substring instrument matches
+	replCompleter.ReplaceFunc = rl.Operation.SetBuffer
+
+	if transcript != "" {
+		if err := startTranscript(transcript); err != nil {
+			return fmt.Errorf("opening transcript: %w", err)
+		}
+		defer stopTranscript()
+	}
+
 	// Store the shared client so commands can pick it up in REPL mode
 	cmdutil.SharedClient = client
+	cmdutil.SharedProfile = cfg.Profile
 
 	for {
 		line, err := rl.Readline()
@@ -145,6 +165,17 @@ func runInteractive() error {
 			continue
 		}
 
+		// !N / !! re-runs a history entry
+		if len(line) > 1 && line[0] == '!' {
+			recalled, err := expandHistoryRef(line[1:])
+			if err != nil {
+				output.Errorf("%s", err)
+				continue
+			}
+			fmt.Printf("  \033[2m→ %s\033[0m\n", recalled)
+			line = recalled
+		}
+
 		switch strings.ToLower(line) {
 		case "quit", "exit":
 			fmt.Println("Bye!")
@@ -154,37 +185,87 @@ func runInteractive() error {
 			continue
 		}
 
-		// Handle REPL-only commands before passing to cobra
-		args := splitArgs(line)
+		recordTranscript(line)
+		captureTranscript(func() { dispatchREPLLine(line, client, rl) })
+	}
+}
 
-		// Strip leading "laevitas" — users often copy examples from help text
-		if len(args) > 1 && strings.ToLower(args[0]) == "laevitas" {
-			args = args[1:]
-			line = strings.Join(args, " ")
-		}
+// dispatchREPLLine runs one REPL line: a REPL-only command (search, save,
+// cols, ...) or else a cobra command.
+func dispatchREPLLine(line string, client *api.Client, rl *readline.Instance) {
+	// Handle REPL-only commands before passing to cobra
+	args := splitArgs(line)
 
-		if len(args) >= 1 {
-			switch strings.ToLower(args[0]) {
-			case "search":
-				runSearch(args[1:])
-				continue
-			case "save":
-				handleSaveCommand(args[1:])
-				continue
-			case "run":
-				handleRunCommand(args[1:], client)
-				continue
-			case "saves":
-				handleSavesCommand()
-				continue
-			case "unsave":
-				handleUnsaveCommand(args[1:])
-				continue
+	// Strip leading "laevitas" — users often copy examples from help text
+	if len(args) > 1 && strings.ToLower(args[0]) == "laevitas" {
+		args = args[1:]
+		line = strings.Join(args, " ")
+	}
+
+	if len(args) >= 1 {
+		switch strings.ToLower(args[0]) {
+		case "search":
+			runSearch(args[1:])
+			return
+		case "save":
+			if err := handleSaveCommand(args[1:]); err != nil {
+				output.Errorf("%s", err)
 			}
+			return
+		case "run":
+			if err := handleRunCommand(args[1:], client); err != nil {
+				output.Errorf("%s", err)
+			}
+			return
+		case "saves":
+			if len(args) > 1 {
+				break // export/import subcommands go through cobra
+			}
+			if err := handleSavesCommand(); err != nil {
+				output.Errorf("%s", err)
+			}
+			return
+		case "unsave":
+			if err := handleUnsaveCommand(args[1:]); err != nil {
+				output.Errorf("%s", err)
+			}
+			return
+		case "cols":
+			if err := handleColsCommand(args[1:], rl); err != nil {
+				output.Errorf("%s", err)
+			}
+			return
+		case "history":
+			if err := handleHistoryCommand(args[1:]); err != nil {
+				output.Errorf("%s", err)
+			}
+			return
+		case "save-last":
+			if err := handleSaveLastCommand(args[1:]); err != nil {
+				output.Errorf("%s", err)
+			}
+			return
+		case "record":
+			if err := handleRecordCommand(args[1:]); err != nil {
+				output.Errorf("%s", err)
+			}
+			return
+		}
+		if format, ok := lastFormats[strings.ToLower(args[0])]; ok && len(args) == 1 {
+			if err := handleLastFormat(format); err != nil {
+				output.Errorf("%s", err)
+			}
+			return
 		}
+	}
 
-		executeREPLCommand(line, client)
+	// Pipes hand text to /bin/sh, so only lines typed at the prompt get
+	// them; saved queries run through executeREPLCommand directly
+	if command, shell, ok := splitPipe(line); ok {
+		runPiped(command, shell, client)
+		return
 	}
+	executeREPLCommand(line, client)
 }
 
 func executeREPLCommand(line string, client *api.Client) {
@@ -201,6 +282,8 @@ func executeREPLCommand(line string, client *api.Client) {
 		}
 	}
 
+	args = expandAlias(args)
+
 	// Handle bare "help" → show root help
 	if args[0] == "help" {
 		if len(args) == 1 {
@@ -212,6 +295,9 @@ func executeREPLCommand(line string, client *api.Client) {
 		args = append(args[1:], "--help")
 	}
 
+	// A new query replaces the cached result (json, csv, cols, save-last)
+	cmdutil.LastResponse = nil
+
 	// Start spinner
 	s := spinner.New(spinner.CharSets[14], 100*time.Millisecond)
 	s.Suffix = " Loading..."
@@ -230,12 +316,21 @@ func executeREPLCommand(line string, client *api.Client) {
 		cmdutil.SpinnerInstance = nil
 	}()
 
-	if err := rootCmd.Execute(); err != nil {
+	err := rootCmd.Execute()
+	cmdutil.LogCommand(err)
+	if cmdutil.IsCancelled(err) {
+		output.Warnf("Cancelled")
+	} else if err != nil {
 		output.Errorf("%s", err)
 	}
 
-	// Reset flags for next command
+	// Reset flags for next command, including the leaf command's own:
+	// cobra keeps their values, so a one-off --include-secrets or
+	// --overwrite would otherwise stick for the rest of the session
 	resetFlags()
+	if c, _, err := rootCmd.Find(args); err == nil {
+		resetChangedFlags(c.NonInheritedFlags())
+	}
 }
 
 // resetFlags clears persistent flag values back to defaults so they
@@ -245,15 +340,114 @@ func resetFlags() {
 	exchange = ""
 	verbose = false
 	noChart = false
+	chartColumn = ""
+	sparkColumn = ""
+	chartMA = 0
+	chartWidth = 60
+	chartHeight = 15
+	stats = false
+	timings = false
+	userAgent = ""
+	logFile = ""
+	quiet = false
+	raw = false
+	humanize = false
+	tmpl = ""
+	timeout = 0
+	noColor = false
+	assumeYes = false
+	explain = false
+	describe = false
+	noPager = false
+	instFile = ""
+	concurrency = cmdutil.DefaultConcurrency
+	maxRetries = api.DefaultMaxRetries
+	retryOn = ""
+	aggregateBy = ""
+	aggSpec = ""
+	filters = nil
+	sortBy = ""
+	profile = replProfile
 	wide = false
 	widthOverride = 0
+	wrap = false
+	noFooter = false
+	noSeparator = false
+	compact = false
+	maxWidth = 0
+	countOnly = false
+	envelope = false
+	saveResp = ""
+	replay = ""
+	locale = ""
 	rootCmd.PersistentFlags().Set("output", "auto")
 	rootCmd.PersistentFlags().Set("exchange", "")
 	rootCmd.PersistentFlags().Set("verbose", "false")
 	rootCmd.PersistentFlags().Set("no-chart", "false")
+	rootCmd.PersistentFlags().Set("chart-column", "")
+	rootCmd.PersistentFlags().Set("spark", "")
+	rootCmd.PersistentFlags().Set("chart-ma", "0")
+	rootCmd.PersistentFlags().Set("chart-width", "60")
+	rootCmd.PersistentFlags().Set("chart-height", "15")
+	output.ChartMA = 0
+	rootCmd.PersistentFlags().Set("stats", "false")
+	rootCmd.PersistentFlags().Set("timings", "false")
+	rootCmd.PersistentFlags().Set("user-agent", "")
+	rootCmd.PersistentFlags().Set("log-file", "")
+	rootCmd.PersistentFlags().Set("quiet", "false")
+	output.Quiet = false
+	rootCmd.PersistentFlags().Set("raw", "false")
+	output.Raw = false
+	rootCmd.PersistentFlags().Set("humanize", "false")
+	output.Humanize = false
+	rootCmd.PersistentFlags().Set("template", "")
+	rootCmd.PersistentFlags().Set("timeout", "0s")
+	rootCmd.PersistentFlags().Set("no-color", "false")
+	output.SetNoColor(false)
+	output.SetTemplate("")
+	rootCmd.PersistentFlags().Set("yes", "false")
+	rootCmd.PersistentFlags().Set("explain", "false")
+	rootCmd.PersistentFlags().Set("describe", "false")
+	rootCmd.PersistentFlags().Set("no-pager", "false")
+	rootCmd.PersistentFlags().Set("instruments-file", "")
+	rootCmd.PersistentFlags().Set("concurrency", fmt.Sprint(cmdutil.DefaultConcurrency))
+	rootCmd.PersistentFlags().Set("max-retries", fmt.Sprint(api.DefaultMaxRetries))
+	rootCmd.PersistentFlags().Set("retry-on", "")
+	output.NoPager = false
+	rootCmd.PersistentFlags().Set("aggregate-by", "")
+	rootCmd.PersistentFlags().Set("agg", "")
+	output.AggregateBy = ""
+	output.Aggs = nil
+	output.Filters = nil
+	rootCmd.PersistentFlags().Set("sort-by", "")
+	output.SortKeys = nil
+	rootCmd.PersistentFlags().Set("profile", replProfile)
 	rootCmd.PersistentFlags().Set("wide", "false")
 	rootCmd.PersistentFlags().Set("width", "0")
 	output.WidthOverride = -1
+	rootCmd.PersistentFlags().Set("wrap", "false")
+	rootCmd.PersistentFlags().Set("no-footer", "false")
+	rootCmd.PersistentFlags().Set("no-separator", "false")
+	rootCmd.PersistentFlags().Set("compact", "false")
+	rootCmd.PersistentFlags().Set("max-width", "0")
+	output.Wrap = false
+	output.NoFooter = false
+	output.NoSeparator = false
+	output.Compact = false
+	output.MaxColWidth = 0
+	rootCmd.PersistentFlags().Set("count", "false")
+	rootCmd.PersistentFlags().Set("meta", "false")
+	cmdutil.CountOnly = false
+	rootCmd.PersistentFlags().Set("save-response", "")
+	rootCmd.PersistentFlags().Set("replay", "")
+	cmdutil.SaveResponse = ""
+	cmdutil.Replay = ""
+	rootCmd.PersistentFlags().Set("locale", "")
+	output.SetLocale("")
+	transcript = ""
+	rootCmd.Flags().Set("transcript", "")
+	versionJSON = false
+	versionCmd.Flags().Set("json", "false")
 }
 
 // runSearch performs a fuzzy search across all instrument catalogs.
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/laevitas/cli/internal/config"
+)
+
+// TestREPLResetsLocalFlags runs two exports in one REPL session; the
+// --include-secrets of the first must not put keys in the second file.
+func TestREPLResetsLocalFlags(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.APIKey = "test-secret-key"
+	if err := config.Save(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	withSecrets := filepath.Join(dir, "f1.json")
+	plain := filepath.Join(dir, "f2.json")
+	executeREPLCommand("config export --include-secrets "+withSecrets, nil)
+	executeREPLCommand("config export "+plain, nil)
+
+	first, err := os.ReadFile(withSecrets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(first), "test-secret-key") {
+		t.Errorf("--include-secrets export has no api_key:\n%s", first)
+	}
+	second, err := os.ReadFile(plain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(second), "test-secret-key") {
+		t.Errorf("plain export after --include-secrets leaked the api_key:\n%s", second)
+	}
+}
+
+// TestREPLImportOverwriteDoesNotStick checks a later plain import in the
+// same session skips collisions instead of replacing them like --overwrite.
+func TestREPLImportOverwriteDoesNotStick(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+
+	local := &config.SavedQueries{}
+	local.Add("a", "perps carry BTC-PERPETUAL")
+	local.Add("b", "perps carry BTC-PERPETUAL")
+	if err := config.SaveQueries(local); err != nil {
+		t.Fatal(err)
+	}
+
+	first := &config.SavedQueries{}
+	first.Add("a", "perps carry ETH-PERPETUAL")
+	second := &config.SavedQueries{}
+	second.Add("b", "perps carry ETH-PERPETUAL")
+	f1, f2 := filepath.Join(dir, "f1.json"), filepath.Join(dir, "f2.json")
+	if err := config.WriteSavedFile(f1, first); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.WriteSavedFile(f2, second); err != nil {
+		t.Fatal(err)
+	}
+
+	executeREPLCommand("saves import --overwrite "+f1, nil)
+	executeREPLCommand("saves import "+f2, nil)
+
+	sq, err := config.LoadSaved()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := sq.Get("a").Command; got != "perps carry ETH-PERPETUAL" {
+		t.Errorf("--overwrite import: a = %q, want it replaced", got)
+	}
+	if got := sq.Get("b").Command; got != "perps carry BTC-PERPETUAL" {
+		t.Errorf("plain import replaced b with %q; --overwrite stuck", got)
+	}
+}
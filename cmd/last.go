@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/laevitas/cli/internal/cmdutil"
+	"github.com/laevitas/cli/internal/output"
+)
+
+// ─── REPL last result ───────────────────────────────────────────────────────
+//
+// The REPL keeps the body of the last query (cmdutil.LastResponse) so it can
+// be shown again in another format, or written to a file, without paying for
+// a second request. Each new query replaces it.
+
+// lastFormats are the REPL words that re-print the last result, and the
+// output format each one selects.
+var lastFormats = map[string]string{
+	"json":     "json",
+	"csv":      "csv",
+	"tsv":      "tsv",
+	"table":    "table",
+	"markdown": "markdown",
+	"md":       "markdown",
+}
+
+var errNoLastResult = fmt.Errorf("no result yet — run a query first")
+
+// handleLastFormat re-prints the last result as format.
+func handleLastFormat(format string) error {
+	if cmdutil.LastResponse == nil {
+		return errNoLastResult
+	}
+	return output.NewPrinter(format).Print(cmdutil.LastResponse)
+}
+
+// handleSaveLastCommand writes the last result, as received, to a file:
+// save-last <file>
+func handleSaveLastCommand(args []string) error {
+	if len(args) != 1 {
+		fmt.Println("  Usage: save-last <file>")
+		return nil
+	}
+	if cmdutil.LastResponse == nil {
+		return errNoLastResult
+	}
+	if err := os.WriteFile(args[0], cmdutil.LastResponse, 0644); err != nil {
+		return err
+	}
+	output.Successf("Saved last result to %s", args[0])
+	return nil
+}
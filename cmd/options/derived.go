@@ -0,0 +1,527 @@
+package options
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/laevitas/cli/internal/api"
+	"github.com/laevitas/cli/internal/cmdutil"
+	"github.com/laevitas/cli/internal/output"
+)
+
+// Derived metrics computed client-side from the options chain snapshot.
+
+// chainQuote is one option from the chain snapshot, reduced to the fields
+// the derived metrics need.
+type chainQuote struct {
+	Maturity string
+	Expiry   time.Time
+	Strike   float64
+	Put      bool
+	OI       float64
+	Volume   float64
+	Mark     float64
+	Spot     float64
+}
+
+// Snapshot columns tried in order for open interest and volume.
+var (
+	chainOIColumns     = []string{"open_interest", "oi", "oi_close"}
+	chainVolumeColumns = []string{"volume_24h", "volume", "volume_usd_24h"}
+	chainMarkColumns   = []string{"mark_price", "mark"}
+	chainSpotColumns   = []string{"underlying_price", "index_price"}
+)
+
+// fetchChain loads the options snapshot for a currency and parses every
+// record whose instrument name looks like CUR-DDMMMYY-STRIKE-C|P. Under
+// --explain it only describes the request and returns no quotes; callers
+// stop when cmdutil.Explain is set.
+func fetchChain(client *api.Client, currency, date string) ([]chainQuote, error) {
+	params := &api.RequestParams{
+		Exchange: cmdutil.Exchange,
+		Currency: currency,
+		Date:     date,
+	}
+	if cmdutil.ExplainRequest(client, api.OptionsSnapshot, params) {
+		return nil, nil
+	}
+
+	cmdutil.StartSpinner()
+	ctx, stop := cmdutil.SignalContext()
+	data, err := client.Get(ctx, api.OptionsSnapshot, params)
+	stop()
+	cmdutil.StopSpinner()
+	if err != nil {
+		return nil, err
+	}
+
+	var quotes []chainQuote
+	for _, rec := range output.ExtractRecords(data) {
+		name, _ := rec["instrument_name"].(string)
+		parts := strings.Split(name, "-")
+		if len(parts) != 4 {
+			continue
+		}
+		strike, err := strconv.ParseFloat(parts[2], 64)
+		if err != nil {
+			continue
+		}
+		q := chainQuote{
+			Maturity: strings.ToUpper(parts[1]),
+			Strike:   strike,
+			Put:      strings.EqualFold(parts[3], "P"),
+			OI:       firstFloat(rec, chainOIColumns),
+			Volume:   firstFloat(rec, chainVolumeColumns),
+			Mark:     firstFloat(rec, chainMarkColumns),
+			Spot:     firstFloat(rec, chainSpotColumns),
+		}
+		q.Expiry, _ = time.Parse("2Jan06", q.Maturity)
+		quotes = append(quotes, q)
+	}
+	if len(quotes) == 0 {
+		return nil, fmt.Errorf("no options found in %s snapshot", currency)
+	}
+	return quotes, nil
+}
+
+func firstFloat(rec map[string]interface{}, columns []string) float64 {
+	for _, col := range columns {
+		if f, ok := output.ToFloat(rec[col]); ok {
+			return f
+		}
+	}
+	return 0
+}
+
+// groupByMaturity buckets quotes by maturity, ordered by expiry date.
+// A non-empty filter keeps only that maturity.
+func groupByMaturity(quotes []chainQuote, filter string) ([]string, map[string][]chainQuote) {
+	groups := map[string][]chainQuote{}
+	var order []string
+	for _, q := range quotes {
+		if filter != "" && !strings.EqualFold(q.Maturity, filter) {
+			continue
+		}
+		if _, ok := groups[q.Maturity]; !ok {
+			order = append(order, q.Maturity)
+		}
+		groups[q.Maturity] = append(groups[q.Maturity], q)
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return groups[order[i]][0].Expiry.Before(groups[order[j]][0].Expiry)
+	})
+	return order, groups
+}
+
+// ratio returns num/den, or nil when the denominator is zero so the cell
+// renders empty instead of Inf.
+func ratio(num, den float64) interface{} {
+	if den == 0 {
+		return nil
+	}
+	return num / den
+}
+
+// ─── pcr ────────────────────────────────────────────────────────────────────
+
+var pcrFlags struct {
+	Currency string
+	Date     string
+	Maturity string
+}
+
+var pcrCmd = &cobra.Command{
+	Use:   "pcr",
+	Short: "Put/call ratio of open interest and volume, per maturity",
+	Long: `Computes put/call ratios from the options chain snapshot: one row per
+maturity plus an ALL row for the whole chain. A ratio above 1 means more
+puts than calls.`,
+	Example: `  laevitas options pcr --currency BTC
+  laevitas options pcr --currency ETH --maturity 28MAR25 -o json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, _ := cmdutil.MustClient()
+		quotes, err := fetchChain(client, pcrFlags.Currency, pcrFlags.Date)
+		if err != nil || cmdutil.Explain {
+			return err
+		}
+		order, groups := groupByMaturity(quotes, pcrFlags.Maturity)
+		if len(order) == 0 {
+			return fmt.Errorf("maturity %s not found in %s chain", pcrFlags.Maturity, pcrFlags.Currency)
+		}
+
+		var rows []map[string]interface{}
+		var all []chainQuote
+		for _, m := range order {
+			rows = append(rows, pcrRow(m, groups[m]))
+			all = append(all, groups[m]...)
+		}
+		if len(order) > 1 {
+			rows = append(rows, pcrRow("ALL", all))
+		}
+		return cmdutil.PrintComputed(cmdutil.MustPrinter(), client, rows)
+	},
+}
+
+func pcrRow(maturity string, quotes []chainQuote) map[string]interface{} {
+	var putOI, callOI, putVol, callVol float64
+	for _, q := range quotes {
+		if q.Put {
+			putOI += q.OI
+			putVol += q.Volume
+		} else {
+			callOI += q.OI
+			callVol += q.Volume
+		}
+	}
+	return map[string]interface{}{
+		"maturity":    maturity,
+		"put_oi":      putOI,
+		"call_oi":     callOI,
+		"oi_pcr":      ratio(putOI, callOI),
+		"put_volume":  putVol,
+		"call_volume": callVol,
+		"volume_pcr":  ratio(putVol, callVol),
+	}
+}
+
+// ─── max-pain ───────────────────────────────────────────────────────────────
+
+var maxPainFlags struct {
+	Currency string
+	Date     string
+	Maturity string
+}
+
+var maxPainCmd = &cobra.Command{
+	Use:   "max-pain",
+	Short: "Max-pain strike per maturity from open interest",
+	Long: `Computes the max-pain strike: the settlement price at which the total
+intrinsic value of open calls and puts (what option holders would be paid)
+is smallest. Candidates are the listed strikes of each maturity.`,
+	Example: `  laevitas options max-pain --currency BTC
+  laevitas options max-pain --currency BTC --maturity 28MAR25 -o json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, _ := cmdutil.MustClient()
+		quotes, err := fetchChain(client, maxPainFlags.Currency, maxPainFlags.Date)
+		if err != nil || cmdutil.Explain {
+			return err
+		}
+		order, groups := groupByMaturity(quotes, maxPainFlags.Maturity)
+		if len(order) == 0 {
+			return fmt.Errorf("maturity %s not found in %s chain", maxPainFlags.Maturity, maxPainFlags.Currency)
+		}
+
+		var rows []map[string]interface{}
+		for _, m := range order {
+			strike, pain, callOI, putOI := maxPain(groups[m])
+			rows = append(rows, map[string]interface{}{
+				"maturity":       m,
+				"max_pain":       strike,
+				"payout_at_pain": pain,
+				"call_oi":        callOI,
+				"put_oi":         putOI,
+			})
+		}
+		return cmdutil.PrintComputed(cmdutil.MustPrinter(), client, rows)
+	},
+}
+
+// maxPain returns the strike minimising total option-holder payout, the
+// payout at that strike (contracts × price), and the call/put OI totals.
+func maxPain(quotes []chainQuote) (strike, payout, callOI, putOI float64) {
+	strikes := map[float64]bool{}
+	for _, q := range quotes {
+		strikes[q.Strike] = true
+		if q.Put {
+			putOI += q.OI
+		} else {
+			callOI += q.OI
+		}
+	}
+
+	payout = math.Inf(1)
+	for k := range strikes {
+		total := 0.0
+		for _, q := range quotes {
+			if q.Put {
+				total += q.OI * math.Max(0, q.Strike-k)
+			} else {
+				total += q.OI * math.Max(0, k-q.Strike)
+			}
+		}
+		if total < payout || (total == payout && k < strike) {
+			strike, payout = k, total
+		}
+	}
+	return strike, payout, callOI, putOI
+}
+
+// ─── expiry-calendar ────────────────────────────────────────────────────────
+
+var expiryCalendarFlags struct {
+	Currency string
+	Date     string
+	Limit    int
+}
+
+var expiryCalendarCmd = &cobra.Command{
+	Use:   "expiry-calendar",
+	Short: "Upcoming expiries with open interest, notional and premium per maturity",
+	Long: `Groups the options chain snapshot by maturity, soonest first, with an ALL
+row for the whole chain (every maturity, even with -n). Maturities that
+have already expired are left out:
+
+  open_interest  contracts open (calls + puts)
+  notional_usd   open interest × underlying price
+  premium        open interest × mark price, in the mark price's currency
+                 (the base coin on Deribit)`,
+	Example: `  laevitas options expiry-calendar --currency BTC
+  laevitas options expiry-calendar --currency ETH -n 4 -o csv`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if expiryCalendarFlags.Limit < 0 {
+			return fmt.Errorf("invalid --limit %d (must be >= 0; 0 lists every expiry)", expiryCalendarFlags.Limit)
+		}
+		client, _ := cmdutil.MustClient()
+		quotes, err := fetchChain(client, expiryCalendarFlags.Currency, expiryCalendarFlags.Date)
+		if err != nil || cmdutil.Explain {
+			return err
+		}
+		all, groups := groupByMaturity(quotes, "")
+		// Expired maturities can linger in the snapshot; they aren't upcoming
+		today := time.Now().UTC().Truncate(24 * time.Hour)
+		var order []string
+		for _, m := range all {
+			if expiry := groups[m][0].Expiry; expiry.IsZero() || !expiry.Before(today) {
+				order = append(order, m)
+			}
+		}
+		shown := order
+		if n := expiryCalendarFlags.Limit; n > 0 && n < len(order) {
+			shown = order[:n]
+		}
+
+		var rows []map[string]interface{}
+		for _, m := range shown {
+			row := expiryRow(m, groups[m])
+			if expiry := groups[m][0].Expiry; !expiry.IsZero() {
+				row["days_to_expiry"] = int(expiry.Sub(today).Hours() / 24)
+			}
+			rows = append(rows, row)
+		}
+		// ALL covers every maturity, including those cut by -n
+		if len(order) > 1 {
+			var all []chainQuote
+			for _, m := range order {
+				all = append(all, groups[m]...)
+			}
+			rows = append(rows, expiryRow("ALL", all))
+		}
+		return cmdutil.PrintComputed(cmdutil.MustPrinter(), client, rows)
+	},
+}
+
+// expiryRow sums open interest, notional and premium over quotes.
+func expiryRow(maturity string, quotes []chainQuote) map[string]interface{} {
+	var callOI, putOI, notional, premium float64
+	for _, q := range quotes {
+		if q.Put {
+			putOI += q.OI
+		} else {
+			callOI += q.OI
+		}
+		notional += q.OI * q.Spot
+		premium += q.OI * q.Mark
+	}
+	return map[string]interface{}{
+		"maturity":      maturity,
+		"instruments":   len(quotes),
+		"call_oi":       output.RoundNoise(callOI),
+		"put_oi":        output.RoundNoise(putOI),
+		"open_interest": output.RoundNoise(callOI + putOI),
+		"notional_usd":  output.RoundNoise(notional),
+		"premium":       output.RoundNoise(premium),
+	}
+}
+
+// ─── vol-surface iv-rank ────────────────────────────────────────────────────
+
+// resolutionDurations maps --resolution values to one bar's length.
+var resolutionDurations = map[string]time.Duration{
+	"1m":  time.Minute,
+	"5m":  5 * time.Minute,
+	"15m": 15 * time.Minute,
+	"1h":  time.Hour,
+	"4h":  4 * time.Hour,
+	"1d":  24 * time.Hour,
+}
+
+var ivRankFlags struct {
+	Currency   string
+	Maturity   string
+	Lookback   int
+	Resolution string
+	Column     string
+}
+
+var ivRankCmd = &cobra.Command{
+	Use:   "iv-rank",
+	Short: "IV rank and percentile of current ATM IV over a lookback window",
+	Long: `Pulls the vol surface history for a maturity and compares the latest
+ATM IV with the last --lookback observations:
+
+  IV rank        where current IV sits between the window's min (0) and max (100)
+  IV percentile  share of observations below current IV`,
+	Example: `  laevitas options vol-surface iv-rank --currency BTC --maturity 26DEC25
+  laevitas options vol-surface iv-rank --currency ETH --maturity 26DEC25 --lookback 30
+  laevitas options vol-surface iv-rank --currency BTC --maturity 26DEC25 --lookback 168 -r 1h -o json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		bar, ok := resolutionDurations[ivRankFlags.Resolution]
+		if !ok {
+			return fmt.Errorf("invalid --resolution %q (use: 1m, 5m, 15m, 1h, 4h, 1d)", ivRankFlags.Resolution)
+		}
+		if ivRankFlags.Lookback < 2 || ivRankFlags.Lookback > 1000 {
+			return fmt.Errorf("invalid --lookback %d (must be 2-1000)", ivRankFlags.Lookback)
+		}
+
+		const layout = "2006-01-02T15:04:05Z"
+		now := time.Now().UTC()
+		client, _ := cmdutil.MustClient()
+		params := &api.RequestParams{
+			Exchange:   cmdutil.Exchange,
+			Currency:   ivRankFlags.Currency,
+			Maturity:   ivRankFlags.Maturity,
+			Resolution: ivRankFlags.Resolution,
+			Start:      now.Add(-time.Duration(ivRankFlags.Lookback) * bar).Format(layout),
+			End:        now.Format(layout),
+			Limit:      ivRankFlags.Lookback,
+		}
+		if cmdutil.ExplainRequest(client, api.VolSurfaceByTime, params) {
+			return nil
+		}
+
+		cmdutil.StartSpinner()
+		ctx, stop := cmdutil.SignalContext()
+		data, err := client.Get(ctx, api.VolSurfaceByTime, params)
+		stop()
+		cmdutil.StopSpinner()
+		if err != nil {
+			return err
+		}
+
+		series := timeSeries(output.ExtractRecords(data), ivRankFlags.Column)
+		if len(series) > ivRankFlags.Lookback {
+			series = series[len(series)-ivRankFlags.Lookback:]
+		}
+		if len(series) < 2 {
+			return fmt.Errorf("not enough %s history for %s %s (got %d points)", ivRankFlags.Column, ivRankFlags.Currency, ivRankFlags.Maturity, len(series))
+		}
+
+		current, lo, hi, rank, pct := ivRank(series)
+		return cmdutil.PrintComputed(cmdutil.MustPrinter(), client, []map[string]interface{}{{
+			"currency":      strings.ToUpper(ivRankFlags.Currency),
+			"maturity":      strings.ToUpper(ivRankFlags.Maturity),
+			"iv_current":    current,
+			"iv_min":        lo,
+			"iv_max":        hi,
+			"iv_rank":       rank,
+			"iv_percentile": pct,
+			"observations":  len(series),
+		}})
+	},
+}
+
+// timeSeries extracts column values from records in chronological order,
+// sorting on timestamp (or date) since the API may return newest first.
+func timeSeries(records []map[string]interface{}, column string) []float64 {
+	type point struct {
+		key   string
+		value float64
+	}
+	points := make([]point, 0, len(records))
+	for _, rec := range records {
+		f, ok := output.ToFloat(rec[column])
+		if !ok {
+			continue
+		}
+		key := ""
+		for _, col := range []string{"timestamp", "date", "minute"} {
+			if v, ok := rec[col]; ok && v != nil {
+				if n, isNum := output.ToFloat(v); isNum {
+					key = fmt.Sprintf("%020.0f", n)
+				} else {
+					key = fmt.Sprintf("%v", v)
+				}
+				break
+			}
+		}
+		points = append(points, point{key, f})
+	}
+	sort.SliceStable(points, func(i, j int) bool { return points[i].key < points[j].key })
+
+	values := make([]float64, len(points))
+	for i, p := range points {
+		values[i] = p.value
+	}
+	return values
+}
+
+// ivRank returns the latest value, the window min/max, the IV rank
+// (0-100 between min and max) and the percentile of observations below it.
+func ivRank(series []float64) (current, lo, hi, rank, pct float64) {
+	current = series[len(series)-1]
+	lo, hi = series[0], series[0]
+	below := 0
+	for _, v := range series {
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+		if v < current {
+			below++
+		}
+	}
+	if hi > lo {
+		rank = (current - lo) / (hi - lo) * 100
+	}
+	pct = float64(below) / float64(len(series)) * 100
+	return current, lo, hi, rank, pct
+}
+
+func init() {
+	pcrCmd.Flags().StringVar(&pcrFlags.Currency, "currency", "", "Base currency (required)")
+	pcrCmd.Flags().StringVar(&pcrFlags.Date, "date", "", "Snapshot datetime (ISO 8601)")
+	pcrCmd.Flags().StringVar(&pcrFlags.Maturity, "maturity", "", "Only this maturity (e.g. 28MAR25)")
+	_ = pcrCmd.MarkFlagRequired("currency")
+
+	maxPainCmd.Flags().StringVar(&maxPainFlags.Currency, "currency", "", "Base currency (required)")
+	maxPainCmd.Flags().StringVar(&maxPainFlags.Date, "date", "", "Snapshot datetime (ISO 8601)")
+	maxPainCmd.Flags().StringVar(&maxPainFlags.Maturity, "maturity", "", "Only this maturity (e.g. 28MAR25)")
+	_ = maxPainCmd.MarkFlagRequired("currency")
+
+	expiryCalendarCmd.Flags().StringVar(&expiryCalendarFlags.Currency, "currency", "", "Base currency (required)")
+	expiryCalendarCmd.Flags().StringVar(&expiryCalendarFlags.Date, "date", "", "Snapshot datetime (ISO 8601)")
+	expiryCalendarCmd.Flags().IntVarP(&expiryCalendarFlags.Limit, "limit", "n", 0, "Only the next N expiries (0 = all)")
+	_ = expiryCalendarCmd.MarkFlagRequired("currency")
+
+	ivRankCmd.Flags().StringVar(&ivRankFlags.Currency, "currency", "", "Base currency (required)")
+	ivRankCmd.Flags().StringVar(&ivRankFlags.Maturity, "maturity", "", "Maturity (required, e.g. 28MAR25)")
+	ivRankCmd.Flags().IntVar(&ivRankFlags.Lookback, "lookback", 252, "Number of observations in the window")
+	ivRankCmd.Flags().StringVarP(&ivRankFlags.Resolution, "resolution", "r", "1d", "Observation spacing: 1m, 5m, 15m, 1h, 4h, 1d")
+	ivRankCmd.Flags().StringVar(&ivRankFlags.Column, "column", "atm_iv", "Series to rank (e.g. skew_25d, butterfly_25d)")
+	_ = ivRankCmd.MarkFlagRequired("currency")
+	_ = ivRankCmd.MarkFlagRequired("maturity")
+
+	// All download the full options snapshot
+	cmdutil.SuggestTimeout(pcrCmd, cmdutil.SnapshotTimeout)
+	cmdutil.SuggestTimeout(maxPainCmd, cmdutil.SnapshotTimeout)
+	cmdutil.SuggestTimeout(expiryCalendarCmd, cmdutil.SnapshotTimeout)
+	cmdutil.ComputedOutput(pcrCmd, maxPainCmd, expiryCalendarCmd, ivRankCmd)
+	Cmd.AddCommand(pcrCmd)
+	Cmd.AddCommand(maxPainCmd)
+	Cmd.AddCommand(expiryCalendarCmd)
+	VolSurfaceCmd.AddCommand(ivRankCmd)
+}
@@ -0,0 +1,60 @@
+package options
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestExpiryCalendarUpcoming checks expired maturities are left out of the
+// calendar and its ALL row, and summed columns carry no float noise.
+func TestExpiryCalendarUpcoming(t *testing.T) {
+	past := "1JAN20"
+	next := strings.ToUpper(time.Now().UTC().AddDate(0, 1, 0).Format("2Jan06"))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"data":[
+			{"instrument_name":"BTC-%[1]s-50000-C","open_interest":5,"mark_price":1,"underlying_price":1},
+			{"instrument_name":"BTC-%[2]s-60000-C","open_interest":0.1,"mark_price":1,"underlying_price":1},
+			{"instrument_name":"BTC-%[2]s-60000-P","open_interest":0.2,"mark_price":1,"underlying_price":1}]}`, past, next)
+	}))
+	t.Cleanup(srv.Close)
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("LAEVITAS_BASE_URL", srv.URL)
+	t.Setenv("LAEVITAS_API_KEY", "test-key")
+	expiryCalendarFlags.Currency = "BTC"
+	t.Cleanup(func() { expiryCalendarFlags.Currency = "" })
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	err = expiryCalendarCmd.RunE(expiryCalendarCmd, nil)
+	os.Stdout = stdout
+	w.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var rows []map[string]interface{}
+	if err := json.NewDecoder(r).Decode(&rows); err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("got %d rows, want only %s: %v", len(rows), next, rows)
+	}
+	if rows[0]["maturity"] != next {
+		t.Errorf("maturity = %v, want %s", rows[0]["maturity"], next)
+	}
+	for _, col := range []string{"open_interest", "notional_usd", "premium"} {
+		if rows[0][col] != 0.3 {
+			t.Errorf("%s = %v, want 0.3", col, rows[0][col])
+		}
+	}
+}
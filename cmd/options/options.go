@@ -1,6 +1,8 @@
 package options
 
 import (
+	"time"
+
 	"github.com/spf13/cobra"
 
 	"github.com/laevitas/cli/internal/api"
@@ -10,7 +12,7 @@ import (
 var Cmd = &cobra.Command{
 	Use:   "options",
 	Short: "Options data — flow, trades, volatility, Greeks, OI, vol-surface",
-	Long: `Access options data from Deribit and Binance.
+	Long: `Access options data from Deribit, Binance, Bybit, and OKX.
 
 Examples:
   laevitas options catalog
@@ -22,15 +24,18 @@ Examples:
   laevitas options vol-surface snapshot --currency BTC`,
 }
 
+var catalogFlags cmdutil.CatalogFlags
+
 var catalogCmd = &cobra.Command{
 	Use:   "catalog",
 	Short: "List all available options instruments",
 	Example: `  laevitas options catalog
-  laevitas options catalog --exchange binance`,
+  laevitas options catalog --exchange binance
+  laevitas options catalog --currency BTC --type put --expires-before 2026-12-01`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client, _ := cmdutil.MustClient()
 		params := &api.RequestParams{Exchange: cmdutil.Exchange}
-		cmdutil.RunAndPrint(client, api.OptionsCatalog, params)
+		cmdutil.RunCatalog(client, api.OptionsCatalog, params, &catalogFlags)
 	},
 }
 
@@ -43,7 +48,8 @@ var snapshotCmd = &cobra.Command{
 	Use:   "snapshot",
 	Short: "Full options chain snapshot — all strikes, maturities, Greeks",
 	Example: `  laevitas options snapshot --currency BTC
-  laevitas options snapshot --currency ETH --date 2025-02-01T12:00:00Z`,
+  laevitas options snapshot --currency ETH --date 2025-02-01T12:00:00Z
+  laevitas options snapshot --currency BTC,ETH`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client, _ := cmdutil.MustClient()
 		params := &api.RequestParams{
@@ -119,6 +125,9 @@ or --currency for cross-instrument flow (max 7-day window).`,
 		params.SortDir = tradesFlags.SortDir
 		params.BlockOnly = tradesFlags.BlockOnly
 		params.OpeningOnly = tradesFlags.OpeningOnly
+		if params.InstrumentName == "" {
+			cmdutil.WarnWindow(params, 7*24*time.Hour, "options trades --currency")
+		}
 		if tradesFlags.MinNotional > 0 {
 			if params.Extra == nil {
 				params.Extra = map[string]string{}
@@ -169,6 +178,37 @@ Valid --group-by values: exchange, instrument_name, strike, maturity, option_typ
 	},
 }
 
+// ─── liquidations ───────────────────────────────────────────────────────────
+
+var liquidationsFlags struct {
+	cmdutil.CommonFlags
+	Direction    string
+	PositionSide string
+	MinAmountUsd float64
+	Sort         string
+	SortDir      string
+}
+
+var liquidationsCmd = &cobra.Command{
+	Use:   "liquidations",
+	Short: "Forced liquidation events for options",
+	Long: `Returns individual forced liquidation events for options contracts.
+Filter by --currency (e.g. BTC) and optional direction/position filters.`,
+	Example: `  laevitas options liquidations --currency BTC -p 24h
+  laevitas options liquidations --currency BTC --position-side long --min-amount-usd 10000
+  laevitas options liquidations --currency ETH --direction sell -n 50`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client, _ := cmdutil.MustClient()
+		params := liquidationsFlags.CommonFlags.ToParams()
+		params.Direction = liquidationsFlags.Direction
+		params.PositionSide = liquidationsFlags.PositionSide
+		params.MinAmountUsd = liquidationsFlags.MinAmountUsd
+		params.Sort = liquidationsFlags.Sort
+		params.SortDir = liquidationsFlags.SortDir
+		cmdutil.RunAndPrint(client, api.OptionsLiquidations, params)
+	},
+}
+
 // ─── ohlcvt ─────────────────────────────────────────────────────────────────
 
 var ohlcvFlags cmdutil.CommonFlags
@@ -177,13 +217,13 @@ var ohlcvCmd = &cobra.Command{
 	Use:     "ohlcvt <instrument>",
 	Aliases: []string{"ohlcv"},
 	Short:   "OHLCVT candle data for a specific option",
-	Args:    cobra.ExactArgs(1),
+	Args:    cmdutil.InstrumentArgs,
 	Example: `  laevitas options ohlcvt BTC-27MAR26-70000-C -p 24h
   laevitas options ohlcvt BTC-27MAR26-70000-C -p 3d -r 1h`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client, _ := cmdutil.MustClient()
 		params := ohlcvFlags.ToParams()
-		params.InstrumentName = args[0]
+		params.InstrumentName = cmdutil.InstrumentArg(args)
 		cmdutil.RunAndPrint(client, api.OptionsOHLCVT, params)
 	},
 }
@@ -194,13 +234,13 @@ var oiCmd = &cobra.Command{
 	Use:     "oi <instrument>",
 	Aliases: []string{"open-interest"},
 	Short:   "Open interest for a specific option over time",
-	Args:    cobra.ExactArgs(1),
+	Args:    cmdutil.InstrumentArgs,
 	Example: `  laevitas options oi BTC-27MAR26-70000-C -p 7d
   laevitas options oi BTC-27MAR26-70000-C -p 30d -r 1d`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client, _ := cmdutil.MustClient()
 		params := oiFlags.ToParams()
-		params.InstrumentName = args[0]
+		params.InstrumentName = cmdutil.InstrumentArg(args)
 		cmdutil.RunAndPrint(client, api.OptionsOpenInterest, params)
 	},
 }
@@ -210,13 +250,13 @@ var volFlags cmdutil.CommonFlags
 var volCmd = &cobra.Command{
 	Use:   "volatility <instrument>",
 	Short: "Implied volatility and Greeks for a specific option",
-	Args:  cobra.ExactArgs(1),
+	Args:  cmdutil.InstrumentArgs,
 	Example: `  laevitas options volatility BTC-27MAR26-70000-C -p 24h
   laevitas options volatility BTC-27MAR26-70000-C -p 7d -r 1h`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client, _ := cmdutil.MustClient()
 		params := volFlags.ToParams()
-		params.InstrumentName = args[0]
+		params.InstrumentName = cmdutil.InstrumentArg(args)
 		cmdutil.RunAndPrint(client, api.OptionsVolatility, params)
 	},
 }
@@ -226,13 +266,13 @@ var level1Flags cmdutil.CommonFlags
 var level1Cmd = &cobra.Command{
 	Use:   "level1 <instrument>",
 	Short: "Best bid/ask data for an option",
-	Args:  cobra.ExactArgs(1),
+	Args:  cmdutil.InstrumentArgs,
 	Example: `  laevitas options level1 BTC-27MAR26-70000-C -p 24h
   laevitas options level1 BTC-27MAR26-70000-C -p 3d -r 1h`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client, _ := cmdutil.MustClient()
 		params := level1Flags.ToParams()
-		params.InstrumentName = args[0]
+		params.InstrumentName = cmdutil.InstrumentArg(args)
 		cmdutil.RunAndPrint(client, api.OptionsLevel1, params)
 	},
 }
@@ -242,13 +282,13 @@ var tickerFlags cmdutil.CommonFlags
 var tickerCmd = &cobra.Command{
 	Use:   "ticker <instrument>",
 	Short: "Historical ticker — IV surface, Greeks, OI by strike",
-	Args:  cobra.ExactArgs(1),
+	Args:  cmdutil.InstrumentArgs,
 	Example: `  laevitas options ticker BTC-27MAR26-70000-C -p 24h
   laevitas options ticker BTC-27MAR26-70000-C -p 7d -r 1h`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client, _ := cmdutil.MustClient()
 		params := tickerFlags.ToParams()
-		params.InstrumentName = args[0]
+		params.InstrumentName = cmdutil.InstrumentArg(args)
 		cmdutil.RunAndPrint(client, api.OptionsTickerHistory, params)
 	},
 }
@@ -258,13 +298,13 @@ var volumeFlags cmdutil.CommonFlags
 var volumeCmd = &cobra.Command{
 	Use:   "volume <instrument>",
 	Short: "24h rolling volume for an option",
-	Args:  cobra.ExactArgs(1),
+	Args:  cmdutil.InstrumentArgs,
 	Example: `  laevitas options volume BTC-27MAR26-70000-C -p 24h
   laevitas options volume BTC-27MAR26-70000-C -p 7d -r 1h`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client, _ := cmdutil.MustClient()
 		params := volumeFlags.ToParams()
-		params.InstrumentName = args[0]
+		params.InstrumentName = cmdutil.InstrumentArg(args)
 		cmdutil.RunAndPrint(client, api.OptionsVolume, params)
 	},
 }
@@ -274,13 +314,13 @@ var refPriceFlags cmdutil.CommonFlags
 var refPriceCmd = &cobra.Command{
 	Use:   "ref-price <instrument>",
 	Short: "Mark price and index price OHLC",
-	Args:  cobra.ExactArgs(1),
+	Args:  cmdutil.InstrumentArgs,
 	Example: `  laevitas options ref-price BTC-27MAR26-70000-C -p 24h
   laevitas options ref-price BTC-27MAR26-70000-C -p 7d -r 1h`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client, _ := cmdutil.MustClient()
 		params := refPriceFlags.ToParams()
-		params.InstrumentName = args[0]
+		params.InstrumentName = cmdutil.InstrumentArg(args)
 		cmdutil.RunAndPrint(client, api.OptionsReferencePrice, params)
 	},
 }
@@ -288,12 +328,12 @@ var refPriceCmd = &cobra.Command{
 var metadataCmd = &cobra.Command{
 	Use:   "metadata <instrument>",
 	Short: "Data availability info",
-	Args:  cobra.ExactArgs(1),
+	Args:  cmdutil.InstrumentArgs,
 	Example: `  laevitas options metadata BTC-27MAR26-70000-C`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client, _ := cmdutil.MustClient()
 		params := &api.RequestParams{
-			InstrumentName: args[0],
+			InstrumentName: cmdutil.InstrumentArg(args),
 			Exchange:       cmdutil.Exchange,
 		}
 		cmdutil.RunAndPrint(client, api.OptionsMetadata, params)
@@ -380,7 +420,7 @@ var vsHistCmd = &cobra.Command{
 }
 
 func init() {
-	snapshotCmd.Flags().StringVar(&snapshotFlags.Currency, "currency", "", "Base currency (required)")
+	snapshotCmd.Flags().StringVar(&snapshotFlags.Currency, "currency", "", "Base currency, or several comma-separated (required)")
 	snapshotCmd.Flags().StringVar(&snapshotFlags.Date, "date", "", "Snapshot datetime (ISO 8601)")
 	_ = snapshotCmd.MarkFlagRequired("currency")
 
@@ -419,6 +459,13 @@ func init() {
 	tradesSummaryCmd.Flags().StringVar(&tradesSummaryFlags.Strategy, "strategy", "", "Filter by strategy")
 	_ = tradesSummaryCmd.MarkFlagRequired("group-by")
 
+	cmdutil.AddCommonFlags(liquidationsCmd, &liquidationsFlags.CommonFlags)
+	liquidationsCmd.Flags().StringVar(&liquidationsFlags.Direction, "direction", "", "Filter: buy or sell")
+	liquidationsCmd.Flags().StringVar(&liquidationsFlags.PositionSide, "position-side", "", "Filter: long or short")
+	liquidationsCmd.Flags().Float64Var(&liquidationsFlags.MinAmountUsd, "min-amount-usd", 0, "Min liquidation value in USD")
+	liquidationsCmd.Flags().StringVar(&liquidationsFlags.Sort, "sort", "", "Sort: timestamp, amount_usd, price")
+	liquidationsCmd.Flags().StringVar(&liquidationsFlags.SortDir, "sort-dir", "", "Sort direction: ASC or DESC")
+
 	cmdutil.AddCommonFlags(ohlcvCmd, &ohlcvFlags)
 	cmdutil.AddCommonFlags(oiCmd, &oiFlags)
 	cmdutil.AddCommonFlags(volCmd, &volFlags)
@@ -443,15 +490,23 @@ func init() {
 	_ = vsHistCmd.MarkFlagRequired("currency")
 	_ = vsHistCmd.MarkFlagRequired("maturity")
 
+	cmdutil.SuggestTimeout(vsSnapshotCmd, cmdutil.SnapshotTimeout)
 	VolSurfaceCmd.AddCommand(vsSnapshotCmd)
 	VolSurfaceCmd.AddCommand(vsTSCmd)
 	VolSurfaceCmd.AddCommand(vsHistCmd)
 
+	cmdutil.AddCatalogFlags(catalogCmd, &catalogFlags, true, "call, put")
+
+	cmdutil.AllowInstrumentsFile(ohlcvCmd, oiCmd, volCmd, level1Cmd, tickerCmd, volumeCmd,
+		refPriceCmd, metadataCmd)
+
 	Cmd.AddCommand(catalogCmd)
+	cmdutil.SuggestTimeout(snapshotCmd, cmdutil.SnapshotTimeout)
 	Cmd.AddCommand(snapshotCmd)
 	Cmd.AddCommand(flowCmd)
 	Cmd.AddCommand(tradesCmd)
 	Cmd.AddCommand(tradesSummaryCmd)
+	Cmd.AddCommand(liquidationsCmd)
 	Cmd.AddCommand(ohlcvCmd)
 	Cmd.AddCommand(oiCmd)
 	Cmd.AddCommand(volCmd)
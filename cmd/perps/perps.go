@@ -1,16 +1,23 @@
 package perps
 
 import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/spf13/cobra"
 
 	"github.com/laevitas/cli/internal/api"
 	"github.com/laevitas/cli/internal/cmdutil"
+	"github.com/laevitas/cli/internal/config"
+	"github.com/laevitas/cli/internal/output"
 )
 
 var Cmd = &cobra.Command{
 	Use:   "perps",
 	Short: "Perpetual swap data — carry, OHLCVT, OI, trades",
-	Long: `Access perpetual swap data from Deribit and Binance.
+	Long: `Access perpetual swap data from Deribit, Binance, Bybit, and OKX.
 
 Examples:
   laevitas perps catalog
@@ -20,15 +27,18 @@ Examples:
   laevitas perps snapshot --currency BTC`,
 }
 
+var catalogFlags cmdutil.CatalogFlags
+
 var catalogCmd = &cobra.Command{
 	Use:   "catalog",
 	Short: "List all available perpetual instruments",
 	Example: `  laevitas perps catalog
-  laevitas perps catalog --exchange binance`,
+  laevitas perps catalog --exchange binance
+  laevitas perps catalog --currency ETH`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client, _ := cmdutil.MustClient()
 		params := &api.RequestParams{Exchange: cmdutil.Exchange}
-		cmdutil.RunAndPrint(client, api.PerpsCatalog, params)
+		cmdutil.RunCatalog(client, api.PerpsCatalog, params, &catalogFlags)
 	},
 }
 
@@ -41,7 +51,8 @@ var snapshotCmd = &cobra.Command{
 	Use:   "snapshot",
 	Short: "Market snapshot of ALL perpetuals at a point in time",
 	Example: `  laevitas perps snapshot --currency BTC
-  laevitas perps snapshot --currency ETH`,
+  laevitas perps snapshot --currency ETH
+  laevitas perps snapshot --currency BTC,ETH`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client, _ := cmdutil.MustClient()
 		params := &api.RequestParams{
@@ -59,14 +70,14 @@ var carryCmd = &cobra.Command{
 	Use:     "carry <instrument>",
 	Aliases: []string{"funding"},
 	Short:   "Funding rate, basis, and annualized carry",
-	Args:    cobra.ExactArgs(1),
+	Args:    cmdutil.InstrumentArgs,
 	Example: `  laevitas perps carry BTC-PERPETUAL -p 24h
   laevitas perps carry BTCUSDT --exchange binance -p 7d -r 1d
   laevitas perps carry ETH-PERPETUAL -p 1h -o json | jq '.[].funding_rate_close'`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client, _ := cmdutil.MustClient()
 		params := carryFlags.ToParams()
-		params.InstrumentName = args[0]
+		params.InstrumentName = cmdutil.InstrumentArg(args)
 		cmdutil.RunAndPrint(client, api.PerpsCarry, params)
 	},
 }
@@ -77,7 +88,7 @@ var ohlcvCmd = &cobra.Command{
 	Use:     "ohlcvt <instrument>",
 	Aliases: []string{"ohlcv"},
 	Short:   "OHLCVT candle data from trades",
-	Args:    cobra.ExactArgs(1),
+	Args:    cmdutil.InstrumentArgs,
 	Example: `  # Deribit (default exchange)
   laevitas perps ohlcvt BTC-PERPETUAL -p 24h
   laevitas perps ohlcvt ETH-PERPETUAL -p 3d -r 1h
@@ -88,7 +99,7 @@ var ohlcvCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		client, _ := cmdutil.MustClient()
 		params := ohlcvFlags.ToParams()
-		params.InstrumentName = args[0]
+		params.InstrumentName = cmdutil.InstrumentArg(args)
 		cmdutil.RunAndPrint(client, api.PerpsOHLCVT, params)
 	},
 }
@@ -99,13 +110,13 @@ var oiCmd = &cobra.Command{
 	Use:     "oi <instrument>",
 	Aliases: []string{"open-interest"},
 	Short:   "Open interest data over time",
-	Args:    cobra.ExactArgs(1),
+	Args:    cmdutil.InstrumentArgs,
 	Example: `  laevitas perps oi BTC-PERPETUAL -p 7d
   laevitas perps oi BTCUSDT --exchange binance -p 30d -r 1d`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client, _ := cmdutil.MustClient()
 		params := oiFlags.ToParams()
-		params.InstrumentName = args[0]
+		params.InstrumentName = cmdutil.InstrumentArg(args)
 		cmdutil.RunAndPrint(client, api.PerpsOpenInterest, params)
 	},
 }
@@ -154,13 +165,13 @@ var volumeFlags cmdutil.CommonFlags
 var volumeCmd = &cobra.Command{
 	Use:   "volume <instrument>",
 	Short: "24h rolling volume data",
-	Args:  cobra.ExactArgs(1),
+	Args:  cmdutil.InstrumentArgs,
 	Example: `  laevitas perps volume BTC-PERPETUAL -p 24h
   laevitas perps volume BTCUSDT --exchange binance -p 7d -r 1h`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client, _ := cmdutil.MustClient()
 		params := volumeFlags.ToParams()
-		params.InstrumentName = args[0]
+		params.InstrumentName = cmdutil.InstrumentArg(args)
 		cmdutil.RunAndPrint(client, api.PerpsVolume, params)
 	},
 }
@@ -170,13 +181,13 @@ var level1Flags cmdutil.CommonFlags
 var level1Cmd = &cobra.Command{
 	Use:   "level1 <instrument>",
 	Short: "Best bid/ask data over time",
-	Args:  cobra.ExactArgs(1),
+	Args:  cmdutil.InstrumentArgs,
 	Example: `  laevitas perps level1 BTC-PERPETUAL -p 24h
   laevitas perps level1 BTCUSDT --exchange binance -p 3d -r 1h`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client, _ := cmdutil.MustClient()
 		params := level1Flags.ToParams()
-		params.InstrumentName = args[0]
+		params.InstrumentName = cmdutil.InstrumentArg(args)
 		cmdutil.RunAndPrint(client, api.PerpsLevel1, params)
 	},
 }
@@ -186,29 +197,45 @@ var orderbookFlags cmdutil.CommonFlags
 var orderbookCmd = &cobra.Command{
 	Use:   "orderbook <instrument>",
 	Short: "L2 orderbook depth metrics",
-	Args:  cobra.ExactArgs(1),
+	Args:  cmdutil.InstrumentArgs,
 	Example: `  laevitas perps orderbook BTC-PERPETUAL -p 24h
   laevitas perps orderbook BTCUSDT --exchange binance -p 7d -r 1h`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client, _ := cmdutil.MustClient()
 		params := orderbookFlags.ToParams()
-		params.InstrumentName = args[0]
+		params.InstrumentName = cmdutil.InstrumentArg(args)
 		cmdutil.RunAndPrint(client, api.PerpsOrderbook, params)
 	},
 }
 
+var orderbookRawFlags cmdutil.CommonFlags
+
+var orderbookRawCmd = &cobra.Command{
+	Use:   "orderbook-raw <instrument>",
+	Short: "Raw L2 orderbook snapshots",
+	Args:  cmdutil.InstrumentArgs,
+	Example: `  laevitas perps orderbook-raw BTC-PERPETUAL -p 1h
+  laevitas perps orderbook-raw BTCUSDT --exchange binance -n 10`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client, _ := cmdutil.MustClient()
+		params := orderbookRawFlags.ToParams()
+		params.InstrumentName = cmdutil.InstrumentArg(args)
+		cmdutil.RunAndPrint(client, api.PerpsOrderbookRaw, params)
+	},
+}
+
 var tickerFlags cmdutil.CommonFlags
 
 var tickerCmd = &cobra.Command{
 	Use:   "ticker <instrument>",
 	Short: "Historical ticker snapshots",
-	Args:  cobra.ExactArgs(1),
+	Args:  cmdutil.InstrumentArgs,
 	Example: `  laevitas perps ticker BTC-PERPETUAL -p 24h
   laevitas perps ticker BTCUSDT --exchange binance -p 7d -r 1h`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client, _ := cmdutil.MustClient()
 		params := tickerFlags.ToParams()
-		params.InstrumentName = args[0]
+		params.InstrumentName = cmdutil.InstrumentArg(args)
 		cmdutil.RunAndPrint(client, api.PerpsTickerHistory, params)
 	},
 }
@@ -218,13 +245,13 @@ var refPriceFlags cmdutil.CommonFlags
 var refPriceCmd = &cobra.Command{
 	Use:   "ref-price <instrument>",
 	Short: "Mark price and index price OHLC",
-	Args:  cobra.ExactArgs(1),
+	Args:  cmdutil.InstrumentArgs,
 	Example: `  laevitas perps ref-price BTC-PERPETUAL -p 24h
   laevitas perps ref-price BTCUSDT --exchange binance -p 7d -r 1h`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client, _ := cmdutil.MustClient()
 		params := refPriceFlags.ToParams()
-		params.InstrumentName = args[0]
+		params.InstrumentName = cmdutil.InstrumentArg(args)
 		cmdutil.RunAndPrint(client, api.PerpsReferencePrice, params)
 	},
 }
@@ -232,13 +259,13 @@ var refPriceCmd = &cobra.Command{
 var metadataCmd = &cobra.Command{
 	Use:   "metadata <instrument>",
 	Short: "Data availability info",
-	Args:  cobra.ExactArgs(1),
+	Args:  cmdutil.InstrumentArgs,
 	Example: `  laevitas perps metadata BTC-PERPETUAL
   laevitas perps metadata BTCUSDT --exchange binance`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client, _ := cmdutil.MustClient()
 		params := &api.RequestParams{
-			InstrumentName: args[0],
+			InstrumentName: cmdutil.InstrumentArg(args),
 			Exchange:       cmdutil.Exchange,
 		}
 		cmdutil.RunAndPrint(client, api.PerpsMetadata, params)
@@ -341,11 +368,317 @@ and most active instruments — all in a single call.`,
 	},
 }
 
+// ─── carry-compare ──────────────────────────────────────────────────────────
+
+// perpInstrumentFormats maps each exchange to its perpetual naming scheme.
+var perpInstrumentFormats = map[string]string{
+	"deribit": "%s-PERPETUAL",
+	"binance": "%sUSDT",
+	"bybit":   "%sUSDT",
+	"okx":     "%s-USDT-SWAP",
+}
+
+// fundingColumns are tried in order to find the funding value of a carry record.
+var fundingColumns = []string{"funding_rate_close", "funding_rate", "funding_8h_close", "funding"}
+
+var carryCompareFlags struct {
+	cmdutil.CommonFlags
+	Exchanges []string
+}
+
+var carryCompareCmd = &cobra.Command{
+	Use:   "carry-compare <currency>",
+	Short: "Latest funding and carry side by side across exchanges",
+	Long: `Fetches the currency's perpetual carry from each exchange and shows the
+latest record per exchange side by side. FUNDING_SPREAD is each exchange's
+funding rate minus the first exchange's — useful for spotting funding arbitrage.
+
+Instruments follow each exchange's naming: BTC-PERPETUAL (deribit),
+BTCUSDT (binance, bybit), BTC-USDT-SWAP (okx). With -o json the result is
+an object keyed by exchange.`,
+	Args: cobra.ExactArgs(1),
+	Example: `  laevitas perps carry-compare BTC
+  laevitas perps carry-compare ETH --exchanges binance,bybit,okx
+  laevitas perps carry-compare BTC -p 24h -o json | jq '.binance.funding_spread'`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		currency := strings.ToUpper(args[0])
+
+		exchanges := make([]string, 0, len(carryCompareFlags.Exchanges))
+		for _, ex := range carryCompareFlags.Exchanges {
+			normalized, err := config.NormalizeExchange(ex)
+			if err != nil {
+				return err
+			}
+			exchanges = append(exchanges, normalized)
+		}
+		if len(exchanges) < 2 {
+			return fmt.Errorf("--exchanges needs at least two exchanges")
+		}
+
+		client, _ := cmdutil.MustClient()
+		if cmdutil.Explain {
+			for _, ex := range exchanges {
+				params := carryCompareFlags.CommonFlags.ToParams()
+				params.Exchange = ex
+				params.InstrumentName = fmt.Sprintf(perpInstrumentFormats[ex], currency)
+				cmdutil.ExplainRequest(client, api.PerpsCarry, params)
+			}
+			return nil
+		}
+		p := cmdutil.MustPrinter()
+
+		cmdutil.StartSpinner()
+		ctx, stop := cmdutil.SignalContext()
+		defer stop()
+		var rows []map[string]interface{}
+		var lastErr error
+		for _, ex := range exchanges {
+			params := carryCompareFlags.CommonFlags.ToParams()
+			params.Exchange = ex
+			params.InstrumentName = fmt.Sprintf(perpInstrumentFormats[ex], currency)
+
+			data, err := client.Get(ctx, api.PerpsCarry, params)
+			if cmdutil.IsCancelled(err) {
+				cmdutil.StopSpinner()
+				return err
+			}
+			if err != nil {
+				output.Warnf("%s %s: %s", ex, params.InstrumentName, err)
+				lastErr = err
+				continue
+			}
+			rec := latestRecord(output.ExtractRecords(data))
+			if rec == nil {
+				output.Warnf("%s %s: no data", ex, params.InstrumentName)
+				continue
+			}
+			rec["exchange"] = ex
+			if _, ok := rec["instrument_name"]; !ok {
+				rec["instrument_name"] = params.InstrumentName
+			}
+			rows = append(rows, rec)
+		}
+		cmdutil.StopSpinner()
+
+		if len(rows) == 0 {
+			// Wrapped so the exit code still tells auth or payment failures apart
+			if lastErr != nil {
+				return fmt.Errorf("no carry data for %s on %s: %w", currency, strings.Join(exchanges, ", "), lastErr)
+			}
+			return fmt.Errorf("no carry data for %s on %s", currency, strings.Join(exchanges, ", "))
+		}
+
+		// Spread against the first exchange that returned a funding rate
+		var ref float64
+		hasRef := false
+		for _, rec := range rows {
+			f, ok := recordFunding(rec)
+			if !ok {
+				continue
+			}
+			if !hasRef {
+				ref, hasRef = f, true
+			}
+			rec["funding_spread"] = f - ref
+		}
+
+		if p.Format == output.FormatJSON && !cmdutil.CountOnly {
+			keyed := make(map[string]interface{}, len(rows))
+			for _, rec := range rows {
+				keyed[rec["exchange"].(string)] = rec
+			}
+			return cmdutil.PrintComputed(p, client, keyed)
+		}
+		return cmdutil.PrintComputed(p, client, rows)
+	},
+}
+
+// latestRecord returns the record with the newest timestamp (or date).
+func latestRecord(records []map[string]interface{}) map[string]interface{} {
+	var latest map[string]interface{}
+	var latestKey string
+	for _, rec := range records {
+		_, key := timeKey(rec)
+		if latest == nil || key > latestKey {
+			latest, latestKey = rec, key
+		}
+	}
+	return latest
+}
+
+// timeKey returns the record's time column and a key that sorts
+// chronologically, or empty strings if it has none.
+func timeKey(rec map[string]interface{}) (column, key string) {
+	for _, col := range []string{"timestamp", "date", "minute"} {
+		if v, ok := rec[col]; ok && v != nil {
+			if f, isNum := output.ToFloat(v); isNum {
+				return col, fmt.Sprintf("%020.0f", f)
+			}
+			return col, fmt.Sprintf("%v", v)
+		}
+	}
+	return "", ""
+}
+
+// recordFunding returns the funding rate of a carry record.
+func recordFunding(rec map[string]interface{}) (float64, bool) {
+	for _, col := range fundingColumns {
+		if f, ok := output.ToFloat(rec[col]); ok {
+			return f, true
+		}
+	}
+	return 0, false
+}
+
+// ─── spread ─────────────────────────────────────────────────────────────────
+
+// Columns tried in order for a record's price: candle close, then mark.
+var (
+	spreadCloseColumns = []string{"close", "mark_price_close", "mark_price"}
+	spreadMarkColumns  = []string{"mark_price_close", "mark_price", "close"}
+)
+
+var spreadFlags struct {
+	cmdutil.CommonFlags
+	Mark bool
+}
+
+var spreadCmd = &cobra.Command{
+	Use:   "spread <instrument-a> <instrument-b>",
+	Short: "Price spread and ratio between two perpetuals over time",
+	Long: `Fetches both instruments' price series, joins them on timestamp and
+shows PRICE_A, PRICE_B, SPREAD (A − B) and RATIO (A / B) per bar — for
+pairs trading and basis monitoring. Bars only one side has are dropped.
+
+Prices are OHLCVT candle closes; --mark uses ref-price mark closes
+instead. Tables chart the spread (--chart-column ratio charts the ratio).`,
+	Args: cobra.ExactArgs(2),
+	Example: `  laevitas perps spread BTC-PERPETUAL ETH-PERPETUAL -p 7d
+  laevitas perps spread BTCUSDT ETHUSDT --exchange binance -p 30d -r 1d
+  laevitas perps spread BTC-PERPETUAL ETH-PERPETUAL --mark -o json | jq '.[-1].ratio'`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		endpoint, columns := api.PerpsOHLCVT, spreadCloseColumns
+		if spreadFlags.Mark {
+			endpoint, columns = api.PerpsReferencePrice, spreadMarkColumns
+		}
+
+		client, _ := cmdutil.MustClient()
+		if cmdutil.Explain {
+			for _, name := range args {
+				params := spreadFlags.CommonFlags.ToParams()
+				params.InstrumentName = name
+				cmdutil.ExplainRequest(client, endpoint, params)
+			}
+			return nil
+		}
+		p := cmdutil.MustPrinter()
+
+		cmdutil.StartSpinner()
+		ctx, stop := cmdutil.SignalContext()
+		defer stop()
+		var series [2][]map[string]interface{}
+		for i, name := range args {
+			params := spreadFlags.CommonFlags.ToParams()
+			params.InstrumentName = name
+			data, err := client.Get(ctx, endpoint, params)
+			if err != nil {
+				cmdutil.StopSpinner()
+				return fmt.Errorf("%s: %w", name, err)
+			}
+			series[i] = output.ExtractRecords(data)
+		}
+		cmdutil.StopSpinner()
+
+		rows := joinSpread(series[0], series[1], columns)
+		if len(rows) == 0 {
+			return fmt.Errorf("no overlapping bars for %s and %s", args[0], args[1])
+		}
+
+		if err := cmdutil.PrintComputed(p, client, rows); err != nil {
+			return err
+		}
+		if p.Format == output.FormatTable && !cmdutil.CountOnly && !cmdutil.NoChart && len(rows) > 1 {
+			col, caption := "spread", fmt.Sprintf("Spread %s − %s", args[0], args[1])
+			if cmdutil.ChartColumn == "ratio" {
+				col, caption = "ratio", fmt.Sprintf("Ratio %s / %s", args[0], args[1])
+			}
+			data, err := json.Marshal(rows)
+			if err != nil {
+				return err
+			}
+			output.RenderChart(p.Writer, data, col, caption)
+		}
+		return nil
+	},
+}
+
+// joinSpread pairs the records of a and b that share a timestamp, oldest
+// first, and computes the spread and ratio of their prices.
+func joinSpread(a, b []map[string]interface{}, columns []string) []map[string]interface{} {
+	type bar struct {
+		column string
+		value  interface{}
+		price  float64
+	}
+	index := func(records []map[string]interface{}) map[string]bar {
+		bars := make(map[string]bar, len(records))
+		for _, rec := range records {
+			col, key := timeKey(rec)
+			if key == "" {
+				continue
+			}
+			if price, ok := firstPrice(rec, columns); ok {
+				bars[key] = bar{col, rec[col], price}
+			}
+		}
+		return bars
+	}
+	barsA, barsB := index(a), index(b)
+
+	keys := make([]string, 0, len(barsA))
+	for key := range barsA {
+		if _, ok := barsB[key]; ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	rows := make([]map[string]interface{}, 0, len(keys))
+	for _, key := range keys {
+		pa, pb := barsA[key], barsB[key]
+		row := map[string]interface{}{
+			pa.column: pa.value,
+			"price_a": pa.price,
+			"price_b": pb.price,
+			"spread":  pa.price - pb.price,
+			"ratio":   nil,
+		}
+		if pb.price != 0 {
+			row["ratio"] = pa.price / pb.price
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func firstPrice(rec map[string]interface{}, columns []string) (float64, bool) {
+	for _, col := range columns {
+		if f, ok := output.ToFloat(rec[col]); ok {
+			return f, true
+		}
+	}
+	return 0, false
+}
+
 func init() {
-	snapshotCmd.Flags().StringVar(&snapshotFlags.Currency, "currency", "", "Filter by currency (BTC, ETH)")
+	snapshotCmd.Flags().StringVar(&snapshotFlags.Currency, "currency", "", "Filter by currency (BTC, ETH; comma-separate for several)")
 	snapshotCmd.Flags().StringVar(&snapshotFlags.Date, "date", "", "Snapshot datetime (ISO 8601)")
 
 	cmdutil.AddCommonFlags(carryCmd, &carryFlags)
+	cmdutil.AddCommonFlags(carryCompareCmd, &carryCompareFlags.CommonFlags)
+	carryCompareCmd.Flags().StringSliceVar(&carryCompareFlags.Exchanges, "exchanges", config.Exchanges, "Exchanges to compare (comma-separated)")
+	cmdutil.AddCommonFlags(spreadCmd, &spreadFlags.CommonFlags)
+	spreadCmd.Flags().BoolVar(&spreadFlags.Mark, "mark", false, "Compare ref-price mark closes instead of candle closes")
 	cmdutil.AddCommonFlags(ohlcvCmd, &ohlcvFlags)
 	cmdutil.AddCommonFlags(oiCmd, &oiFlags)
 
@@ -361,6 +694,7 @@ func init() {
 	cmdutil.AddCommonFlags(volumeCmd, &volumeFlags)
 	cmdutil.AddCommonFlags(level1Cmd, &level1Flags)
 	cmdutil.AddCommonFlags(orderbookCmd, &orderbookFlags)
+	cmdutil.AddCommonFlags(orderbookRawCmd, &orderbookRawFlags)
 	cmdutil.AddCommonFlags(tickerCmd, &tickerFlags)
 	cmdutil.AddCommonFlags(refPriceCmd, &refPriceFlags)
 
@@ -386,15 +720,25 @@ func init() {
 	flowCmd.Flags().IntVar(&flowFlags.TopN, "top-n", 10, "Number of notable trades / active instruments")
 	_ = flowCmd.MarkFlagRequired("currency")
 
+	cmdutil.AddCatalogFlags(catalogCmd, &catalogFlags, false, "linear, inverse")
+
+	cmdutil.AllowInstrumentsFile(carryCmd, ohlcvCmd, oiCmd, tradesCmd, volumeCmd, level1Cmd,
+		orderbookCmd, orderbookRawCmd, tickerCmd, refPriceCmd, metadataCmd)
+	cmdutil.ComputedOutput(carryCompareCmd, spreadCmd)
+
 	Cmd.AddCommand(catalogCmd)
+	cmdutil.SuggestTimeout(snapshotCmd, cmdutil.SnapshotTimeout)
 	Cmd.AddCommand(snapshotCmd)
 	Cmd.AddCommand(carryCmd)
+	Cmd.AddCommand(carryCompareCmd)
+	Cmd.AddCommand(spreadCmd)
 	Cmd.AddCommand(ohlcvCmd)
 	Cmd.AddCommand(oiCmd)
 	Cmd.AddCommand(tradesCmd)
 	Cmd.AddCommand(volumeCmd)
 	Cmd.AddCommand(level1Cmd)
 	Cmd.AddCommand(orderbookCmd)
+	Cmd.AddCommand(orderbookRawCmd)
 	Cmd.AddCommand(tickerCmd)
 	Cmd.AddCommand(refPriceCmd)
 	Cmd.AddCommand(metadataCmd)
@@ -0,0 +1,29 @@
+package perps
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/laevitas/cli/internal/cmdutil"
+)
+
+// TestCarryCompareKeepsAPIError fails every exchange with a 401; the
+// returned error must still map to the auth exit code.
+func TestCarryCompareKeepsAPIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"invalid api key"}`, http.StatusUnauthorized)
+	}))
+	t.Cleanup(srv.Close)
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("LAEVITAS_BASE_URL", srv.URL)
+	t.Setenv("LAEVITAS_API_KEY", "bad-key")
+
+	err := carryCompareCmd.RunE(carryCompareCmd, []string{"BTC"})
+	if err == nil {
+		t.Fatal("no error when every exchange failed")
+	}
+	if code := cmdutil.ExitCode(err); code != cmdutil.ExitAuth {
+		t.Errorf("exit code %d, want %d (auth): %v", code, cmdutil.ExitAuth, err)
+	}
+}
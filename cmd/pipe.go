@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/laevitas/cli/internal/api"
+	"github.com/laevitas/cli/internal/output"
+)
+
+// ─── REPL pipes ─────────────────────────────────────────────────────────────
+
+// splitPipe splits a REPL line at its first unquoted "|" into the laevitas
+// command and the shell command its output is piped to. Quotes follow the
+// same rules as splitArgs.
+func splitPipe(line string) (left, right string, ok bool) {
+	quoteChar := byte(0)
+	for i := 0; i < len(line); i++ {
+		ch := line[i]
+		switch {
+		case quoteChar != 0:
+			if ch == quoteChar {
+				quoteChar = 0
+			}
+		case ch == '"' || ch == '\'':
+			quoteChar = ch
+		case ch == '|':
+			return strings.TrimSpace(line[:i]), strings.TrimSpace(line[i+1:]), true
+		}
+	}
+	return line, "", false
+}
+
+// runPiped runs a REPL command with its stdout piped into shell, run by
+// /bin/sh -c, e.g. perps carry BTC-PERPETUAL -o json | jq '.data[0]'.
+func runPiped(command, shell string, client *api.Client) {
+	if command == "" || shell == "" {
+		output.Errorf("Usage: <command> | <shell command>")
+		return
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		output.Errorf("Creating pipe: %s", err)
+		return
+	}
+	sh := exec.Command("/bin/sh", "-c", shell)
+	sh.Stdin = r
+	sh.Stdout = os.Stdout
+	sh.Stderr = os.Stderr
+	if err := sh.Start(); err != nil {
+		r.Close()
+		w.Close()
+		output.Errorf("Running %s: %s", shell, err)
+		return
+	}
+	r.Close()
+
+	// Commands write to os.Stdout directly, so swap it for the pipe while
+	// the left side runs. Not a terminal any more, so no colors or pager.
+	stdout, terminal := os.Stdout, output.Terminal
+	os.Stdout, output.Terminal = w, w
+	executeREPLCommand(command, client)
+	os.Stdout, output.Terminal = stdout, terminal
+	w.Close()
+
+	if err := sh.Wait(); err != nil {
+		if exit, ok := err.(*exec.ExitError); ok {
+			output.Errorf("%s: exit status %d", firstWord(shell), exit.ExitCode())
+		} else {
+			output.Errorf("%s: %s", firstWord(shell), err)
+		}
+	}
+}
+
+// firstWord returns the program name of a shell command, for messages.
+func firstWord(shell string) string {
+	if f := strings.Fields(shell); len(f) > 0 {
+		return f[0]
+	}
+	return shell
+}
@@ -76,11 +76,11 @@ var ohlcvtFlags cmdutil.CommonFlags
 var ohlcvtCmd = &cobra.Command{
 	Use:   "ohlcvt <instrument>",
 	Short: "Probability OHLCVT candle data (prices = 0.0-1.0)",
-	Args:  cobra.ExactArgs(1),
+	Args:  cmdutil.InstrumentArgs,
 	Run: func(cmd *cobra.Command, args []string) {
 		client, _ := cmdutil.MustClient()
 		params := ohlcvtFlags.ToParams()
-		params.InstrumentName = args[0]
+		params.InstrumentName = cmdutil.InstrumentArg(args)
 		cmdutil.RunAndPrint(client, api.PredictionsOHLCVT, params)
 	},
 }
@@ -90,11 +90,11 @@ var tradesFlags cmdutil.CommonFlags
 var tradesCmd = &cobra.Command{
 	Use:   "trades <instrument>",
 	Short: "Individual prediction market trades",
-	Args:  cobra.ExactArgs(1),
+	Args:  cmdutil.InstrumentArgs,
 	Run: func(cmd *cobra.Command, args []string) {
 		client, _ := cmdutil.MustClient()
 		params := tradesFlags.ToParams()
-		params.InstrumentName = args[0]
+		params.InstrumentName = cmdutil.InstrumentArg(args)
 		cmdutil.RunAndPrint(client, api.PredictionsTrades, params)
 	},
 }
@@ -104,11 +104,11 @@ var tickerFlags cmdutil.CommonFlags
 var tickerCmd = &cobra.Command{
 	Use:   "ticker <instrument>",
 	Short: "Historical ticker — probability, bid/ask, spread, liquidity",
-	Args:  cobra.ExactArgs(1),
+	Args:  cmdutil.InstrumentArgs,
 	Run: func(cmd *cobra.Command, args []string) {
 		client, _ := cmdutil.MustClient()
 		params := tickerFlags.ToParams()
-		params.InstrumentName = args[0]
+		params.InstrumentName = cmdutil.InstrumentArg(args)
 		cmdutil.RunAndPrint(client, api.PredictionsTickerHistory, params)
 	},
 }
@@ -118,11 +118,11 @@ var orderbookFlags cmdutil.CommonFlags
 var orderbookCmd = &cobra.Command{
 	Use:   "orderbook <instrument>",
 	Short: "Raw L2 orderbook snapshots",
-	Args:  cobra.ExactArgs(1),
+	Args:  cmdutil.InstrumentArgs,
 	Run: func(cmd *cobra.Command, args []string) {
 		client, _ := cmdutil.MustClient()
 		params := orderbookFlags.ToParams()
-		params.InstrumentName = args[0]
+		params.InstrumentName = cmdutil.InstrumentArg(args)
 		cmdutil.RunAndPrint(client, api.PredictionsOrderbookRaw, params)
 	},
 }
@@ -130,14 +130,46 @@ var orderbookCmd = &cobra.Command{
 var metadataCmd = &cobra.Command{
 	Use:   "metadata <instrument>",
 	Short: "Data availability info",
-	Args:  cobra.ExactArgs(1),
+	Args:  cmdutil.InstrumentArgs,
 	Run: func(cmd *cobra.Command, args []string) {
 		client, _ := cmdutil.MustClient()
-		params := &api.RequestParams{InstrumentName: args[0]}
+		params := &api.RequestParams{InstrumentName: cmdutil.InstrumentArg(args)}
 		cmdutil.RunAndPrint(client, api.PredictionsMetadata, params)
 	},
 }
 
+var flowFlags struct {
+	Category  string
+	EventSlug string
+	Start     string
+	End       string
+	MinAmount float64
+	TopN      int
+}
+
+var flowCmd = &cobra.Command{
+	Use:   "flow",
+	Short: "Aggregated flow summary — trades, volume, probability moves",
+	Long: `Returns a prediction market flow summary including trade volume,
+buy/sell breakdown, biggest probability movers, notable trades,
+and most active markets — all in a single call.`,
+	Example: `  laevitas predictions flow --category crypto
+  laevitas predictions flow --event will-bitcoin-reach-250000 --top-n 20
+  laevitas predictions flow --category politics --min-amount 1000 --start 2026-02-26T00:00:00Z`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client, _ := cmdutil.MustClient()
+		params := &api.RequestParams{
+			Category:  flowFlags.Category,
+			EventSlug: flowFlags.EventSlug,
+			Start:     flowFlags.Start,
+			End:       flowFlags.End,
+			MinAmount: flowFlags.MinAmount,
+			TopN:      flowFlags.TopN,
+		}
+		cmdutil.RunAndPrint(client, api.PredictionsFlow, params)
+	},
+}
+
 func init() {
 	catalogCmd.Flags().StringVar(&catalogFlags.Category, "category", "", "Filter by category")
 	catalogCmd.Flags().StringVar(&catalogFlags.EventSlug, "event", "", "Filter by event slug")
@@ -148,17 +180,28 @@ func init() {
 	snapshotCmd.Flags().StringVar(&snapshotFlags.Date, "date", "", "Snapshot datetime (ISO 8601)")
 	snapshotCmd.Flags().StringVarP(&snapshotFlags.Resolution, "resolution", "r", "1h", "Resolution")
 
+	flowCmd.Flags().StringVar(&flowFlags.Category, "category", "", "Filter by category")
+	flowCmd.Flags().StringVar(&flowFlags.EventSlug, "event", "", "Filter by event slug")
+	flowCmd.Flags().StringVar(&flowFlags.Start, "start", "", "Start datetime (ISO 8601)")
+	flowCmd.Flags().StringVar(&flowFlags.End, "end", "", "End datetime (ISO 8601)")
+	flowCmd.Flags().Float64Var(&flowFlags.MinAmount, "min-amount", 0, "Min trade amount for notable trades")
+	flowCmd.Flags().IntVar(&flowFlags.TopN, "top-n", 10, "Number of notable trades / active markets")
+
 	cmdutil.AddCommonFlags(ohlcvtCmd, &ohlcvtFlags)
 	cmdutil.AddCommonFlags(tradesCmd, &tradesFlags)
 	cmdutil.AddCommonFlags(tickerCmd, &tickerFlags)
 	cmdutil.AddCommonFlags(orderbookCmd, &orderbookFlags)
 
+	cmdutil.AllowInstrumentsFile(ohlcvtCmd, tradesCmd, tickerCmd, orderbookCmd, metadataCmd)
+
 	Cmd.AddCommand(catalogCmd)
 	Cmd.AddCommand(categoriesCmd)
+	cmdutil.SuggestTimeout(snapshotCmd, cmdutil.SnapshotTimeout)
 	Cmd.AddCommand(snapshotCmd)
 	Cmd.AddCommand(ohlcvtCmd)
 	Cmd.AddCommand(tradesCmd)
 	Cmd.AddCommand(tickerCmd)
 	Cmd.AddCommand(orderbookCmd)
 	Cmd.AddCommand(metadataCmd)
+	Cmd.AddCommand(flowCmd)
 }
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/laevitas/cli/internal/cmdutil"
+	"github.com/laevitas/cli/internal/completer"
+	"github.com/laevitas/cli/internal/output"
+)
+
+var resolveFlags struct {
+	Type  string
+	Limit int
+}
+
+var resolveCmd = &cobra.Command{
+	Use:   "resolve <currency>",
+	Short: "Find an exchange's instrument names for a currency",
+	Long: `Look up the instrument names an exchange uses for a base currency in the
+cached catalogs — e.g. BTC is BTC-PERPETUAL on Deribit but BTCUSDT on
+Binance. Names are printed one per line, best match first, ready to pass
+to another command. Use -o json or -o csv for structured output.`,
+	Example: `  laevitas resolve BTC --exchange binance --type perp
+  laevitas resolve ETH --exchange deribit --type future
+  laevitas perps carry $(laevitas resolve SOL --exchange okx --type perp -n 1) --exchange okx`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, _ := cmdutil.MustClient()
+		if client == nil {
+			return fmt.Errorf("no API client available")
+		}
+
+		results, fuzzy, err := completer.New(client, nil).Resolve(args[0], resolveFlags.Type, cmdutil.Exchange)
+		if err != nil {
+			return err
+		}
+		if len(results) == 0 {
+			return fmt.Errorf("no %s instruments found for %s", cmdutil.Exchange, strings.ToUpper(args[0]))
+		}
+		if fuzzy {
+			output.Warnf("No %s instrument named like %s; showing names containing it.", cmdutil.Exchange, strings.ToUpper(args[0]))
+		}
+		if n := resolveFlags.Limit; n > 0 && n < len(results) {
+			results = results[:n]
+		}
+
+		// Plain names unless a structured format was asked for explicitly
+		switch strings.ToLower(cmdutil.OutputFormat) {
+		case "", "auto", "table":
+			for _, r := range results {
+				fmt.Println(r.Instrument)
+			}
+			return nil
+		}
+		data, err := json.Marshal(results)
+		if err != nil {
+			return err
+		}
+		return cmdutil.MustPrinter().Print(data)
+	},
+}
+
+func init() {
+	resolveCmd.Flags().StringVar(&resolveFlags.Type, "type", "", "Instrument type: "+completer.ResolveKinds+" (default all)")
+	resolveCmd.Flags().IntVarP(&resolveFlags.Limit, "limit", "n", 0, "Print at most N matches (0 = all)")
+}
@@ -3,6 +3,9 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"runtime"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
@@ -13,6 +16,7 @@ import (
 	"github.com/laevitas/cli/cmd/perps"
 	"github.com/laevitas/cli/cmd/predictions"
 	"github.com/laevitas/cli/cmd/update"
+	"github.com/laevitas/cli/internal/api"
 	"github.com/laevitas/cli/internal/cmdutil"
 	internalConfig "github.com/laevitas/cli/internal/config"
 	"github.com/laevitas/cli/internal/output"
@@ -24,8 +28,47 @@ var (
 	exchange      string
 	verbose       bool
 	noChart       bool
+	chartColumn   string
+	sparkColumn   string
+	chartMA       int
+	chartWidth    int
+	chartHeight   int
+	stats         bool
+	quiet         bool
+	raw           bool
+	assumeYes     bool
+	explain       bool
+	describe      bool
+	envelope      bool
+	noPager       bool
+	instFile      string
+	concurrency   int
+	maxRetries    int
+	retryOn       string
+	humanize      bool
+	tmpl          string
+	timeout       time.Duration
+	noColor       bool
+	aggregateBy   string
+	aggSpec       string
+	filters       []string
+	sortBy        string
+	profile       string
 	wide          bool
 	widthOverride int
+	wrap          bool
+	noFooter      bool
+	noSeparator   bool
+	compact       bool
+	maxWidth      int
+	countOnly     bool
+	saveResp      string
+	replay        string
+	locale        string
+	transcript    string
+	timings       bool
+	userAgent     string
+	logFile       string
 )
 
 const helpBanner = `  ██╗      █████╗ ███████╗██╗   ██╗██╗████████╗ █████╗ ███████╗
@@ -42,7 +85,7 @@ var rootCmd = &cobra.Command{
 	Long: `LAEVITAS CLI provides real-time access to crypto derivatives data
 including futures, perpetuals, options, volatility surfaces, and prediction markets.
 
-Data sourced from Deribit, Binance, and Polymarket.
+Data sourced from Deribit, Binance, Bybit, OKX, and Polymarket.
 
   Authenticate:  laevitas config init
   Quick start:   laevitas futures snapshot --currency BTC
@@ -52,35 +95,199 @@ Data sourced from Deribit, Binance, and Polymarket.
 Documentation:  https://apiv2.laevitas.ch/redoc
 API Reference:  https://apiv2.laevitas.ch/redoc`,
 	Version: fmt.Sprintf("%s (commit: %s, built: %s)", version.Version, version.CommitSHA, version.BuildDate),
-	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if tmpl != "" && outputFormat == "auto" {
+			outputFormat = "template"
+		}
 		switch outputFormat {
-		case "auto", "json", "table", "csv":
+		case "auto", "json", "table", "csv", "tsv", "markdown", "md":
+		case "template":
+			if tmpl == "" {
+				return fmt.Errorf("-o template needs --template, e.g. --template '{{.instrument_name}} {{.mark_price}}'")
+			}
 		default:
-			fmt.Fprintf(os.Stderr, "Invalid output format: %s (use: auto, json, table, csv)\n", outputFormat)
-			os.Exit(1)
+			return fmt.Errorf("invalid output format: %s (use: %s, template)", outputFormat, strings.Join(internalConfig.Outputs, ", "))
+		}
+		if err := output.SetTemplate(tmpl); err != nil {
+			return err
 		}
 		// Push globals into cmdutil so subcommands can access them
+		internalConfig.ProfileOverride = profile
 		cmdutil.OutputFormat = outputFormat
 		if exchange != "" {
-			cmdutil.Exchange = exchange
+			ex, err := internalConfig.NormalizeExchange(exchange)
+			if err != nil {
+				return err
+			}
+			cmdutil.Exchange = ex
 		}
 		cmdutil.Verbose = verbose
 		cmdutil.NoChart = noChart
+		cmdutil.ChartColumn = chartColumn
+		cmdutil.SparkColumn = sparkColumn
+		if chartMA < 0 {
+			return fmt.Errorf("invalid --chart-ma: %d (must be >= 0)", chartMA)
+		}
+		output.ChartMA = chartMA
+		if chartWidth < 0 || chartHeight < 2 {
+			return fmt.Errorf("invalid chart size: %dx%d (width >= 0, height >= 2)", chartWidth, chartHeight)
+		}
+		output.ChartWidth = chartWidth
+		output.ChartHeight = chartHeight
+		cmdutil.Stats = stats
+		cmdutil.Timings = timings
+		cmdutil.UserAgent = userAgent
+		if countOnly && raw {
+			return fmt.Errorf("--count and --raw can't be combined")
+		}
+		cmdutil.CountOnly = countOnly
+		if envelope && raw {
+			return fmt.Errorf("--meta and --raw can't be combined")
+		}
+		output.Envelope = envelope
+		cmdutil.AssumeYes = assumeYes
+		cmdutil.Explain = explain
+		cmdutil.Describe = describe
+		if instFile != "" && !cmdutil.AllowsInstrumentsFile(cmd) {
+			return fmt.Errorf("--instruments-file only works with commands that take an <instrument>")
+		}
+		cmdutil.InstrumentsFile = instFile
+		if replay != "" && instFile != "" {
+			return fmt.Errorf("--replay and --instruments-file can't be combined")
+		}
+		// Computed tables have no single API response to pass through
+		if cmdutil.IsComputedOutput(cmd) {
+			switch {
+			case raw:
+				return fmt.Errorf("--raw only works with commands that print an API response")
+			case saveResp != "":
+				return fmt.Errorf("--save-response only works with commands that print an API response")
+			case replay != "":
+				return fmt.Errorf("--replay only works with commands that print an API response")
+			}
+		}
+		cmdutil.SaveResponse = saveResp
+		cmdutil.Replay = replay
+		// In the REPL the log stays open across commands until another path is given
+		if logFile != "" && (cmdutil.Log == nil || cmdutil.Log.Path != logFile) {
+			l, err := api.OpenLog(logFile)
+			if err != nil {
+				return fmt.Errorf("opening --log-file: %w", err)
+			}
+			cmdutil.Log.Close()
+			cmdutil.Log = l
+		}
+		cmdutil.CommandPath = cmd.CommandPath()
+		cmdutil.CommandStart = time.Now()
+		if concurrency < 1 {
+			return fmt.Errorf("invalid --concurrency: %d (must be >= 1)", concurrency)
+		}
+		if concurrency > cmdutil.MaxConcurrency {
+			output.Warnf("--concurrency capped at %d to stay under API rate limits", cmdutil.MaxConcurrency)
+			concurrency = cmdutil.MaxConcurrency
+		}
+		cmdutil.Concurrency = concurrency
+		if maxRetries < 0 {
+			return fmt.Errorf("invalid --max-retries: %d (must be >= 0)", maxRetries)
+		}
+		cmdutil.MaxRetries = maxRetries
+		if retryOn != "" {
+			if _, err := internalConfig.ParseRetryOn(retryOn); err != nil {
+				return fmt.Errorf("invalid --retry-on: %s (must be comma-separated 4xx/5xx status codes, e.g. 429,503, or none)", retryOn)
+			}
+		}
+		cmdutil.RetryOn = retryOn
+		switch {
+		case timeout < 0:
+			return fmt.Errorf("invalid --timeout: %s (must be positive)", timeout)
+		case timeout > 0:
+			cmdutil.Timeout = timeout
+		default:
+			cmdutil.Timeout = cmdutil.CommandTimeout(cmd)
+		}
+		output.NoPager = noPager
+		output.Quiet = quiet
+		output.Raw = raw
+		output.Humanize = humanize
+		if err := output.SetLocale(locale); err != nil {
+			return err
+		}
+		output.SetNoColor(noColor)
+		aggs, err := output.ParseAggs(aggSpec)
+		if err != nil {
+			return err
+		}
+		sortKeys, err := output.ParseSortKeys(sortBy)
+		if err != nil {
+			return err
+		}
+		output.AggregateBy = aggregateBy
+		output.Aggs = aggs
+		output.SortKeys = sortKeys
+		output.Filters = nil
+		for _, expr := range filters {
+			f, err := output.ParseFilter(expr)
+			if err != nil {
+				return err
+			}
+			output.Filters = append(output.Filters, f)
+		}
 		// Width override: --wide takes precedence over --width
 		if wide {
 			output.WidthOverride = 0
 		} else if widthOverride > 0 {
 			output.WidthOverride = widthOverride
 		}
+		if maxWidth < 0 {
+			return fmt.Errorf("invalid --max-width: %d (must be >= 0)", maxWidth)
+		}
+		output.MaxColWidth = maxWidth
+		output.Wrap = wrap
+		output.NoFooter = noFooter
+		output.NoSeparator = noSeparator
+		output.Compact = compact
+
+		// One-shot runs in a terminal get a stderr spinner; the REPL sets its own
+		if !cmdutil.InteractiveMode {
+			cmdutil.SpinnerInstance = cmdutil.ProgressSpinner()
+		}
+		return nil
 	},
 	SilenceUsage:  true,
 	SilenceErrors: true,
 }
 
+var versionJSON bool
+
+// versionInfo is the machine-readable form of `version --json`.
+type versionInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildDate string `json:"build_date"`
+	Go        string `json:"go"`
+	OS        string `json:"os"`
+	Arch      string `json:"arch"`
+}
+
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Print version and build information",
-	Run: func(cmd *cobra.Command, args []string) {
+	Example: `  laevitas version
+  laevitas version --json
+  laevitas version -o json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// JSON only when asked for explicitly, so the output never depends on a TTY
+		if versionJSON || outputFormat == "json" {
+			return output.NewPrinter("json").Print(versionInfo{
+				Version:   version.Version,
+				Commit:    version.CommitSHA,
+				BuildDate: version.BuildDate,
+				Go:        runtime.Version(),
+				OS:        runtime.GOOS,
+				Arch:      runtime.GOARCH,
+			})
+		}
+
 		dim := "\033[2m"
 		reset := "\033[0m"
 		if !term.IsTerminal(int(os.Stdout.Fd())) {
@@ -90,6 +297,7 @@ var versionCmd = &cobra.Command{
 		fmt.Printf("laevitas v%s (build: %s, %s)\n", version.Version, version.CommitSHA, version.BuildDate)
 		fmt.Printf("%sLaevitas Pte. Ltd. — https://www.laevitas.ch%s\n", dim, reset)
 		fmt.Printf("%sAPI: https://apiv2.laevitas.ch%s\n", dim, reset)
+		return nil
 	},
 }
 
@@ -125,12 +333,53 @@ Twitter: https://twitter.com/laevitas1` + "\033[0m" + `
 `)
 	}
 
-	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", internalConfig.DefaultOutput, "Output format: auto, json, table, csv")
-	rootCmd.PersistentFlags().StringVar(&exchange, "exchange", "", "Exchange (deribit, binance). Overrides config default.")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", internalConfig.DefaultOutput, "Output format: "+strings.Join(internalConfig.Outputs, ", "))
+	rootCmd.PersistentFlags().StringVar(&exchange, "exchange", "", "Exchange ("+strings.Join(internalConfig.Exchanges, ", ")+"). Overrides config default.")
+	rootCmd.PersistentFlags().StringVar(&profile, "profile", "", "Config profile to use for this command (see: config profile list)")
 	rootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "Show full HTTP request/response for debugging")
 	rootCmd.PersistentFlags().BoolVar(&noChart, "no-chart", false, "Disable inline charts for time-series data")
+	rootCmd.PersistentFlags().StringVar(&chartColumn, "chart-column", "", "Column to chart instead of the default series (e.g. mark_price_close)")
+	rootCmd.PersistentFlags().StringVar(&sparkColumn, "spark", "", "Print a one-line sparkline of this column below tables")
+	rootCmd.PersistentFlags().IntVar(&chartMA, "chart-ma", 0, "Overlay an N-period moving average on charts")
+	rootCmd.PersistentFlags().IntVar(&chartWidth, "chart-width", 60, "Chart width in columns (0 = fill terminal)")
+	rootCmd.PersistentFlags().IntVar(&chartHeight, "chart-height", 15, "Chart height in rows")
+	rootCmd.PersistentFlags().BoolVar(&stats, "stats", false, "Print request timing, size, and payment summary to stderr")
+	rootCmd.PersistentFlags().BoolVar(&timings, "timings", false, "Print DNS, connect, TLS, first-byte and total time of the request to stderr")
+	rootCmd.PersistentFlags().StringVar(&logFile, "log-file", "", "Append JSON-lines logs of requests, retries, payments and errors to this file")
+	rootCmd.PersistentFlags().StringVar(&userAgent, "user-agent", "", "Override the User-Agent header (default laevitas-cli/<version>)")
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "Suppress warnings, hints, footers and the spinner on stderr (errors still print)")
+	rootCmd.PersistentFlags().StringVar(&instFile, "instruments-file", "", "Run an instrument command for each instrument in this file (- = stdin) and merge the results")
+	rootCmd.PersistentFlags().IntVar(&concurrency, "concurrency", cmdutil.DefaultConcurrency, fmt.Sprintf("Parallel requests for --instruments-file (max %d)", cmdutil.MaxConcurrency))
+	rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", 0, "Per-request timeout, e.g. 45s or 2m (default 30s; snapshots 2m)")
+	rootCmd.PersistentFlags().IntVar(&maxRetries, "max-retries", api.DefaultMaxRetries, "Retries on --retry-on statuses and transient network errors, with backoff (0 = none)")
+	rootCmd.PersistentFlags().StringVar(&retryOn, "retry-on", "", "HTTP statuses to retry with backoff, e.g. 429,503, or none (default 429; config retry_on)")
+	rootCmd.PersistentFlags().BoolVar(&noPager, "no-pager", false, "Don't page long table output through $PAGER / less")
+	rootCmd.PersistentFlags().BoolVar(&explain, "explain", false, "Describe the endpoint and parameters a command would query, without running it")
+	rootCmd.PersistentFlags().BoolVar(&describe, "describe", false, "Fetch one record and list its fields, types and table priority instead of the data")
+	rootCmd.PersistentFlags().BoolVarP(&assumeYes, "yes", "y", false, "Approve x402 payments without asking (max_payment_usd still applies)")
+	rootCmd.PersistentFlags().BoolVar(&countOnly, "count", false, "Print only the number of records (JSON: {\"count\": N})")
+	rootCmd.PersistentFlags().BoolVar(&envelope, "meta", false, "JSON: wrap output as {\"data\": [...], \"count\": N, \"next_cursor\": ...} for paging from stdout")
+	rootCmd.Flags().StringVar(&transcript, "transcript", "", "REPL only: append every command and its output to this file")
+	rootCmd.PersistentFlags().StringVar(&saveResp, "save-response", "", "Also write the raw API response body to this file")
+	rootCmd.PersistentFlags().StringVar(&replay, "replay", "", "Print a response saved with --save-response instead of calling the API")
+	rootCmd.PersistentFlags().BoolVar(&raw, "raw", false, "Print the API response body verbatim (overrides -o; no charts or footers)")
+	rootCmd.PersistentFlags().StringVar(&tmpl, "template", "", "Go template rendered per row for -o template, e.g. '{{.instrument_name}} {{.mark_price}}'")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colors in tables and JSON (also set by the NO_COLOR env var)")
+	rootCmd.PersistentFlags().BoolVar(&humanize, "humanize", false, "Abbreviate large numbers in tables: 12.3K, 4.5M, 1.23B (JSON/CSV keep full precision)")
+	rootCmd.PersistentFlags().StringVar(&locale, "locale", "", "Format table numbers and month names for a locale, e.g. de or fr-FR (default en)")
+	rootCmd.PersistentFlags().StringArrayVar(&filters, "filter", nil, "Keep rows matching column OP value, e.g. 'days_to_expiry<7' (repeatable, ANDed)")
+	rootCmd.PersistentFlags().StringVar(&sortBy, "sort-by", "", "Sort rows client-side, e.g. days_to_expiry or oi:desc,instrument_name")
+	rootCmd.PersistentFlags().StringVar(&aggregateBy, "aggregate-by", "", "Group result rows client-side by this column (see --agg)")
+	rootCmd.PersistentFlags().StringVar(&aggSpec, "agg", "", "Aggregates per group: sum, avg, min, max, count (e.g. sum:amount_usd,count:*)")
 	rootCmd.PersistentFlags().BoolVar(&wide, "wide", false, "Disable column truncation (show all data)")
 	rootCmd.PersistentFlags().IntVar(&widthOverride, "width", 0, "Override terminal width for table formatting")
+	rootCmd.PersistentFlags().BoolVar(&wrap, "wrap", false, "Wrap long text cells onto extra lines instead of truncating them")
+	rootCmd.PersistentFlags().IntVar(&maxWidth, "max-width", 0, "Cap text columns at N characters (truncated, or wrapped with --wrap)")
+	rootCmd.PersistentFlags().BoolVar(&noFooter, "no-footer", false, "Don't print the \"N records\" line under tables")
+	rootCmd.PersistentFlags().BoolVar(&compact, "compact", false, "One space between table columns and no dotted row separators, to fit more columns")
+	rootCmd.PersistentFlags().BoolVar(&noSeparator, "no-separator", false, "Don't print the header rule and the dotted line every 5 table rows")
+
+	versionCmd.Flags().BoolVar(&versionJSON, "json", false, "Print build information as JSON (same as -o json)")
 
 	rootCmd.AddCommand(config.Cmd)
 	rootCmd.AddCommand(futures.Cmd)
@@ -138,14 +387,29 @@ Twitter: https://twitter.com/laevitas1` + "\033[0m" + `
 	rootCmd.AddCommand(options.Cmd)
 	rootCmd.AddCommand(predictions.Cmd)
 	rootCmd.AddCommand(watchCmd)
+	rootCmd.AddCommand(diffCmd)
+	rootCmd.AddCommand(searchCmd)
+	rootCmd.AddCommand(resolveCmd)
+	rootCmd.AddCommand(healthCmd)
+	rootCmd.AddCommand(catalogCmd)
+	rootCmd.AddCommand(exchangesCmd)
+	rootCmd.AddCommand(saveCmd)
+	rootCmd.AddCommand(runCmd)
+	rootCmd.AddCommand(savesCmd)
+	rootCmd.AddCommand(unsaveCmd)
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(update.Cmd)
 }
 
 func Execute() error {
+	rootCmd.SetArgs(expandAlias(os.Args[1:]))
 	err := rootCmd.Execute()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "✗ %s\n", err)
+	cmdutil.LogCommand(err)
+	cmdutil.Log.Close()
+	if cmdutil.IsCancelled(err) {
+		output.Warnf("Cancelled")
+	} else if err != nil {
+		output.PrintError(output.Resolve(cmdutil.OutputFormat), err)
 	}
 	return err
 }
@@ -1,21 +1,137 @@
 package cmd
 
 import (
+	"bufio"
 	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
 	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"golang.org/x/term"
 
 	"github.com/laevitas/cli/internal/api"
 	"github.com/laevitas/cli/internal/config"
 	"github.com/laevitas/cli/internal/output"
 )
 
-// handleSaveCommand saves a query: save <name> <command...>
-func handleSaveCommand(args []string) {
+// ─── Cobra commands (non-interactive) ───────────────────────────────────────
+//
+// In the REPL these names are intercepted before cobra and dispatched to the
+// handle*Command functions directly; the commands below expose the same
+// handlers to scripts and cron jobs.
+
+var saveCmd = &cobra.Command{
+	Use:   "save <name> <command...>",
+	Short: "Save a command as a named query",
+	Example: `  laevitas save btc-funding perps carry BTC-PERPETUAL -r 1d -n 30
+  laevitas save funding perps carry {instrument} -r {resolution=1d}
+  laevitas save btc-flow --tag options --tag btc options flow --currency BTC
+  laevitas save morning "perps carry BTC-PERPETUAL -n 5; options flow --currency BTC"`,
+	// Everything after the name is the saved command, flags included
+	DisableFlagParsing: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 2 {
+			return cmd.Help()
+		}
+		return handleSaveCommand(args)
+	},
+}
+
+var runCmd = &cobra.Command{
+	Use:   "run <name> [args...]",
+	Short: "Run a saved query",
+	Example: `  laevitas run btc-funding
+  laevitas run funding BTC-PERPETUAL
+  laevitas run funding instrument=ETH-PERPETUAL resolution=1h -o json`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		commands, err := expandSavedQuery(args[0], args[1:])
+		if err != nil {
+			return err
+		}
+
+		markSavedQueryRun(args[0])
+
+		// Execute through the root command; global flags given to "run"
+		// (e.g. -o json) stay in effect for the saved commands, local
+		// flags don't carry over from one command to the next.
+		for i, command := range commands {
+			printRunSeparator(os.Stderr, i, len(commands), command)
+			cmdArgs := splitArgs(command)
+			if c, _, err := rootCmd.Find(cmdArgs); err == nil {
+				resetChangedFlags(c.NonInheritedFlags())
+			}
+			rootCmd.SetArgs(cmdArgs)
+			if err := rootCmd.Execute(); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+var savesCmd = &cobra.Command{
+	Use:   "saves",
+	Short: "List saved queries",
+	Example: `  laevitas saves
+  laevitas saves export team-queries.json
+  laevitas saves import team-queries.json --overwrite`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return handleSavesCommand()
+	},
+}
+
+var unsaveCmd = &cobra.Command{
+	Use:   "unsave <name>",
+	Short: "Remove a saved query",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return handleUnsaveCommand(args)
+	},
+}
+
+// resetChangedFlags restores every changed flag in fs to its default, so a
+// command run twice in one process starts clean. Slice flags are replaced
+// rather than Set, which would append to the previous values.
+func resetChangedFlags(fs *pflag.FlagSet) {
+	fs.VisitAll(func(f *pflag.Flag) {
+		if !f.Changed {
+			return
+		}
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			var def []string
+			if d := strings.Trim(f.DefValue, "[]"); d != "" {
+				def = strings.Split(d, ",")
+			}
+			sv.Replace(def)
+		} else {
+			f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	})
+}
+
+// ─── Handlers (shared by REPL and cobra) ────────────────────────────────────
+
+// handleSaveCommand saves a query: save <name> [--tag <tag>]... <command...>
+func handleSaveCommand(args []string) error {
+	var tags []string
+	if len(args) > 0 {
+		tags, args = parseSaveTags(args[0], args[1:])
+	}
 	if len(args) < 2 {
-		fmt.Println("  Usage: save <name> <command...>")
+		fmt.Println("  Usage: save <name> [--tag <tag>]... <command...>")
 		fmt.Println("  Example: save btc-funding perps funding BTC-PERPETUAL -r 1d -n 30")
-		fmt.Println("  Variables: save funding perps funding {instrument} -r 1d -n 30")
-		return
+		fmt.Println("  Variables: save funding perps funding {instrument} -r {resolution=1d} -n 30")
+		fmt.Println("  Sequence: save morning perps carry BTC-PERPETUAL ; options flow --currency BTC")
+		fmt.Println("  Tags: save btc-flow --tag options options flow --currency BTC")
+		return nil
 	}
 
 	name := args[0]
@@ -23,16 +139,17 @@ func handleSaveCommand(args []string) {
 
 	sq, err := config.LoadSaved()
 	if err != nil {
-		output.Errorf("Loading saved queries: %s", err)
-		return
+		return fmt.Errorf("loading saved queries: %w", err)
 	}
 
 	existing := sq.Get(name)
 	sq.Add(name, command)
+	if len(tags) > 0 {
+		sq.SetTags(name, tags)
+	}
 
 	if err := config.SaveQueries(sq); err != nil {
-		output.Errorf("Saving query: %s", err)
-		return
+		return fmt.Errorf("saving query: %w", err)
 	}
 
 	if existing != nil {
@@ -44,73 +161,158 @@ func handleSaveCommand(args []string) {
 	dim := "\033[2m"
 	reset := "\033[0m"
 	fmt.Printf("  %s→ %s%s\n", dim, command, reset)
+	if len(tags) > 0 {
+		fmt.Printf("  %s  tags: %s%s\n", dim, strings.Join(tags, ", "), reset)
+	}
+
+	if vars := config.Placeholders(command); len(vars) > 0 {
+		usage := make([]string, len(vars))
+		for i, v := range vars {
+			switch {
+			case v.Name == "":
+				usage[i] = "<arg>"
+			case v.HasDefault:
+				usage[i] = fmt.Sprintf("[%s=%s]", v.Name, v.Default)
+			default:
+				usage[i] = "<" + v.Name + ">"
+			}
+		}
+		fmt.Printf("  %s  %d variable(s) — run %s %s%s\n",
+			dim, len(vars), name, strings.Join(usage, " "), reset)
+	}
+	return nil
+}
+
+// parseSaveTags pulls leading --tag <t> / --tag=<t> options (comma-separated
+// values allowed) off the arguments that follow the query name, and returns
+// the tags and the arguments with the name put back in front.
+func parseSaveTags(name string, rest []string) ([]string, []string) {
+	var tags []string
+	add := func(v string) {
+		for _, t := range strings.Split(v, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				tags = append(tags, t)
+			}
+		}
+	}
+	for len(rest) > 0 {
+		switch {
+		case rest[0] == "--tag" && len(rest) > 1:
+			add(rest[1])
+			rest = rest[2:]
+		case strings.HasPrefix(rest[0], "--tag="):
+			add(strings.TrimPrefix(rest[0], "--tag="))
+			rest = rest[1:]
+		default:
+			return tags, append([]string{name}, rest...)
+		}
+	}
+	return tags, []string{name}
+}
 
-	placeholders := config.CountPlaceholders(command)
-	if placeholders > 0 {
-		fmt.Printf("  %s  %d variable(s) — run %s %s <args...>%s\n",
-			dim, placeholders, name, name, reset)
+// markSavedQueryRun records the last-run time of a saved query.
+func markSavedQueryRun(name string) {
+	sq, err := config.LoadSaved()
+	if err != nil {
+		return
 	}
+	sq.MarkRun(name)
+	config.SaveQueries(sq)
 }
 
 // handleRunCommand executes a saved query: run <name> [args...]
-func handleRunCommand(args []string, client *api.Client) {
+func handleRunCommand(args []string, client *api.Client) error {
 	if len(args) < 1 {
 		fmt.Println("  Usage: run <name> [args...]")
 		fmt.Println("  Example: run btc-funding")
 		fmt.Println("  With vars: run funding BTC-PERPETUAL")
-		return
+		fmt.Println("  By name:   run funding instrument=BTC-PERPETUAL resolution=1h")
+		return nil
 	}
 
-	name := args[0]
-	runArgs := args[1:]
+	commands, err := expandSavedQuery(args[0], args[1:])
+	if err != nil {
+		return err
+	}
+
+	markSavedQueryRun(args[0])
+
+	// Execute the expanded commands through the REPL
+	for i, command := range commands {
+		printRunSeparator(os.Stdout, i, len(commands), command)
+		fmt.Println()
+		executeREPLCommand(command, client)
+	}
+	return nil
+}
 
+// printRunSeparator prints the "→ command" line before each command of a
+// saved query, numbered when the query holds more than one command.
+func printRunSeparator(w io.Writer, i, n int, command string) {
+	dim := "\033[2m"
+	reset := "\033[0m"
+	if n == 1 {
+		fmt.Fprintf(w, "  %s→ %s%s\n", dim, command, reset)
+		return
+	}
+	if i > 0 {
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintf(w, "  %s── %d/%d → %s%s\n", dim, i+1, n, command, reset)
+}
+
+// expandSavedQuery looks up a saved query by name, expands its {variable}
+// placeholders (positional or name=value) with args, and splits it into
+// its sub-commands.
+func expandSavedQuery(name string, args []string) ([]string, error) {
 	sq, err := config.LoadSaved()
 	if err != nil {
-		output.Errorf("Loading saved queries: %s", err)
-		return
+		return nil, fmt.Errorf("loading saved queries: %w", err)
 	}
 
 	query := sq.Get(name)
 	if query == nil {
-		output.Errorf("No saved query named %q. Use 'saves' to list all.", name)
-		return
+		return nil, fmt.Errorf("no saved query named %q. Use 'saves' to list all", name)
 	}
 
 	command := query.Command
-
-	// Expand {variable} placeholders
-	placeholders := config.CountPlaceholders(command)
-	if placeholders > 0 {
-		if len(runArgs) < placeholders {
-			output.Errorf("Query %q expects %d argument(s) but got %d.", name, placeholders, len(runArgs))
-			dim := "\033[2m"
-			reset := "\033[0m"
-			fmt.Printf("  %s→ %s%s\n", dim, command, reset)
-			return
+	if config.CountPlaceholders(command) > 0 {
+		expanded, err := config.Expand(command, args)
+		if err != nil {
+			return nil, fmt.Errorf("query %q: %w\n  → %s", name, err, command)
 		}
-		command = config.Expand(command, runArgs)
+		command = expanded
 	}
 
-	dim := "\033[2m"
-	reset := "\033[0m"
-	fmt.Printf("  %s→ %s%s\n\n", dim, command, reset)
+	commands := config.SplitCommands(command)
+	if len(commands) == 0 {
+		return nil, fmt.Errorf("query %q is empty", name)
+	}
 
-	// Execute the expanded command through the REPL
-	executeREPLCommand(command, client)
+	// A saved query that runs another saved query could loop forever, and
+	// one from an imported file must not run shell commands through a pipe
+	for _, c := range commands {
+		if fields := strings.Fields(c); len(fields) > 0 && strings.EqualFold(fields[0], "run") {
+			return nil, fmt.Errorf("query %q cannot call run", name)
+		}
+		if _, shell, ok := splitPipe(c); ok {
+			return nil, fmt.Errorf("query %q pipes into a shell command (%s); pipes only run when typed at the prompt", name, shell)
+		}
+	}
+	return commands, nil
 }
 
 // handleSavesCommand lists all saved queries: saves
-func handleSavesCommand() {
+func handleSavesCommand() error {
 	sq, err := config.LoadSaved()
 	if err != nil {
-		output.Errorf("Loading saved queries: %s", err)
-		return
+		return fmt.Errorf("loading saved queries: %w", err)
 	}
 
 	if len(sq.Queries) == 0 {
 		fmt.Println("  No saved queries yet.")
 		fmt.Println("  Use: save <name> <command...>")
-		return
+		return nil
 	}
 
 	bold := "\033[1m"
@@ -119,7 +321,7 @@ func handleSavesCommand() {
 	yellow := "\033[33m"
 	reset := "\033[0m"
 
-	fmt.Printf("\n  %s%sSaved Queries%s (%d)\n\n", bold, cyan, reset, len(sq.Queries))
+	fmt.Printf("\n  %s%sSaved Queries%s (%d)\n", bold, cyan, reset, len(sq.Queries))
 
 	// Find max name width for alignment
 	maxWidth := 0
@@ -129,43 +331,177 @@ func handleSavesCommand() {
 		}
 	}
 
+	// Group by tag; a query with several tags is listed under each.
+	// Untagged queries come last.
+	groups := make(map[string][]config.SavedQuery)
+	var tags []string
 	for _, q := range sq.Queries {
-		placeholders := config.CountPlaceholders(q.Command)
-		varHint := ""
-		if placeholders > 0 {
-			varHint = fmt.Sprintf(" %s(%d var)%s", yellow, placeholders, reset)
+		qTags := q.Tags
+		if len(qTags) == 0 {
+			qTags = []string{""}
+		}
+		for _, t := range qTags {
+			if _, ok := groups[t]; !ok && t != "" {
+				tags = append(tags, t)
+			}
+			groups[t] = append(groups[t], q)
+		}
+	}
+	sort.Strings(tags)
+	if len(groups[""]) > 0 {
+		tags = append(tags, "")
+	}
+
+	for _, tag := range tags {
+		fmt.Println()
+		if len(tags) > 1 || tag != "" {
+			label := tag
+			if label == "" {
+				label = "untagged"
+			}
+			fmt.Printf("  %s%s#%s%s\n", bold, yellow, label, reset)
+		}
+		for _, q := range groups[tag] {
+			placeholders := config.CountPlaceholders(q.Command)
+			varHint := ""
+			if placeholders > 0 {
+				varHint = fmt.Sprintf(" %s(%d var)%s", yellow, placeholders, reset)
+			}
+			lastRun := "never run"
+			if !q.LastRun.IsZero() {
+				ago := output.HumanDuration(time.Since(q.LastRun))
+				if ago != "yesterday" {
+					ago += " ago"
+				}
+				lastRun = "ran " + ago
+			}
+			fmt.Printf("  %s%-*s%s  %s→ %s%s%s  %s%s%s\n",
+				bold, maxWidth, q.Name, reset,
+				dim, reset, q.Command, varHint,
+				dim, lastRun, reset)
 		}
-		fmt.Printf("  %s%-*s%s  %s→ %s%s%s\n",
-			bold, maxWidth, q.Name, reset,
-			dim, reset, q.Command, varHint)
 	}
 	fmt.Println()
+	return nil
 }
 
 // handleUnsaveCommand removes a saved query: unsave <name>
-func handleUnsaveCommand(args []string) {
+func handleUnsaveCommand(args []string) error {
 	if len(args) < 1 {
 		fmt.Println("  Usage: unsave <name>")
-		return
+		return nil
 	}
 
 	name := args[0]
 
 	sq, err := config.LoadSaved()
 	if err != nil {
-		output.Errorf("Loading saved queries: %s", err)
-		return
+		return fmt.Errorf("loading saved queries: %w", err)
 	}
 
 	if !sq.Remove(name) {
-		output.Errorf("No saved query named %q.", name)
-		return
+		return fmt.Errorf("no saved query named %q", name)
 	}
 
 	if err := config.SaveQueries(sq); err != nil {
-		output.Errorf("Saving: %s", err)
-		return
+		return fmt.Errorf("saving: %w", err)
 	}
 
 	output.Successf("Removed saved query %q", name)
+	return nil
+}
+
+// ─── Export / import ────────────────────────────────────────────────────────
+
+var savesExportCmd = &cobra.Command{
+	Use:   "export <file>",
+	Short: "Write all saved queries to a JSON file for sharing",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sq, err := config.LoadSaved()
+		if err != nil {
+			return fmt.Errorf("loading saved queries: %w", err)
+		}
+		if err := config.WriteSavedFile(args[0], sq); err != nil {
+			return fmt.Errorf("writing %s: %w", args[0], err)
+		}
+		output.Successf("Exported %d saved queries to %s", len(sq.Queries), args[0])
+		return nil
+	},
+}
+
+var importOverwrite bool
+
+var savesImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Merge saved queries from a JSON file",
+	Long: `Merge saved queries from a file written by "saves export".
+
+When a name already exists with a different command, you are asked whether
+to replace it (in a terminal) or it is skipped. Use --overwrite to replace
+all collisions without asking. A name that exists with the same command is
+left as is, gaining any tags the file adds.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		incoming, err := config.ReadSavedFile(args[0])
+		if err != nil {
+			return err
+		}
+		sq, err := config.LoadSaved()
+		if err != nil {
+			return fmt.Errorf("loading saved queries: %w", err)
+		}
+
+		reader := bufio.NewReader(os.Stdin)
+		added, replaced, unchanged, skipped := 0, 0, 0, 0
+		for _, q := range incoming.Queries {
+			existing := sq.Get(q.Name)
+			switch {
+			case existing == nil:
+				added++
+			case existing.Command == q.Command:
+				// Identical command — keep it, adding any new tags
+				for _, t := range q.Tags {
+					if !slices.Contains(existing.Tags, t) {
+						existing.Tags = append(existing.Tags, t)
+					}
+				}
+				unchanged++
+				continue
+			case importOverwrite:
+				replaced++
+			case output.IsTTY() && term.IsTerminal(int(os.Stdin.Fd())):
+				// The answer is read from stdin, so both ends must be a terminal
+				fmt.Printf("  %s exists:\n    current:  %s\n    incoming: %s\n  Replace? [y/N] ", q.Name, existing.Command, q.Command)
+				answer, _ := reader.ReadString('\n')
+				if !strings.HasPrefix(strings.ToLower(strings.TrimSpace(answer)), "y") {
+					skipped++
+					continue
+				}
+				replaced++
+			default:
+				output.Warnf("Skipping %s: already exists (use --overwrite to replace)", q.Name)
+				skipped++
+				continue
+			}
+
+			sq.Add(q.Name, q.Command)
+			if len(q.Tags) > 0 {
+				sq.SetTags(q.Name, q.Tags)
+			}
+		}
+
+		if err := config.SaveQueries(sq); err != nil {
+			return fmt.Errorf("saving: %w", err)
+		}
+		output.Successf("Imported from %s: %d added, %d replaced, %d unchanged, %d skipped", args[0], added, replaced, unchanged, skipped)
+		return nil
+	},
+}
+
+func init() {
+	savesImportCmd.Flags().BoolVar(&importOverwrite, "overwrite", false, "Replace saved queries with the same name without asking")
+
+	savesCmd.AddCommand(savesExportCmd)
+	savesCmd.AddCommand(savesImportCmd)
 }
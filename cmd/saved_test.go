@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/laevitas/cli/internal/config"
+)
+
+// TestRunSequenceResetsLocalFlags runs a saved two-command sequence through
+// the same subcommand; -n from the first command must not reach the second.
+func TestRunSequenceResetsLocalFlags(t *testing.T) {
+	var mu sync.Mutex
+	var queries []url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		queries = append(queries, r.URL.Query())
+		mu.Unlock()
+		w.Write([]byte("[]"))
+	}))
+	t.Cleanup(srv.Close)
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("LAEVITAS_BASE_URL", srv.URL)
+	t.Setenv("LAEVITAS_API_KEY", "test-key")
+
+	sq := &config.SavedQueries{}
+	sq.Add("seqtest", "perps carry BTC-PERPETUAL -n 5 ; perps carry ETH-PERPETUAL")
+	if err := config.SaveQueries(sq); err != nil {
+		t.Fatal(err)
+	}
+
+	rootCmd.SetArgs([]string{"run", "seqtest", "-o", "json", "--quiet"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("run seqtest: %v", err)
+	}
+	t.Cleanup(resetFlags)
+
+	if len(queries) != 2 {
+		t.Fatalf("got %d requests, want 2: %v", len(queries), queries)
+	}
+	if got := queries[0].Get("limit"); got != "5" {
+		t.Errorf("first command: limit = %q, want 5", got)
+	}
+	if got := queries[1].Get("limit"); got == "5" {
+		t.Errorf("second command inherited -n 5: %s", queries[1].Encode())
+	}
+}
+
+// TestRunRejectsPipe runs a saved query (as if imported) that pipes into a
+// shell command; it must fail without running the shell.
+func TestRunRejectsPipe(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	marker := filepath.Join(dir, "pwned")
+
+	sq := &config.SavedQueries{}
+	sq.Add("piped", "perps carry BTC-PERPETUAL | touch "+marker)
+	if err := config.SaveQueries(sq); err != nil {
+		t.Fatal(err)
+	}
+
+	err := handleRunCommand([]string{"piped"}, nil)
+	if err == nil || !strings.Contains(err.Error(), "pipe") {
+		t.Errorf("got %v, want a pipe error", err)
+	}
+	if _, statErr := os.Stat(marker); statErr == nil {
+		t.Error("the shell command ran")
+	}
+}
+
+// TestRunTemplateQuery runs a saved -o template command; its {{...}}
+// actions are not variables, so it needs no arguments.
+func TestRunTemplateQuery(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	const command = "perps carry BTC-PERPETUAL -o template --template '{{.instrument_name}} {{.value}}'"
+
+	if err := handleSaveCommand([]string{"tq", command}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := expandSavedQuery("tq", nil)
+	if err != nil {
+		t.Fatalf("run tq: %v", err)
+	}
+	if len(got) != 1 || got[0] != command {
+		t.Errorf("got %q, want the command unchanged", got)
+	}
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/laevitas/cli/internal/cmdutil"
+	"github.com/laevitas/cli/internal/completer"
+)
+
+var searchCmd = &cobra.Command{
+	Use:   "search <keywords...>",
+	Short: "Search instrument names across all catalogs",
+	Long: `Search futures, perpetuals, options, and prediction market catalogs
+for instruments whose name contains every keyword (case-insensitive).`,
+	Args: cobra.MinimumNArgs(1),
+	Example: `  laevitas search btc mar
+  laevitas search eth perpetual -o json
+  laevitas search sol -o csv`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, _ := cmdutil.MustClient()
+		if client == nil {
+			return fmt.Errorf("no API client available")
+		}
+
+		results := completer.New(client, nil).Search(args)
+		if results == nil {
+			results = []completer.SearchResult{}
+		}
+
+		data, err := json.Marshal(results)
+		if err != nil {
+			return err
+		}
+		return cmdutil.MustPrinter().Print(data)
+	},
+}
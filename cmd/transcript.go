@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/laevitas/cli/internal/output"
+)
+
+// ─── REPL transcript ────────────────────────────────────────────────────────
+//
+// With --transcript <file> or "record <file>", each REPL line is appended to
+// the file with a timestamp, followed by what it printed (stdout and stderr,
+// colors stripped).
+
+// replTranscript is the open transcript file, nil when not recording.
+var replTranscript *os.File
+
+// ansiRe matches ANSI escape sequences, kept out of the transcript.
+var ansiRe = regexp.MustCompile(`\x1b\[[0-9;?]*[a-zA-Z]`)
+
+// startTranscript starts appending the session to path.
+func startTranscript(path string) error {
+	stopTranscript()
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(f, "# laevitas transcript started %s\n", time.Now().Format(time.RFC3339))
+	replTranscript = f
+	return nil
+}
+
+// stopTranscript closes the transcript, if one is open.
+func stopTranscript() {
+	if replTranscript == nil {
+		return
+	}
+	fmt.Fprintf(replTranscript, "\n# transcript stopped %s\n", time.Now().Format(time.RFC3339))
+	replTranscript.Close()
+	replTranscript = nil
+}
+
+// recordTranscript writes an entered line to the transcript.
+func recordTranscript(line string) {
+	if replTranscript != nil {
+		fmt.Fprintf(replTranscript, "\n[%s] > %s\n", time.Now().Format("2006-01-02 15:04:05"), line)
+	}
+}
+
+// captureTranscript runs fn, copying what it writes to stdout and stderr
+// into the transcript as well as the terminal. output.Terminal keeps
+// pointing at the real stdout, so tables and colors are unchanged.
+func captureTranscript(fn func()) {
+	f := replTranscript
+	if f == nil {
+		fn()
+		return
+	}
+	outR, outW, err := os.Pipe()
+	if err != nil {
+		fn()
+		return
+	}
+	errR, errW, err := os.Pipe()
+	if err != nil {
+		outR.Close()
+		outW.Close()
+		fn()
+		return
+	}
+
+	var (
+		mu  sync.Mutex
+		buf bytes.Buffer
+		wg  sync.WaitGroup
+	)
+	tee := func(r *os.File, w io.Writer) {
+		defer wg.Done()
+		chunk := make([]byte, 4096)
+		for {
+			n, err := r.Read(chunk)
+			if n > 0 {
+				w.Write(chunk[:n])
+				mu.Lock()
+				buf.Write(chunk[:n])
+				mu.Unlock()
+			}
+			if err != nil {
+				return
+			}
+		}
+	}
+	stdout, stderr := os.Stdout, os.Stderr
+	wg.Add(2)
+	go tee(outR, stdout)
+	go tee(errR, stderr)
+
+	os.Stdout, os.Stderr = outW, errW
+	output.Terminal = stdout
+	defer func() {
+		os.Stdout, os.Stderr = stdout, stderr
+		output.Terminal = stdout
+		outW.Close()
+		errW.Close()
+		wg.Wait()
+		outR.Close()
+		errR.Close()
+		// "record off" may have closed the file in the meantime
+		if replTranscript == f {
+			f.Write(ansiRe.ReplaceAll(buf.Bytes(), nil))
+		}
+	}()
+	fn()
+}
+
+// handleRecordCommand starts or stops the transcript: record <file> | off.
+func handleRecordCommand(args []string) error {
+	switch {
+	case len(args) == 0:
+		if replTranscript != nil {
+			fmt.Printf("  Recording to %s (record off to stop)\n", replTranscript.Name())
+		} else {
+			fmt.Println("  Not recording. Usage: record <file> | record off")
+		}
+		return nil
+	case args[0] == "off":
+		if replTranscript == nil {
+			return fmt.Errorf("not recording")
+		}
+		name := replTranscript.Name()
+		stopTranscript()
+		output.Successf("Stopped recording to %s", name)
+		return nil
+	}
+	if err := startTranscript(args[0]); err != nil {
+		return fmt.Errorf("opening transcript: %w", err)
+	}
+	output.Successf("Recording session to %s", args[0])
+	return nil
+}
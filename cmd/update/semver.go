@@ -0,0 +1,105 @@
+package update
+
+import (
+	"strconv"
+	"strings"
+)
+
+// semver is a parsed MAJOR.MINOR.PATCH[-PRERELEASE][+BUILD] version.
+// Build metadata is dropped since it doesn't affect precedence.
+type semver struct {
+	major, minor, patch int
+	pre                 []string
+}
+
+// parseSemver parses a version with or without a leading "v". Missing minor
+// or patch components default to zero ("1.2" == "1.2.0").
+func parseSemver(s string) (semver, bool) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		s = s[:i]
+	}
+
+	var v semver
+	core := s
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		core = s[:i]
+		if s[i+1:] == "" {
+			return semver{}, false
+		}
+		v.pre = strings.Split(s[i+1:], ".")
+	}
+
+	parts := strings.Split(core, ".")
+	if len(parts) == 0 || len(parts) > 3 {
+		return semver{}, false
+	}
+	nums := [3]int{}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return semver{}, false
+		}
+		nums[i] = n
+	}
+	v.major, v.minor, v.patch = nums[0], nums[1], nums[2]
+	return v, true
+}
+
+// compareSemver returns -1, 0 or 1 as a is lower than, equal to or higher
+// than b, following semver precedence: numeric components compare as
+// numbers (1.10.0 > 1.9.0) and a pre-release sorts before its release
+// (1.2.0-rc1 < 1.2.0).
+func compareSemver(a, b semver) int {
+	if c := compareInt(a.major, b.major); c != 0 {
+		return c
+	}
+	if c := compareInt(a.minor, b.minor); c != 0 {
+		return c
+	}
+	if c := compareInt(a.patch, b.patch); c != 0 {
+		return c
+	}
+
+	switch {
+	case len(a.pre) == 0 && len(b.pre) == 0:
+		return 0
+	case len(a.pre) == 0:
+		return 1
+	case len(b.pre) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(a.pre) && i < len(b.pre); i++ {
+		if c := comparePrerelease(a.pre[i], b.pre[i]); c != 0 {
+			return c
+		}
+	}
+	return compareInt(len(a.pre), len(b.pre))
+}
+
+// comparePrerelease compares one dot-separated pre-release identifier.
+// Numeric identifiers compare numerically and sort before alphanumeric ones.
+func comparePrerelease(a, b string) int {
+	na, errA := strconv.Atoi(a)
+	nb, errB := strconv.Atoi(b)
+	switch {
+	case errA == nil && errB == nil:
+		return compareInt(na, nb)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,106 @@
+package update
+
+import "testing"
+
+func TestParseSemver(t *testing.T) {
+	tests := []struct {
+		in   string
+		want semver
+		ok   bool
+	}{
+		{"v1.2.3", semver{major: 1, minor: 2, patch: 3}, true},
+		{"1.2.3", semver{major: 1, minor: 2, patch: 3}, true},
+		{"v1.2", semver{major: 1, minor: 2}, true},
+		{"v1.2.0-rc.1", semver{major: 1, minor: 2, pre: []string{"rc", "1"}}, true},
+		{"v1.2.0+build.5", semver{major: 1, minor: 2}, true},
+		{"v1.2.0-", semver{}, false},
+		{"v1.2.3.4", semver{}, false},
+		{"v1.x.0", semver{}, false},
+		{"dev", semver{}, false},
+		{"abc1234", semver{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, ok := parseSemver(tt.in)
+			if ok != tt.ok {
+				t.Fatalf("parseSemver(%q) ok = %v, want %v", tt.in, ok, tt.ok)
+			}
+			if ok && compareSemver(got, tt.want) != 0 {
+				t.Errorf("parseSemver(%q) = %+v, want %+v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareSemver(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"v1.10.0", "v1.9.0", 1},
+		{"v2.0.0", "v1.99.99", 1},
+		{"v1.2.10", "v1.2.9", 1},
+		{"v1.2.0-rc.1", "v1.2.0", -1},
+		{"v1.2.0-rc.2", "v1.2.0-rc.10", -1},
+		{"v1.2.0-rc.10", "v1.2.0-rc.2", 1},
+		{"v1.2.0-alpha", "v1.2.0-beta", -1},
+		{"v1.2.0-rc", "v1.2.0-rc.1", -1},
+		{"v1.2.0-1", "v1.2.0-rc", -1},
+		{"v1.2", "1.2.0", 0},
+		{"v1.2.0+build.1", "v1.2.0+build.2", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"_vs_"+tt.b, func(t *testing.T) {
+			a, okA := parseSemver(tt.a)
+			b, okB := parseSemver(tt.b)
+			if !okA || !okB {
+				t.Fatalf("parseSemver failed for %q or %q", tt.a, tt.b)
+			}
+			if got := compareSemver(a, b); got != tt.want {
+				t.Errorf("compareSemver(%s, %s) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsDevBuild(t *testing.T) {
+	tests := []struct {
+		in   string
+		want bool
+	}{
+		{"v1.2.0", false},
+		{"1.2.0", false},
+		{"v1.2.0-rc.1", false},
+		{"v1.2.0-3-gabc123", true},
+		{"v1.2.0-3-gabc123-dirty", true},
+		{"v1.2.0-dirty", true},
+		{"dev", true},
+		{"abc1234", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := isDevBuild(tt.in); got != tt.want {
+				t.Errorf("isDevBuild(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGitDescribeSuffix(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"v1.2.0-3-gabc123-dirty", "-3-gabc123-dirty"},
+		{"v1.2.0-3-gabc123", "-3-gabc123"},
+		{"v1.2.0-dirty", "-dirty"},
+		{"v1.2.0", ""},
+		{"v1.2.0-rc.1", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := gitDescribeSuffix.FindString(tt.in); got != tt.want {
+				t.Errorf("gitDescribeSuffix in %q = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
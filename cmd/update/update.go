@@ -1,12 +1,17 @@
 package update
 
 import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"strings"
 
@@ -17,78 +22,202 @@ import (
 )
 
 const (
-	repo         = "laevitas/cli"
-	binaryName   = "laevitas"
-	githubAPIURL = "https://api.github.com/repos/" + repo + "/releases/latest"
+	repo          = "laevitas/cli"
+	binaryName    = "laevitas"
+	githubAPIURL  = "https://api.github.com/repos/" + repo + "/releases/latest"
+	githubTagURL  = "https://api.github.com/repos/" + repo + "/releases/tags/"
+	githubListURL = "https://api.github.com/repos/" + repo + "/releases"
+
+	// checksumsAsset is the sha256sum-format manifest published with each release.
+	checksumsAsset = "checksums.txt"
 )
 
+// releaseDownloadURL is the base URL for release assets. A variable so it
+// can be pointed at a fixture server.
+var releaseDownloadURL = "https://github.com/" + repo + "/releases/download"
+
 // githubRelease is the subset of fields we need from the GitHub API.
 type githubRelease struct {
-	TagName string `json:"tag_name"`
-	HTMLURL string `json:"html_url"`
+	TagName    string `json:"tag_name"`
+	HTMLURL    string `json:"html_url"`
+	Draft      bool   `json:"draft"`
+	Prerelease bool   `json:"prerelease"`
 }
 
+// Release channels for --channel.
+const (
+	channelStable = "stable"
+	channelBeta   = "beta"
+)
+
 // Cmd is the top-level "update" command.
 var Cmd = &cobra.Command{
 	Use:     "update",
 	Aliases: []string{"upgrade", "self-update"},
 	Short:   "Update the CLI to the latest version",
-	Long:    "Check for and install the latest release from GitHub.",
-	RunE:    runUpdate,
+	Long: `Check for and install the latest release from GitHub.
+
+Use --version to pin or roll forward/back to a specific release. The binary
+being replaced is kept next to the executable with an .old suffix, and
+--rollback swaps it back in.
+
+--channel beta also considers prereleases and installs whichever release
+has the highest version.`,
+	Example: `  laevitas update
+  laevitas update --check
+  laevitas update --channel beta
+  laevitas update --version v0.3.1
+  laevitas update --rollback`,
+	RunE: runUpdate,
 }
 
-var checkOnly bool
+var (
+	checkOnly     bool
+	targetVersion string
+	rollback      bool
+	channel       string
+)
 
 func init() {
 	Cmd.Flags().BoolVar(&checkOnly, "check", false, "Only check for updates, don't install")
+	Cmd.Flags().StringVar(&targetVersion, "version", "", "Install a specific release tag (e.g. v0.3.1) instead of the latest")
+	Cmd.Flags().BoolVar(&rollback, "rollback", false, "Reinstall the binary that the last update replaced")
+	Cmd.Flags().StringVar(&channel, "channel", channelStable, "Release channel: stable, or beta to include prereleases")
 }
 
 func runUpdate(cmd *cobra.Command, args []string) error {
+	if channel != channelStable && channel != channelBeta {
+		return fmt.Errorf("invalid --channel: %s (use: %s, %s)", channel, channelStable, channelBeta)
+	}
+	if rollback {
+		if checkOnly || targetVersion != "" {
+			return fmt.Errorf("--rollback cannot be combined with --check or --version")
+		}
+		return runRollback()
+	}
+	if targetVersion != "" {
+		if checkOnly {
+			return fmt.Errorf("--check cannot be combined with --version")
+		}
+		return runInstallVersion(targetVersion)
+	}
+
 	current := version.Version
 
 	fmt.Printf("Current version: %s\n", current)
 	fmt.Print("Checking for updates... ")
 
-	latest, err := fetchLatestVersion()
+	var latest *githubRelease
+	var err error
+	if channel == channelBeta {
+		latest, err = fetchNewestRelease()
+	} else {
+		latest, err = fetchLatestVersion()
+	}
 	if err != nil {
 		fmt.Println("✗")
 		return fmt.Errorf("checking for updates: %w", err)
 	}
 
-	fmt.Printf("%s\n", latest.TagName)
+	if latest.Prerelease {
+		fmt.Printf("%s (prerelease)\n", latest.TagName)
+	} else {
+		fmt.Printf("%s\n", latest.TagName)
+	}
 
-	latestClean := strings.TrimPrefix(latest.TagName, "v")
-	currentClean := strings.TrimPrefix(current, "v")
+	// Dev builds have no release to compare against, so never auto-install
+	// over them: a "newer" release may well be older than the checkout.
+	if isDevBuild(current) {
+		output.Warnf("Running a dev build (%s) — cannot compare versions.", current)
+		fmt.Printf("Latest release is %s. Run `laevitas update --version %s` to install it.\n", latest.TagName, latest.TagName)
+		return nil
+	}
 
-	if currentClean == latestClean || current == "dev" && !checkOnly {
-		if current == "dev" {
-			output.Warnf("Running dev build — cannot compare versions. Use --check or reinstall.")
-			return nil
-		}
+	latestVer, ok := parseSemver(latest.TagName)
+	if !ok {
+		return fmt.Errorf("unrecognised release tag %q", latest.TagName)
+	}
+	currentVer, _ := parseSemver(current)
+
+	// Only offer strictly newer releases — never a downgrade
+	if compareSemver(latestVer, currentVer) <= 0 {
 		output.Successf("Already up to date.")
 		return nil
 	}
 
 	if checkOnly {
-		if currentClean != latestClean {
-			fmt.Printf("\nUpdate available: %s → %s\n", current, latest.TagName)
-			fmt.Printf("Run `laevitas update` to install.\n")
-		} else {
-			output.Successf("Already up to date.")
-		}
+		fmt.Printf("\nUpdate available: %s → %s\n", current, latest.TagName)
+		fmt.Printf("Run `laevitas update` to install.\n")
 		return nil
 	}
 
 	fmt.Printf("\nUpdating %s → %s\n", current, latest.TagName)
 
+	return installRelease(latest)
+}
+
+// gitDescribeSuffix matches what `git describe --dirty` appends to a tag
+// for commits after it ("-3-gabc1234") or uncommitted changes ("-dirty").
+var gitDescribeSuffix = regexp.MustCompile(`(-\d+-g[0-9a-f]+)?(-dirty)?$`)
+
+// isDevBuild reports whether v is a local build rather than a release:
+// "dev", a bare commit hash, or a git-describe version past a tag.
+func isDevBuild(v string) bool {
+	if _, ok := parseSemver(v); !ok {
+		return true
+	}
+	return gitDescribeSuffix.FindString(v) != ""
+}
+
+// runInstallVersion installs the release tagged tag, whether it's newer or
+// older than the running build.
+func runInstallVersion(tag string) error {
+	if !strings.HasPrefix(tag, "v") {
+		tag = "v" + tag
+	}
+	current := version.Version
+
+	fmt.Printf("Current version: %s\n", current)
+	fmt.Printf("Looking up %s... ", tag)
+
+	release, err := fetchRelease(tag)
+	if err != nil {
+		fmt.Println("✗")
+		return err
+	}
+
+	fmt.Println("✓")
+
+	if strings.TrimPrefix(current, "v") == strings.TrimPrefix(release.TagName, "v") {
+		output.Successf("Already running %s.", release.TagName)
+		return nil
+	}
+
+	fmt.Printf("\nInstalling %s → %s\n", current, release.TagName)
+
+	return installRelease(release)
+}
+
+// installRelease downloads, verifies and installs the binary for this
+// platform from release.
+func installRelease(latest *githubRelease) error {
 	// Determine binary asset name for this platform
 	suffix := ""
 	if runtime.GOOS == "windows" {
 		suffix = ".exe"
 	}
 	assetName := fmt.Sprintf("%s-%s-%s%s", binaryName, runtime.GOOS, runtime.GOARCH, suffix)
-	downloadURL := fmt.Sprintf("https://github.com/%s/releases/download/%s/%s", repo, latest.TagName, assetName)
+	downloadURL := fmt.Sprintf("%s/%s/%s", releaseDownloadURL, latest.TagName, assetName)
 
+	fmt.Print("Fetching checksum... ")
+
+	expected, err := fetchChecksum(latest.TagName, assetName)
+	if err != nil {
+		fmt.Println("✗")
+		return fmt.Errorf("fetching checksum: %w", err)
+	}
+
+	fmt.Println("✓")
 	fmt.Printf("Downloading %s... ", assetName)
 
 	// Download to temp file
@@ -99,16 +228,17 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 	}
 	defer os.Remove(tmpFile)
 
+	// Never install a binary that doesn't match the published checksum
+	if err := verifyChecksum(tmpFile, expected); err != nil {
+		fmt.Println("✗")
+		return fmt.Errorf("verifying %s: %w", assetName, err)
+	}
+
 	fmt.Println("✓")
 
-	// Find current executable path
-	execPath, err := os.Executable()
+	execPath, err := executablePath()
 	if err != nil {
-		return fmt.Errorf("finding current executable: %w", err)
-	}
-	execPath, err = filepath.EvalSymlinks(execPath)
-	if err != nil {
-		return fmt.Errorf("resolving executable path: %w", err)
+		return err
 	}
 
 	fmt.Printf("Replacing %s... ", execPath)
@@ -120,17 +250,138 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 
 	fmt.Println("✓")
 	output.Successf("Updated to %s", latest.TagName)
+	if _, err := os.Stat(backupPath(execPath)); err == nil {
+		output.Warnf("Previous binary kept as %s — run `laevitas update --rollback` to restore it.", backupPath(execPath))
+	}
+
+	return nil
+}
+
+// runRollback swaps the .old backup left by the last update back in. The
+// binary it replaces becomes the new backup, so a second rollback undoes
+// the first.
+func runRollback() error {
+	execPath, err := executablePath()
+	if err != nil {
+		return err
+	}
+	backup := backupPath(execPath)
+	if _, err := os.Stat(backup); err != nil {
+		return fmt.Errorf("no previous version to roll back to (%s not found)", backup)
+	}
+
+	// Move the backup aside first: replaceBinary writes a fresh backup of
+	// the current binary to the same path.
+	tmp, err := os.CreateTemp("", "laevitas-rollback-*")
+	if err != nil {
+		return fmt.Errorf("creating temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	tmp.Close()
+	defer os.Remove(tmpPath)
+
+	if err := copyFile(backup, tmpPath); err != nil {
+		return fmt.Errorf("reading backup: %w", err)
+	}
+
+	fmt.Printf("Restoring %s... ", backup)
+
+	if err := replaceBinary(execPath, tmpPath); err != nil {
+		fmt.Println("✗")
+		return fmt.Errorf("replacing binary: %w", err)
+	}
+
+	fmt.Println("✓")
+	output.Successf("Rolled back. Run `laevitas version` to confirm.")
 
 	return nil
 }
 
+// executablePath returns the resolved path of the running binary.
+func executablePath() (string, error) {
+	execPath, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("finding current executable: %w", err)
+	}
+	execPath, err = filepath.EvalSymlinks(execPath)
+	if err != nil {
+		return "", fmt.Errorf("resolving executable path: %w", err)
+	}
+	return execPath, nil
+}
+
+// backupPath is where replaceBinary keeps the binary it replaced.
+func backupPath(execPath string) string {
+	return execPath + ".old"
+}
+
 func fetchLatestVersion() (*githubRelease, error) {
-	resp, err := http.Get(githubAPIURL)
+	return fetchReleaseFrom(githubAPIURL)
+}
+
+// fetchRelease looks up the release tagged tag.
+func fetchRelease(tag string) (*githubRelease, error) {
+	release, err := fetchReleaseFrom(githubTagURL + tag)
+	if err == errReleaseNotFound {
+		return nil, fmt.Errorf("release %s not found — see https://github.com/%s/releases", tag, repo)
+	}
+	return release, err
+}
+
+var errReleaseNotFound = errors.New("release not found")
+
+// fetchNewestRelease lists recent releases, prereleases included, and
+// returns the one with the highest semver tag. /releases/latest can't be
+// used here since it skips prereleases.
+func fetchNewestRelease() (*githubRelease, error) {
+	resp, err := http.Get(githubListURL + "?per_page=50")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GitHub API returned %d", resp.StatusCode)
+	}
+
+	var releases []githubRelease
+	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
+		return nil, fmt.Errorf("parsing release info: %w", err)
+	}
+
+	var newest *githubRelease
+	var newestVer semver
+	for i := range releases {
+		r := &releases[i]
+		if r.Draft {
+			continue
+		}
+		v, ok := parseSemver(r.TagName)
+		if !ok {
+			continue
+		}
+		if newest == nil || compareSemver(v, newestVer) > 0 {
+			newest, newestVer = r, v
+		}
+	}
+
+	if newest == nil {
+		return nil, fmt.Errorf("no releases found")
+	}
+
+	return newest, nil
+}
+
+func fetchReleaseFrom(url string) (*githubRelease, error) {
+	resp, err := http.Get(url)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errReleaseNotFound
+	}
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("GitHub API returned %d", resp.StatusCode)
 	}
@@ -147,6 +398,96 @@ func fetchLatestVersion() (*githubRelease, error) {
 	return &release, nil
 }
 
+// fetchChecksum returns the expected SHA-256 (hex) of assetName in the given
+// release, read from checksums.txt or, failing that, <asset>.sha256.
+func fetchChecksum(tag, assetName string) (string, error) {
+	base := fmt.Sprintf("%s/%s/", releaseDownloadURL, tag)
+
+	body, err := fetchSmallAsset(base + checksumsAsset)
+	if err == nil {
+		if sum, ok := parseChecksum(body, assetName); ok {
+			return sum, nil
+		}
+	}
+
+	body, err = fetchSmallAsset(base + assetName + ".sha256")
+	if err != nil {
+		return "", fmt.Errorf("no checksum published for %s in release %s", assetName, tag)
+	}
+	// Per-asset files may hold just the hash, or "<hash>  <name>"
+	if sum, ok := parseChecksum(body, assetName); ok {
+		return sum, nil
+	}
+	fields := strings.Fields(body)
+	if len(fields) > 0 && isSHA256(fields[0]) {
+		return strings.ToLower(fields[0]), nil
+	}
+	return "", fmt.Errorf("malformed checksum file for %s", assetName)
+}
+
+// fetchSmallAsset downloads a text release asset such as checksums.txt.
+func fetchSmallAsset(url string) (string, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// parseChecksum finds assetName in sha256sum output ("<hash>  <name>", with
+// an optional "*" marking binary mode).
+func parseChecksum(body, assetName string) (string, bool) {
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || !isSHA256(fields[0]) {
+			continue
+		}
+		if strings.TrimPrefix(fields[1], "*") == assetName {
+			return strings.ToLower(fields[0]), true
+		}
+	}
+	return "", false
+}
+
+func isSHA256(s string) bool {
+	if len(s) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+// verifyChecksum compares the SHA-256 of the file at path with expected.
+func verifyChecksum(path, expected string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+
+	actual := hex.EncodeToString(h.Sum(nil))
+	if actual != expected {
+		return fmt.Errorf("checksum mismatch (expected %s, got %s) — refusing to install", expected, actual)
+	}
+	return nil
+}
+
 func downloadBinary(url string) (string, error) {
 	resp, err := http.Get(url)
 	if err != nil {
@@ -177,9 +518,11 @@ func downloadBinary(url string) (string, error) {
 }
 
 func replaceBinary(target, source string) error {
-	// On Windows, can't overwrite a running exe — rename first
+	old := backupPath(target)
+
+	// On Windows, can't overwrite a running exe — rename first. The renamed
+	// file stays behind as the backup for --rollback.
 	if runtime.GOOS == "windows" {
-		old := target + ".old"
 		os.Remove(old) // clean up any previous .old file
 		if err := os.Rename(target, old); err != nil {
 			return fmt.Errorf("backing up current binary: %w", err)
@@ -189,10 +532,16 @@ func replaceBinary(target, source string) error {
 			os.Rename(old, target)
 			return err
 		}
-		os.Remove(old)
 		return nil
 	}
 
+	// Keep a copy of the current binary for --rollback. Best effort: a
+	// read-only install directory shouldn't block the update itself.
+	os.Remove(old)
+	if err := copyFile(target, old); err != nil {
+		os.Remove(old)
+	}
+
 	// Unix: write to temp in same dir, then atomic rename
 	dir := filepath.Dir(target)
 	tmp, err := os.CreateTemp(dir, ".laevitas-update-*")
@@ -0,0 +1,132 @@
+package update
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testAsset = "laevitas_linux_amd64"
+
+// serveRelease points releaseDownloadURL at a fixture server publishing
+// files under /<tag>/.
+func serveRelease(t *testing.T, tag string, files map[string]string) {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := files[strings.TrimPrefix(r.URL.Path, "/"+tag+"/")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+
+	old := releaseDownloadURL
+	releaseDownloadURL = srv.URL
+	t.Cleanup(func() { releaseDownloadURL = old })
+}
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func writeBinary(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), testAsset)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestFetchChecksumMatch(t *testing.T) {
+	binary := "release binary"
+	serveRelease(t, "v1.2.0", map[string]string{
+		checksumsAsset: sha256Hex("other") + "  laevitas_darwin_arm64\n" +
+			sha256Hex(binary) + "  " + testAsset + "\n",
+	})
+
+	sum, err := fetchChecksum("v1.2.0", testAsset)
+	if err != nil {
+		t.Fatalf("fetchChecksum: %v", err)
+	}
+	if sum != sha256Hex(binary) {
+		t.Fatalf("got checksum %s, want %s", sum, sha256Hex(binary))
+	}
+	if err := verifyChecksum(writeBinary(t, binary), sum); err != nil {
+		t.Errorf("verifyChecksum of matching binary: %v", err)
+	}
+}
+
+func TestFetchChecksumMismatch(t *testing.T) {
+	serveRelease(t, "v1.2.0", map[string]string{
+		checksumsAsset: sha256Hex("release binary") + "  " + testAsset + "\n",
+	})
+
+	sum, err := fetchChecksum("v1.2.0", testAsset)
+	if err != nil {
+		t.Fatalf("fetchChecksum: %v", err)
+	}
+	err = verifyChecksum(writeBinary(t, "tampered binary"), sum)
+	if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("verifyChecksum of tampered binary: got %v, want a mismatch error", err)
+	}
+}
+
+func TestFetchChecksumMissingAsset(t *testing.T) {
+	serveRelease(t, "v1.2.0", map[string]string{
+		checksumsAsset: sha256Hex("other") + "  laevitas_darwin_arm64\n",
+	})
+
+	if sum, err := fetchChecksum("v1.2.0", testAsset); err == nil {
+		t.Errorf("got checksum %s for an asset the release doesn't list", sum)
+	}
+}
+
+func TestFetchChecksumPerAssetFile(t *testing.T) {
+	binary := "release binary"
+	serveRelease(t, "v1.2.0", map[string]string{
+		testAsset + ".sha256": strings.ToUpper(sha256Hex(binary)) + "\n",
+	})
+
+	sum, err := fetchChecksum("v1.2.0", testAsset)
+	if err != nil {
+		t.Fatalf("fetchChecksum: %v", err)
+	}
+	if err := verifyChecksum(writeBinary(t, binary), sum); err != nil {
+		t.Errorf("verifyChecksum: %v", err)
+	}
+}
+
+func TestParseChecksum(t *testing.T) {
+	sum := sha256Hex("x")
+	tests := []struct {
+		name string
+		body string
+		want string
+		ok   bool
+	}{
+		{"text mode", sum + "  " + testAsset, sum, true},
+		{"binary mode", sum + " *" + testAsset, sum, true},
+		{"uppercase hash", strings.ToUpper(sum) + "  " + testAsset, sum, true},
+		{"other asset", sum + "  laevitas_windows_amd64.exe", "", false},
+		{"prefix of asset name", sum + "  laevitas_linux", "", false},
+		{"short hash", "abc123  " + testAsset, "", false},
+		{"empty", "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseChecksum(tt.body, testAsset)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("parseChecksum(%q) = %q, %v; want %q, %v", tt.body, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
@@ -1,10 +1,16 @@
 package cmd
 
 import (
+	"bufio"
+	"context"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"os"
-	"os/signal"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -14,6 +20,7 @@ import (
 
 	"github.com/laevitas/cli/internal/api"
 	"github.com/laevitas/cli/internal/cmdutil"
+	"github.com/laevitas/cli/internal/config"
 	"github.com/laevitas/cli/internal/output"
 )
 
@@ -29,10 +36,13 @@ const (
 	wRed         = "\033[31m"
 	wCyan        = "\033[36m"
 	wBgDarkGray  = "\033[48;5;236m"
+	wBgRed       = "\033[41m"
+	wBell        = "\a"
 	wWhite       = "\033[97m"
 )
 
-// validIntervals are the supported watch intervals.
+// validIntervals are named watch interval shortcuts. Any other duration
+// within [watchMinInterval, watchMaxInterval] is accepted too.
 var validIntervals = map[string]time.Duration{
 	"5s":  5 * time.Second,
 	"10s": 10 * time.Second,
@@ -41,6 +51,29 @@ var validIntervals = map[string]time.Duration{
 	"5m":  5 * time.Minute,
 }
 
+// Bounds for custom watch intervals: fast enough to be useful, slow
+// enough not to hammer the API.
+const (
+	watchMinInterval = 2 * time.Second
+	watchMaxInterval = time.Hour
+)
+
+// parseWatchInterval resolves a watch interval from a preset name or any
+// Go duration string (15s, 2m, 1m30s) within the allowed bounds.
+func parseWatchInterval(s string) (time.Duration, error) {
+	if d, ok := validIntervals[s]; ok {
+		return d, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid interval %q — use a duration like 5s, 15s, 2m or 1h", s)
+	}
+	if d < watchMinInterval || d > watchMaxInterval {
+		return 0, fmt.Errorf("interval %s out of range — must be between 2s and 1h", s)
+	}
+	return d, nil
+}
+
 var watchCmd = &cobra.Command{
 	Use:   "watch <interval> <command> [args...]",
 	Short: "Re-run a query at a configurable interval with live-updating output",
@@ -50,10 +83,28 @@ displays live-updating output with change highlighting.
 Values that increased since the last refresh are shown in green,
 values that decreased are shown in red.
 
-Supported intervals: 5s, 10s, 30s, 1m, 5m
-Press 'q' or Ctrl+C to exit watch mode.`,
+Intervals are durations between 2s and 1h (e.g. 5s, 15s, 2m, 1m30s).
+Press 'q' or Ctrl+C to exit watch mode. Where the terminal can't be put in
+raw mode (Windows consoles, redirected stdin) only Ctrl+C is available.
+
+Watch flags (may appear anywhere after "watch"):
+  --diff           Only show rows where a numeric value changed since the last refresh
+  --record <file>  Append every refresh to a log file with a refreshed_at column
+                   (CSV for .csv files, NDJSON otherwise)
+  --only-changed   With --record, log only cells that changed between refreshes,
+                   one {ts, row_key, col, from, to} event each
+  --alert <cond>   Highlight cells and ring the bell when "column OP value" holds,
+                   with OP one of > < >= <= == (repeatable)
+  --spark <column> Add a trailing sparkline of each row's recent values of column
+  --compact        One space between columns, to fit more of them
+  --explain        Describe the request each refresh would make, without running it`,
 	Example: `  laevitas watch 10s perps funding BTC-PERPETUAL -n 1
   laevitas watch 30s futures snapshot --currency BTC
+  laevitas watch --diff 10s options snapshot --currency BTC
+  laevitas watch 1m perps snapshot --currency BTC --record perps.ndjson
+  laevitas watch 30s perps snapshot --currency BTC --record flips.ndjson --only-changed
+  laevitas watch 30s perps carry BTC-PERPETUAL --alert 'funding_rate_close>0.0005'
+  laevitas watch 10s perps snapshot --currency BTC --spark funding_rate
   laevitas watch 1m options snapshot --currency ETH
   laevitas watch 5s perps snapshot --currency BTC`,
 	DisableFlagParsing: true,
@@ -80,16 +131,78 @@ Press 'q' or Ctrl+C to exit watch mode.`,
 	},
 }
 
+// watchOptions holds watch's own flags. Flag parsing is disabled on the
+// watch command so the inner command's flags pass through untouched; these
+// are picked out of the argument list by extractWatchFlags instead.
+type watchOptions struct {
+	diffOnly    bool   // --diff: render only rows that changed
+	recordPath  string // --record: append each refresh to this file
+	onlyChanged bool   // --only-changed: record cell changes instead of rows
+	alerts      []watchAlert
+	sparkColumn string // --spark: column to trend in a trailing sparkline
+}
+
+// extractWatchFlags removes watch-level flags from args, wherever they
+// appear, and returns the options along with the remaining args.
+func extractWatchFlags(args []string) (watchOptions, []string, error) {
+	var opts watchOptions
+	rest := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		name, value, hasValue := strings.Cut(args[i], "=")
+		switch name {
+		case "--diff":
+			opts.diffOnly = true
+		case "--only-changed":
+			opts.onlyChanged = true
+		case "--compact":
+			output.Compact = true
+		case "--explain":
+			cmdutil.Explain = true
+		case "--record", "--alert", "--spark":
+			if !hasValue {
+				if i+1 >= len(args) {
+					return opts, nil, fmt.Errorf("flag needs an argument: %s", name)
+				}
+				i++
+				value = args[i]
+			}
+			switch name {
+			case "--record":
+				opts.recordPath = value
+				continue
+			case "--spark":
+				opts.sparkColumn = value
+				continue
+			}
+			alert, err := parseWatchAlert(value)
+			if err != nil {
+				return opts, nil, err
+			}
+			opts.alerts = append(opts.alerts, alert)
+		default:
+			rest = append(rest, args[i])
+		}
+	}
+	return opts, rest, nil
+}
+
 // runWatch is the main watch loop.
 func runWatch(args []string) error {
+	opts, args, err := extractWatchFlags(args)
+	if err != nil {
+		return err
+	}
 	if len(args) < 2 {
 		return fmt.Errorf("usage: watch <interval> <command> [args...]")
 	}
+	if opts.onlyChanged && opts.recordPath == "" {
+		return fmt.Errorf("--only-changed needs --record <file>")
+	}
 
 	intervalStr := args[0]
-	interval, ok := validIntervals[intervalStr]
-	if !ok {
-		return fmt.Errorf("invalid interval %q — supported: 5s, 10s, 30s, 1m, 5m", intervalStr)
+	interval, err := parseWatchInterval(intervalStr)
+	if err != nil {
+		return err
 	}
 
 	innerArgs := args[1:]
@@ -105,28 +218,55 @@ func runWatch(args []string) error {
 	if client == nil {
 		return fmt.Errorf("no API client available")
 	}
+	if cmdutil.ExplainRequest(client, endpoint, params) {
+		return nil
+	}
+	// The payment prompt can't read the raw-mode stdin watch holds
+	cmdutil.RefusePayments(client)
 
-	// Put terminal in raw mode so we can read 'q' without blocking
-	oldState, err := term.MakeRaw(int(os.Stdin.Fd()))
-	if err != nil {
-		return fmt.Errorf("failed to set raw terminal mode: %w", err)
+	var recorder *watchRecorder
+	if opts.recordPath != "" {
+		recorder, err = newWatchRecorder(opts.recordPath, opts.onlyChanged)
+		if err != nil {
+			return err
+		}
+		// Runs after watchExit on every exit path, flushing the last refresh
+		defer recorder.Close()
 	}
-	defer term.Restore(int(os.Stdin.Fd()), oldState)
+
+	// Put terminal in raw mode so we can read 'q' without blocking. When
+	// that isn't possible, fall back to Ctrl+C only via the signal handler.
+	fd := int(os.Stdin.Fd())
+	oldState := watchMakeRaw(fd)
+	keysEnabled := oldState != nil
 
 	// Hide cursor
 	fmt.Print(wHideCursor)
-	defer fmt.Print(wShowCursor)
 
-	// Handle Ctrl+C gracefully
-	sigCh := make(chan os.Signal, 1)
-	signal.Notify(sigCh, os.Interrupt)
-	defer signal.Stop(sigCh)
+	// Always leave the terminal usable. Deferred calls also run while a
+	// panic unwinds, so a rendering panic is reported on a sane terminal.
+	defer func() {
+		if oldState != nil {
+			term.Restore(fd, oldState)
+		}
+		fmt.Print(wShowCursor)
+	}()
+
+	// Ctrl+C (a signal, or a key in raw mode) and q cancel ctx, which
+	// also aborts a fetch in flight instead of waiting for its timeout
+	sigCtx, stop := cmdutil.SignalContext()
+	defer stop()
+	ctx, cancel := context.WithCancel(sigCtx)
+	defer cancel()
 
-	// Channel for keypress events
+	// Channel for keypress events; stays silent without raw mode
 	keyCh := make(chan byte, 1)
-	go watchReadKeys(keyCh)
+	if keysEnabled {
+		go watchReadKeys(keyCh, cancel)
+	}
 
 	var prevData []byte
+	history := watchSparkHistory{}
 	lastRefresh := time.Time{}
 	tick := time.NewTicker(100 * time.Millisecond) // tick for status bar updates
 	defer tick.Stop()
@@ -135,12 +275,12 @@ func runWatch(args []string) error {
 
 	for {
 		select {
-		case <-sigCh:
+		case <-ctx.Done():
 			watchExit()
 			return nil
 
 		case key := <-keyCh:
-			if key == 'q' || key == 'Q' || key == 3 { // 3 = Ctrl+C
+			if watchIsQuitKey(key) {
 				watchExit()
 				return nil
 			}
@@ -152,7 +292,11 @@ func runWatch(args []string) error {
 			if refreshNow || (!lastRefresh.IsZero() && now.Sub(lastRefresh) >= interval) {
 				refreshNow = false
 
-				data, fetchErr := client.Get(endpoint, params)
+				data, fetchErr := client.Get(ctx, endpoint, params)
+				if ctx.Err() != nil {
+					watchExit()
+					return nil
+				}
 
 				// Render the screen
 				fmt.Print(wClearScreen)
@@ -161,8 +305,16 @@ func runWatch(args []string) error {
 				if fetchErr != nil {
 					output.Errorf("Fetch failed: %s", fetchErr)
 				} else {
-					watchRenderTable(data, prevData)
+					if opts.sparkColumn != "" {
+						history.add(data, opts.sparkColumn)
+					}
+					watchRenderTable(data, prevData, opts, history)
 					prevData = data
+					if recorder != nil {
+						if recErr := recorder.Record(data, time.Now()); recErr != nil {
+							output.Errorf("Recording failed: %s", recErr)
+						}
+					}
 				}
 
 				lastRefresh = time.Now()
@@ -175,7 +327,7 @@ func runWatch(args []string) error {
 				if remaining < 0 {
 					remaining = 0
 				}
-				watchPrintStatusBar(elapsed, remaining)
+				watchPrintStatusBar(elapsed, remaining, keysEnabled)
 			}
 		}
 	}
@@ -183,26 +335,45 @@ func runWatch(args []string) error {
 
 // resolveWatchCommand walks the cobra command tree to find the API endpoint
 // and request params for the given args (e.g. ["perps", "funding", "BTC-PERPETUAL", "-n", "1"]).
+// The command's flags are restored afterwards, so running it again (the
+// other side of a diff, or the next REPL line) starts clean.
 func resolveWatchCommand(args []string) (string, *api.RequestParams, error) {
+	if cmd, _, err := rootCmd.Find(args); err == nil {
+		defer resetChangedFlags(cmd.Flags())
+	}
+	cmd, err := parseWatchCommand(args)
+	if err != nil {
+		return "", nil, err
+	}
+	return watchRequest(cmd)
+}
+
+// parseWatchCommand finds the leaf command for args and parses its flags.
+func parseWatchCommand(args []string) (*cobra.Command, error) {
 	if len(args) == 0 {
-		return "", nil, fmt.Errorf("no command specified")
+		return nil, fmt.Errorf("no command specified")
 	}
 
 	// Walk the command tree to find the leaf command
 	cmd, remainingArgs, err := rootCmd.Find(args)
 	if err != nil {
-		return "", nil, fmt.Errorf("unknown command: %s", strings.Join(args, " "))
+		return nil, fmt.Errorf("unknown command: %s", strings.Join(args, " "))
 	}
 
 	if cmd == rootCmd {
-		return "", nil, fmt.Errorf("cannot watch the root command — specify a subcommand like 'perps funding BTC-PERPETUAL'")
+		return nil, fmt.Errorf("cannot watch the root command — specify a subcommand like 'perps funding BTC-PERPETUAL'")
 	}
 
 	// Parse flags on the resolved command
 	if err := cmd.ParseFlags(remainingArgs); err != nil {
-		return "", nil, fmt.Errorf("parsing flags: %w", err)
+		return nil, fmt.Errorf("parsing flags: %w", err)
 	}
+	return cmd, nil
+}
 
+// watchRequest builds the endpoint and request params from the flags
+// parseWatchCommand parsed into cmd.
+func watchRequest(cmd *cobra.Command) (string, *api.RequestParams, error) {
 	// Get non-flag args
 	nonFlagArgs := cmd.Flags().Args()
 
@@ -217,19 +388,41 @@ func resolveWatchCommand(args []string) (string, *api.RequestParams, error) {
 		Exchange: cmdutil.Exchange,
 	}
 
-	// Extract common flags if they exist on this command
-	if f := cmd.Flags().Lookup("start"); f != nil && f.Value.String() != "" {
-		params.Start = f.Value.String()
+	// Flags after the command name never reach PersistentPreRunE, so a
+	// --exchange given there is read from the command's own parsed flags
+	if f := cmd.Flags().Lookup("exchange"); f != nil && f.Value.String() != "" {
+		ex, err := config.NormalizeExchange(f.Value.String())
+		if err != nil {
+			return "", nil, err
+		}
+		params.Exchange = ex
 	}
-	if f := cmd.Flags().Lookup("end"); f != nil && f.Value.String() != "" {
-		params.End = f.Value.String()
+
+	// Commands with the common time-range flags are validated and get
+	// their window from ToParams, as when run directly (--period,
+	// --since/--for, epoch --start/--end)
+	if common := cmdutil.CommonFlagsOf(cmd); common != nil {
+		if err := common.Validate(); err != nil {
+			return "", nil, err
+		}
+		window := common.ToParams()
+		params.Start, params.End = window.Start, window.End
+	} else {
+		if f := cmd.Flags().Lookup("start"); f != nil && f.Value.String() != "" {
+			params.Start = f.Value.String()
+		}
+		if f := cmd.Flags().Lookup("end"); f != nil && f.Value.String() != "" {
+			params.End = f.Value.String()
+		}
 	}
+
+	// Extract common flags if they exist on this command
 	if f := cmd.Flags().Lookup("resolution"); f != nil && f.Value.String() != "" {
 		params.Resolution = f.Value.String()
 	}
 	if f := cmd.Flags().Lookup("limit"); f != nil && f.Value.String() != "" && f.Value.String() != "0" {
-		if n, parseErr := strconv.Atoi(f.Value.String()); parseErr == nil {
-			params.Limit = n
+		if n, parseErr := strconv.Atoi(f.Value.String()); parseErr == nil && n > 0 {
+			params.Limit = cmdutil.ClampLimit(n)
 		}
 	}
 	if f := cmd.Flags().Lookup("cursor"); f != nil && f.Value.String() != "" {
@@ -289,7 +482,8 @@ func resolveWatchCommand(args []string) (string, *api.RequestParams, error) {
 			params.MinAmountUsd = v
 		}
 	}
-	if f := cmd.Flags().Lookup("group-by"); f != nil && f.Value.String() != "" {
+	// Only trades-summary's own API flag; never a persistent one
+	if f := cmd.NonInheritedFlags().Lookup("group-by"); f != nil && f.Value.String() != "" {
 		params.GroupBy = f.Value.String()
 	}
 	if f := cmd.Flags().Lookup("strategy"); f != nil && f.Value.String() != "" {
@@ -326,51 +520,54 @@ func watchEndpointForCommand(cmd *cobra.Command) (string, error) {
 	// Map of "parent child" → endpoint
 	endpointMap := map[string]string{
 		// Futures
-		"futures catalog":   api.FuturesCatalog,
-		"futures snapshot":  api.FuturesSnapshot,
-		"futures ohlcvt":    api.FuturesOHLCVT,
-		"futures oi":        api.FuturesOpenInterest,
-		"futures carry":     api.FuturesCarry,
-		"futures trades":    api.FuturesTrades,
-		"futures volume":    api.FuturesVolume,
-		"futures level1":    api.FuturesLevel1,
-		"futures orderbook": api.FuturesOrderbook,
-		"futures ticker":    api.FuturesTickerHistory,
-		"futures ref-price": api.FuturesReferencePrice,
+		"futures catalog":        api.FuturesCatalog,
+		"futures snapshot":       api.FuturesSnapshot,
+		"futures ohlcvt":         api.FuturesOHLCVT,
+		"futures oi":             api.FuturesOpenInterest,
+		"futures carry":          api.FuturesCarry,
+		"futures trades":         api.FuturesTrades,
+		"futures volume":         api.FuturesVolume,
+		"futures level1":         api.FuturesLevel1,
+		"futures orderbook":      api.FuturesOrderbook,
+		"futures orderbook-raw":  api.FuturesOrderbookRaw,
+		"futures ticker":         api.FuturesTickerHistory,
+		"futures ref-price":      api.FuturesReferencePrice,
 		"futures metadata":       api.FuturesMetadata,
 		"futures liquidations":   api.FuturesLiquidations,
 		"futures trades-summary": api.FuturesTradesSummary,
 		"futures flow":           api.FuturesFlow,
 		// Perps
-		"perps catalog":   api.PerpsCatalog,
-		"perps snapshot":  api.PerpsSnapshot,
-		"perps carry":     api.PerpsCarry,
-		"perps ohlcvt":    api.PerpsOHLCVT,
-		"perps oi":        api.PerpsOpenInterest,
-		"perps trades":    api.PerpsTrades,
-		"perps volume":    api.PerpsVolume,
-		"perps level1":    api.PerpsLevel1,
-		"perps orderbook": api.PerpsOrderbook,
-		"perps ticker":    api.PerpsTickerHistory,
-		"perps ref-price": api.PerpsReferencePrice,
+		"perps catalog":        api.PerpsCatalog,
+		"perps snapshot":       api.PerpsSnapshot,
+		"perps carry":          api.PerpsCarry,
+		"perps ohlcvt":         api.PerpsOHLCVT,
+		"perps oi":             api.PerpsOpenInterest,
+		"perps trades":         api.PerpsTrades,
+		"perps volume":         api.PerpsVolume,
+		"perps level1":         api.PerpsLevel1,
+		"perps orderbook":      api.PerpsOrderbook,
+		"perps orderbook-raw":  api.PerpsOrderbookRaw,
+		"perps ticker":         api.PerpsTickerHistory,
+		"perps ref-price":      api.PerpsReferencePrice,
 		"perps metadata":       api.PerpsMetadata,
 		"perps liquidations":   api.PerpsLiquidations,
 		"perps trades-summary": api.PerpsTradesSummary,
 		"perps flow":           api.PerpsFlow,
 		// Options
-		"options catalog":    api.OptionsCatalog,
-		"options snapshot":   api.OptionsSnapshot,
-		"options ohlcvt":     api.OptionsOHLCVT,
-		"options trades":     api.OptionsTrades,
-		"options oi":         api.OptionsOpenInterest,
-		"options volume":     api.OptionsVolume,
-		"options level1":     api.OptionsLevel1,
-		"options ref-price":  api.OptionsReferencePrice,
-		"options flow":       api.OptionsFlow,
-		"options ticker":     api.OptionsTickerHistory,
-		"options volatility": api.OptionsVolatility,
+		"options catalog":        api.OptionsCatalog,
+		"options snapshot":       api.OptionsSnapshot,
+		"options ohlcvt":         api.OptionsOHLCVT,
+		"options trades":         api.OptionsTrades,
+		"options oi":             api.OptionsOpenInterest,
+		"options volume":         api.OptionsVolume,
+		"options level1":         api.OptionsLevel1,
+		"options ref-price":      api.OptionsReferencePrice,
+		"options flow":           api.OptionsFlow,
+		"options ticker":         api.OptionsTickerHistory,
+		"options volatility":     api.OptionsVolatility,
 		"options metadata":       api.OptionsMetadata,
 		"options trades-summary": api.OptionsTradesSummary,
+		"options liquidations":   api.OptionsLiquidations,
 		// Vol surface (under options)
 		"options vol-surface by-expiry": api.VolSurfaceByExpiry,
 		"options vol-surface by-tenor":  api.VolSurfaceByTenor,
@@ -384,6 +581,7 @@ func watchEndpointForCommand(cmd *cobra.Command) (string, error) {
 		"predictions orderbook":  api.PredictionsOrderbookRaw,
 		"predictions ticker":     api.PredictionsTickerHistory,
 		"predictions metadata":   api.PredictionsMetadata,
+		"predictions flow":       api.PredictionsFlow,
 	}
 
 	// Extract command key from full path "laevitas parent child [subchild]"
@@ -419,7 +617,11 @@ func watchPrintHeader(cmdLabel, interval string) {
 }
 
 // watchRenderTable renders the API data as a table with change highlighting.
-func watchRenderTable(data, prevData []byte) {
+// With --diff, rows without a changed numeric cell are left out once there
+// is a previous refresh to compare against. Cells matching an --alert are
+// highlighted and listed below the table. With --spark, each row ends in a
+// sparkline of its history for that column.
+func watchRenderTable(data, prevData []byte, opts watchOptions, history watchSparkHistory) {
 	currRows := watchParseJSON(data)
 	prevRows := watchParseJSON(prevData)
 
@@ -436,6 +638,16 @@ func watchRenderTable(data, prevData []byte) {
 	numCols := len(headers)
 	isNumeric := watchDetectNumeric(headers, dataRows)
 
+	hits := watchFindAlerts(opts.alerts, headers, dataRows, prevValues)
+
+	// Rows to print: all of them, or only changed or alerting ones in diff mode
+	visible := make([]int, 0, len(dataRows))
+	for r := range dataRows {
+		if !opts.diffOnly || prevData == nil || hits.rows[r] || watchRowChanged(dataRows[r], r, headers, isNumeric, prevValues) {
+			visible = append(visible, r)
+		}
+	}
+
 	// Format data cells for display
 	displayRows := make([][]string, len(dataRows))
 	for r, row := range dataRows {
@@ -470,12 +682,20 @@ func watchRenderTable(data, prevData []byte) {
 		}
 	}
 
-	// Terminal width truncation
+	// Terminal width truncation, leaving room for the sparkline column
+	gap := output.ColumnGap()
 	termWidth := watchTermWidth()
+	sparkHeader := ""
+	if opts.sparkColumn != "" {
+		sparkHeader = strings.ToUpper(opts.sparkColumn) + " TREND"
+		if termWidth > 0 {
+			termWidth -= len(gap) + max(len(sparkHeader), watchSparkLen)
+		}
+	}
 	totalWidth := 0
 	for i, w := range widths {
 		if i > 0 {
-			totalWidth += 2
+			totalWidth += len(gap)
 		}
 		totalWidth += w
 	}
@@ -487,34 +707,49 @@ func watchRenderTable(data, prevData []byte) {
 	var hdr strings.Builder
 	for i, h := range displayHeaders {
 		if i > 0 {
-			hdr.WriteString("  ")
+			hdr.WriteString(gap)
 		}
 		hdr.WriteString(watchPadRight(h, widths[i]))
 	}
+	if sparkHeader != "" {
+		hdr.WriteString(gap + sparkHeader)
+	}
 	fmt.Printf("%s%s%s%s%s\n", wBold, wWhite, wBgDarkGray, hdr.String(), wReset)
 
 	// Print separator
 	var sep strings.Builder
 	for i, w := range widths {
 		if i > 0 {
-			sep.WriteString("  ")
+			sep.WriteString(gap)
 		}
 		sep.WriteString(strings.Repeat("─", w))
 	}
+	if sparkHeader != "" {
+		sep.WriteString(gap + strings.Repeat("─", max(len(sparkHeader), watchSparkLen)))
+	}
 	fmt.Printf("%s%s%s\n", wDim, sep.String(), wReset)
 
+	if len(visible) == 0 {
+		fmt.Printf("\n  %sNo changes at %s%s\n", wDim, time.Now().Format("15:04:05"), wReset)
+		fmt.Printf("\n%s0 of %d records changed%s\n", wDim, len(dataRows), wReset)
+		return
+	}
+
 	// Print data rows with change highlighting
-	for r, row := range displayRows {
+	for _, r := range visible {
+		row := displayRows[r]
 		var line strings.Builder
 		for c, cell := range row {
 			if c > 0 {
-				line.WriteString("  ")
+				line.WriteString(gap)
 			}
 
 			padded := watchPadCell(cell, widths[c], isNumeric[c])
 
-			// Check for change from previous data
-			if isNumeric[c] && prevData != nil && c < len(headers) && r < len(dataRows) && c < len(dataRows[r]) {
+			// Alerts take precedence over up/down coloring
+			if hits.cells[watchKey(r, headers[c])] {
+				padded = wBold + wWhite + wBgRed + padded + wReset
+			} else if isNumeric[c] && prevData != nil && c < len(headers) && r < len(dataRows) && c < len(dataRows[r]) {
 				prevVal, hasPrev := prevValues[watchKey(r, headers[c])]
 				if hasPrev {
 					currRaw := dataRows[r][c]
@@ -529,17 +764,44 @@ func watchRenderTable(data, prevData []byte) {
 
 			line.WriteString(padded)
 		}
+		if sparkHeader != "" {
+			line.WriteString(gap + wCyan + output.Sparkline(history[watchKey(r, opts.sparkColumn)]) + wReset)
+		}
 		fmt.Println(line.String())
 	}
 
 	// Record count
-	if len(dataRows) > 0 {
+	if len(visible) < len(dataRows) {
+		fmt.Printf("\n%s%d of %d records changed%s\n", wDim, len(visible), len(dataRows), wReset)
+	} else if len(dataRows) > 0 {
 		fmt.Printf("\n%s%d records%s\n", wDim, len(dataRows), wReset)
 	}
+
+	watchPrintAlerts(hits)
+}
+
+// watchRowChanged reports whether any numeric cell of data row r differs
+// from the previous refresh. Rows with no previous values count as changed.
+func watchRowChanged(row []string, r int, headers []string, isNumeric []bool, prevValues map[string]string) bool {
+	seen := false
+	for c, cell := range row {
+		if c >= len(headers) {
+			break
+		}
+		prevVal, hasPrev := prevValues[watchKey(r, headers[c])]
+		if !hasPrev {
+			continue
+		}
+		seen = true
+		if isNumeric[c] && watchCompare(cell, prevVal) != 0 {
+			return true
+		}
+	}
+	return !seen
 }
 
 // watchPrintStatusBar renders the live-updating status bar at the bottom.
-func watchPrintStatusBar(elapsed, remaining time.Duration) {
+func watchPrintStatusBar(elapsed, remaining time.Duration, keysEnabled bool) {
 	tw := watchTermWidth()
 	if tw <= 0 {
 		tw = 80
@@ -548,8 +810,12 @@ func watchPrintStatusBar(elapsed, remaining time.Duration) {
 	elapsedStr := watchFmtDuration(elapsed)
 	remainingStr := watchFmtDuration(remaining)
 
-	bar := fmt.Sprintf(" Last updated: %s ago | Next refresh in %s | Press q to stop",
-		elapsedStr, remainingStr)
+	stopHint := "Press q to stop"
+	if !keysEnabled {
+		stopHint = "Press Ctrl+C to stop"
+	}
+	bar := fmt.Sprintf(" Last updated: %s ago | Next refresh in %s | %s",
+		elapsedStr, remainingStr, stopHint)
 
 	// Pad to terminal width for full-width background
 	if len(bar) < tw {
@@ -565,6 +831,437 @@ func watchPrintStatusBar(elapsed, remaining time.Duration) {
 	fmt.Printf("%s%s%s%s", wBgDarkGray, wWhite, bar, wReset)
 }
 
+// ─── Watch alerts ────────────────────────────────────────────────────────────
+
+// watchAlert is a parsed --alert condition, e.g. funding_rate_close>0.0005.
+type watchAlert struct {
+	expr   string
+	column string
+	op     string
+	value  float64
+}
+
+var watchAlertPattern = regexp.MustCompile(`^\s*([A-Za-z0-9_.]+)\s*(>=|<=|==|>|<)\s*(\S+)\s*$`)
+
+func parseWatchAlert(s string) (watchAlert, error) {
+	m := watchAlertPattern.FindStringSubmatch(s)
+	if m == nil {
+		return watchAlert{}, fmt.Errorf("invalid --alert %q — use column OP value with OP one of > < >= <= ==", s)
+	}
+	v, err := strconv.ParseFloat(m[3], 64)
+	if err != nil {
+		return watchAlert{}, fmt.Errorf("invalid --alert %q — %q is not a number", s, m[3])
+	}
+	return watchAlert{expr: strings.TrimSpace(s), column: m[1], op: m[2], value: v}, nil
+}
+
+// matches reports whether the raw cell value satisfies the condition.
+// Non-numeric and empty cells never match.
+func (a watchAlert) matches(cell string) bool {
+	v, err := strconv.ParseFloat(cell, 64)
+	if err != nil {
+		return false
+	}
+	switch a.op {
+	case ">":
+		return v > a.value
+	case "<":
+		return v < a.value
+	case ">=":
+		return v >= a.value
+	case "<=":
+		return v <= a.value
+	case "==":
+		return v == a.value
+	}
+	return false
+}
+
+// watchAlertHit is one cell that satisfied an alert on this refresh.
+type watchAlertHit struct {
+	alert watchAlert
+	label string // instrument_name, or the row number
+	value string
+	fresh bool // didn't match on the previous refresh
+}
+
+// watchAlertHits collects every alerting cell of a refresh.
+type watchAlertHits struct {
+	list  []watchAlertHit
+	cells map[string]bool // watchKey → hit
+	rows  map[int]bool
+}
+
+// watchFindAlerts evaluates alerts against every row. A hit is fresh when
+// the same cell didn't match on the previous refresh, so the bell only
+// rings when a threshold is crossed rather than on every tick.
+func watchFindAlerts(alerts []watchAlert, headers []string, dataRows [][]string, prevValues map[string]string) watchAlertHits {
+	hits := watchAlertHits{cells: map[string]bool{}, rows: map[int]bool{}}
+	if len(alerts) == 0 {
+		return hits
+	}
+	colIndex := make(map[string]int, len(headers))
+	for c, h := range headers {
+		colIndex[h] = c
+	}
+	labelCol, hasLabel := colIndex[diffKeyColumn]
+
+	for r, row := range dataRows {
+		for _, a := range alerts {
+			c, ok := colIndex[a.column]
+			if !ok || c >= len(row) || !a.matches(row[c]) {
+				continue
+			}
+			key := watchKey(r, a.column)
+			prev, hadPrev := prevValues[key]
+			label := fmt.Sprintf("row %d", r+1)
+			if hasLabel && labelCol < len(row) && row[labelCol] != "" {
+				label = row[labelCol]
+			}
+			hits.list = append(hits.list, watchAlertHit{
+				alert: a,
+				label: label,
+				value: row[c],
+				fresh: !hadPrev || !a.matches(prev),
+			})
+			hits.cells[key] = true
+			hits.rows[r] = true
+		}
+	}
+	return hits
+}
+
+// watchPrintAlerts lists alert hits below the table and rings the terminal
+// bell if any of them is new.
+func watchPrintAlerts(hits watchAlertHits) {
+	if len(hits.list) == 0 {
+		return
+	}
+	fmt.Println()
+	bell := false
+	for _, h := range hits.list {
+		fmt.Printf("%s%s%s ALERT %s %s  %s = %s  %s(%s)%s\n",
+			wBold, wWhite, wBgRed, wReset,
+			h.label, h.alert.column, output.FormatNumber(h.value),
+			wDim, h.alert.expr, wReset)
+		if h.fresh {
+			bell = true
+		}
+	}
+	if bell {
+		fmt.Print(wBell)
+	}
+}
+
+// ─── Watch sparklines ───────────────────────────────────────────────────────
+
+// watchSparkLen is how many refreshes a --spark sparkline covers.
+const watchSparkLen = 20
+
+// watchSparkHistory holds recent values per cell, keyed by watchKey, as a
+// ring buffer of the last watchSparkLen refreshes.
+type watchSparkHistory map[string][]float64
+
+// add appends this refresh's value of column for every row.
+func (h watchSparkHistory) add(data []byte, column string) {
+	rows := watchParseJSON(data)
+	if len(rows) < 2 {
+		return
+	}
+	col := -1
+	for i, name := range rows[0] {
+		if name == column {
+			col = i
+			break
+		}
+	}
+	if col < 0 {
+		return
+	}
+	for r, row := range rows[1:] {
+		v, err := strconv.ParseFloat(row[col], 64)
+		if err != nil {
+			continue
+		}
+		key := watchKey(r, column)
+		values := append(h[key], v)
+		if len(values) > watchSparkLen {
+			values = values[len(values)-watchSparkLen:]
+		}
+		h[key] = values
+	}
+}
+
+// ─── Watch recording ─────────────────────────────────────────────────────────
+
+// watchRecorder appends every watch refresh to a CSV or NDJSON file, one
+// row per record plus a refreshed_at column.
+type watchRecorder struct {
+	file    *os.File
+	csv     *csv.Writer // nil for NDJSON
+	columns []string    // CSV header, fixed by the first refresh
+
+	// onlyChanged records cell changes against prev (--only-changed)
+	onlyChanged bool
+	prev        [][]string
+}
+
+// newWatchRecorder opens path for appending. Files ending in .csv are
+// written as CSV, anything else as NDJSON. An existing file must hold the
+// same kind of records, full rows or --only-changed events, since the two
+// have different columns.
+func newWatchRecorder(path string, onlyChanged bool) (*watchRecorder, error) {
+	isCSV := strings.EqualFold(filepath.Ext(path), ".csv")
+	var existing []string
+	if isCSV {
+		existing, _ = readCSVHeader(path)
+	} else {
+		existing = readNDJSONKeys(path)
+	}
+	if len(existing) > 0 {
+		// Full-row records always carry refreshed_at, change events never do
+		if changeLog := !slices.Contains(existing, "refreshed_at"); changeLog != onlyChanged {
+			if onlyChanged {
+				return nil, fmt.Errorf("%s holds full-row records; record --only-changed events to a new file", path)
+			}
+			return nil, fmt.Errorf("%s holds --only-changed events; record full rows to a new file", path)
+		}
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("opening record file: %w", err)
+	}
+	r := &watchRecorder{file: f, onlyChanged: onlyChanged}
+	if isCSV {
+		r.csv = csv.NewWriter(f)
+		// Appending to an existing log: reuse its header
+		if len(existing) > 0 {
+			r.columns = existing
+		}
+	}
+	return r, nil
+}
+
+// Record writes one refresh and flushes it to disk.
+func (r *watchRecorder) Record(data []byte, at time.Time) error {
+	rows := watchParseJSON(data)
+	if len(rows) < 2 {
+		return nil
+	}
+	headers, dataRows := rows[0], rows[1:]
+	ts := at.UTC().Format(time.RFC3339)
+
+	if r.onlyChanged {
+		prev := r.prev
+		r.prev = rows
+		if prev == nil {
+			return nil // first refresh is the baseline
+		}
+		return r.recordChanges(ts, watchChanges(prev, rows))
+	}
+
+	if r.csv == nil {
+		for _, row := range dataRows {
+			rec := map[string]interface{}{"refreshed_at": ts}
+			for c, cell := range row {
+				if c < len(headers) && cell != "" {
+					rec[headers[c]] = watchRecordValue(cell)
+				}
+			}
+			line, err := json.Marshal(rec)
+			if err != nil {
+				return err
+			}
+			if _, err := r.file.Write(append(line, '\n')); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if r.columns == nil {
+		r.columns = append([]string{"refreshed_at"}, headers...)
+		if err := r.csv.Write(r.columns); err != nil {
+			return err
+		}
+	}
+	index := make(map[string]int, len(headers))
+	for c, h := range headers {
+		index[h] = c
+	}
+	for _, row := range dataRows {
+		out := make([]string, len(r.columns))
+		out[0] = ts
+		for i, col := range r.columns[1:] {
+			if c, ok := index[col]; ok && c < len(row) {
+				out[i+1] = row[c]
+			}
+		}
+		if err := r.csv.Write(out); err != nil {
+			return err
+		}
+	}
+	r.csv.Flush()
+	return r.csv.Error()
+}
+
+// watchChange is one cell that changed between two refreshes.
+type watchChange struct {
+	RowKey   string
+	Col      string
+	From, To string
+}
+
+// watchRowKeyColumns identify a row across refreshes, in order of
+// preference. Rows without any of them are matched by position.
+var watchRowKeyColumns = []string{"instrument_name", "slug", "maturity"}
+
+// watchChanges lists the cells of curr that differ from prev. Numbers are
+// compared numerically (watchCompare), so 0.10 → 0.1 is not a change.
+// Rows that are new in curr have nothing to compare against and are skipped.
+func watchChanges(prev, curr [][]string) []watchChange {
+	prevKeys := watchRowKeys(prev)
+	prevValues := watchBuildValueMap(prev)
+	prevIndex := make(map[string]int, len(prevKeys))
+	for i, k := range prevKeys {
+		prevIndex[k] = i
+	}
+
+	headers := curr[0]
+	var changes []watchChange
+	for r, key := range watchRowKeys(curr) {
+		p, ok := prevIndex[key]
+		if !ok {
+			continue
+		}
+		for c, cell := range curr[r+1] {
+			if c >= len(headers) {
+				break
+			}
+			was, hadPrev := prevValues[watchKey(p, headers[c])]
+			if !hadPrev || was == cell {
+				continue
+			}
+			_, errA := strconv.ParseFloat(was, 64)
+			_, errB := strconv.ParseFloat(cell, 64)
+			if errA == nil && errB == nil && watchCompare(cell, was) == 0 {
+				continue
+			}
+			changes = append(changes, watchChange{RowKey: key, Col: headers[c], From: was, To: cell})
+		}
+	}
+	return changes
+}
+
+// watchRowKeys returns a key per data row: the first watchRowKeyColumns
+// value present in the grid, or the row position.
+func watchRowKeys(rows [][]string) []string {
+	keyCol := -1
+	for _, name := range watchRowKeyColumns {
+		for c, h := range rows[0] {
+			if h == name {
+				keyCol = c
+				break
+			}
+		}
+		if keyCol >= 0 {
+			break
+		}
+	}
+	keys := make([]string, len(rows)-1)
+	for r, row := range rows[1:] {
+		if keyCol >= 0 && keyCol < len(row) && row[keyCol] != "" {
+			keys[r] = row[keyCol]
+		} else {
+			keys[r] = strconv.Itoa(r)
+		}
+	}
+	return keys
+}
+
+// recordChanges writes change events as NDJSON or CSV rows.
+func (r *watchRecorder) recordChanges(ts string, changes []watchChange) error {
+	if r.csv == nil {
+		for _, ch := range changes {
+			line, err := json.Marshal(struct {
+				TS     string      `json:"ts"`
+				RowKey string      `json:"row_key"`
+				Col    string      `json:"col"`
+				From   interface{} `json:"from"`
+				To     interface{} `json:"to"`
+			}{ts, ch.RowKey, ch.Col, watchRecordValue(ch.From), watchRecordValue(ch.To)})
+			if err != nil {
+				return err
+			}
+			if _, err := r.file.Write(append(line, '\n')); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if r.columns == nil {
+		r.columns = []string{"ts", "row_key", "col", "from", "to"}
+		if err := r.csv.Write(r.columns); err != nil {
+			return err
+		}
+	}
+	for _, ch := range changes {
+		if err := r.csv.Write([]string{ts, ch.RowKey, ch.Col, ch.From, ch.To}); err != nil {
+			return err
+		}
+	}
+	r.csv.Flush()
+	return r.csv.Error()
+}
+
+// Close flushes any buffered rows and closes the file.
+func (r *watchRecorder) Close() error {
+	if r.csv != nil {
+		r.csv.Flush()
+	}
+	return r.file.Close()
+}
+
+// readCSVHeader returns the first row of an existing, non-empty CSV file.
+func readCSVHeader(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return csv.NewReader(f).Read()
+}
+
+// readNDJSONKeys returns the field names of the first record in an NDJSON
+// file, or nil when there is none.
+func readNDJSONKeys(path string) []string {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	line, _ := bufio.NewReader(f).ReadBytes('\n')
+	var rec map[string]json.RawMessage
+	if json.Unmarshal(line, &rec) != nil {
+		return nil
+	}
+	keys := make([]string, 0, len(rec))
+	for k := range rec {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// watchRecordValue keeps numbers numeric in NDJSON output.
+func watchRecordValue(s string) interface{} {
+	if _, err := strconv.ParseFloat(s, 64); err == nil && json.Valid([]byte(s)) {
+		return json.Number(s)
+	}
+	return s
+}
+
 // ─── Watch helper functions ──────────────────────────────────────────────────
 
 // watchParseJSON parses raw API JSON into a 2D string grid (header + data).
@@ -749,7 +1446,7 @@ func watchTruncCols(widths []int, termWidth int) {
 		total := 0
 		for i, w := range widths {
 			if i > 0 {
-				total += 2
+				total += len(output.ColumnGap())
 			}
 			total += w
 		}
@@ -771,18 +1468,42 @@ func watchTruncCols(widths []int, termWidth int) {
 	}
 }
 
-// watchReadKeys reads single bytes from stdin in a goroutine.
-func watchReadKeys(ch chan<- byte) {
+// watchMakeRaw puts the terminal in raw mode for single-key input and
+// returns the state to restore, or nil if raw mode isn't available. Windows
+// consoles don't deliver single bytes reliably from a raw stdin, so they
+// always use the Ctrl+C-only fallback.
+func watchMakeRaw(fd int) *term.State {
+	if runtime.GOOS == "windows" || !term.IsTerminal(fd) {
+		return nil
+	}
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return nil
+	}
+	return state
+}
+
+// watchReadKeys reads single bytes from stdin in a goroutine. A quit key
+// also calls quit right away, since the loop doesn't read ch mid-fetch.
+func watchReadKeys(ch chan<- byte, quit func()) {
 	buf := make([]byte, 1)
 	for {
 		n, err := os.Stdin.Read(buf)
 		if err != nil || n == 0 {
 			return
 		}
+		if watchIsQuitKey(buf[0]) {
+			quit()
+		}
 		ch <- buf[0]
 	}
 }
 
+// watchIsQuitKey reports whether key stops watch: q, Q or Ctrl+C (3).
+func watchIsQuitKey(key byte) bool {
+	return key == 'q' || key == 'Q' || key == 3
+}
+
 // watchExit restores the screen before exiting watch mode.
 func watchExit() {
 	fmt.Print(wShowCursor)
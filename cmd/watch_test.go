@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestWatchTimeWindow checks watch builds the window the command's own
+// time flags ask for, as when the command runs on its own.
+func TestWatchTimeWindow(t *testing.T) {
+	for _, tc := range []struct {
+		flags []string
+		want  time.Duration
+	}{
+		{[]string{"--since", "2h"}, 2 * time.Hour},
+		{[]string{"-p", "24h"}, 24 * time.Hour},
+		{[]string{"--start", "2026-01-01T00:00:00Z", "--for", "3d"}, 72 * time.Hour},
+		{nil, 7 * 24 * time.Hour},
+	} {
+		args := append([]string{"perps", "carry", "BTC-PERPETUAL"}, tc.flags...)
+		_, params, err := resolveWatchCommand(args)
+		if err != nil {
+			t.Errorf("%v: %v", tc.flags, err)
+			continue
+		}
+		start, errS := time.Parse(time.RFC3339, params.Start)
+		end, errE := time.Parse(time.RFC3339, params.End)
+		if errS != nil || errE != nil {
+			t.Errorf("%v: start %q, end %q are not ISO 8601", tc.flags, params.Start, params.End)
+			continue
+		}
+		if w := end.Sub(start); w != tc.want {
+			t.Errorf("%v: window %s, want %s", tc.flags, w, tc.want)
+		}
+	}
+}
+
+func TestWatchValidatesTimeFlags(t *testing.T) {
+	for _, flags := range [][]string{
+		{"--since", "2h", "--start", "2026-01-01"},
+		{"-p", "soon"},
+		{"--for", "1d"},
+	} {
+		args := append([]string{"perps", "carry", "BTC-PERPETUAL"}, flags...)
+		if _, _, err := resolveWatchCommand(args); err == nil {
+			t.Errorf("%v: no error", flags)
+		} else if !strings.Contains(err.Error(), "--") {
+			t.Errorf("%v: error %q doesn't name the flag", flags, err)
+		}
+	}
+}
+
+// TestWatchEpochStart checks an epoch --start is sent as an ISO datetime
+// with an end filled in, not passed through raw.
+func TestWatchEpochStart(t *testing.T) {
+	_, params, err := resolveWatchCommand([]string{"perps", "carry", "BTC-PERPETUAL", "--start", "1709000000"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if params.Start != "2024-02-27T02:13:20Z" {
+		t.Errorf("start = %q, want 2024-02-27T02:13:20Z", params.Start)
+	}
+	if params.End != "2024-03-05T02:13:20Z" {
+		t.Errorf("end = %q, want the default 7d window after start", params.End)
+	}
+}
+
+// TestWatchGroupByIsTheAPIFlag checks only trades-summary's own --group-by
+// reaches the API; the client-side --aggregate-by never does.
+func TestWatchGroupByIsTheAPIFlag(t *testing.T) {
+	_, params, err := resolveWatchCommand([]string{"perps", "trades-summary", "--currency", "BTC", "--group-by", "direction"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if params.GroupBy != "direction" {
+		t.Errorf("group_by = %q, want direction", params.GroupBy)
+	}
+
+	_, params, err = resolveWatchCommand([]string{"perps", "carry", "BTC-PERPETUAL", "--aggregate-by", "exchange"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if params.GroupBy != "" {
+		t.Errorf("client-side --aggregate-by sent as group_by=%q", params.GroupBy)
+	}
+}
+
+// TestWatchRecorderModeSwitch appends to a record file in the other mode;
+// full rows and change events have different columns, so it is refused.
+func TestWatchRecorderModeSwitch(t *testing.T) {
+	refreshes := [][]byte{
+		[]byte(`[{"instrument_name":"BTC-PERPETUAL","mark_price":100}]`),
+		[]byte(`[{"instrument_name":"BTC-PERPETUAL","mark_price":101}]`),
+	}
+	record := func(path string, onlyChanged bool) error {
+		r, err := newWatchRecorder(path, onlyChanged)
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+		for _, data := range refreshes {
+			if err := r.Record(data, time.Now()); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return nil
+	}
+
+	for _, ext := range []string{".csv", ".ndjson"} {
+		for _, first := range []bool{false, true} {
+			path := filepath.Join(t.TempDir(), "log"+ext)
+			if err := record(path, first); err != nil {
+				t.Fatalf("%s only-changed=%v: %v", ext, first, err)
+			}
+			if err := record(path, first); err != nil {
+				t.Errorf("%s only-changed=%v: appending in the same mode: %v", ext, first, err)
+			}
+			if err := record(path, !first); err == nil {
+				t.Errorf("%s: appending only-changed=%v to an only-changed=%v file was allowed", ext, !first, first)
+			}
+		}
+	}
+}
@@ -1,7 +1,12 @@
 package api
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/rand"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -9,8 +14,10 @@ import (
 	"net/http/httputil"
 	"net/url"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/laevitas/cli/internal/config"
@@ -30,8 +37,13 @@ type RequestMeta struct {
 	Duration      time.Duration
 	PaymentMethod string // "api-key", "credit", "on-chain"
 	Credits       string // remaining credits (x402)
-	Retries       int    // number of 429 retries before success
-	ResponseSize  int    // response body size in bytes
+	Retries       int    // number of status/network retries before success
+	ResponseSize  int    // response body size in bytes (decompressed)
+	WireSize      int    // bytes received when gzip-encoded, 0 otherwise
+	// Timings are the phases of the final attempt (nil for batches)
+	Timings *Timings
+	// RequestID is the X-Request-ID sent with the final attempt
+	RequestID string
 }
 
 // Client is the LAEVITAS API client.
@@ -40,13 +52,76 @@ type Client struct {
 	apiKey     string
 	httpClient *http.Client
 	Verbose    bool
+	Quiet      bool // suppress retry notices
+	MaxRetries int  // retries on RetryOn statuses and transient network errors
+
+	// RetryOn are the HTTP statuses retried with backoff (default 429).
+	// Empty means no status is retried; network errors still are.
+	RetryOn []int
+
+	// UserAgent replaces the default laevitas-cli/<version> User-Agent.
+	UserAgent string
+
+	// Log receives request, retry and payment events (--log-file); nil disables.
+	Log *Logger
+
+	// Timeout is the deadline for each call, retries and x402 payment
+	// included (0 = none).
+	Timeout time.Duration
 
 	// x402 payment support
 	paymentClient *x402.PaymentClient
-	creditToken   string // cached JWT credit token
 
-	// LastMeta contains metadata from the most recent request.
-	LastMeta RequestMeta
+	mu          sync.Mutex  // guards creditToken and lastMeta
+	creditToken string      // cached JWT credit token
+	lastMeta    RequestMeta // metadata from the most recent request
+
+	// MaxPaymentUSD rejects x402 payments above this amount (0 = no cap).
+	MaxPaymentUSD float64
+	// ConfirmPayment, if set, is asked before each x402 payment is signed;
+	// returning false declines the payment.
+	ConfirmPayment func(x402.Payment) bool
+}
+
+// LastMeta returns the metadata of the most recent request. When requests
+// run concurrently, use GetWithMeta to get each call's own metadata.
+func (c *Client) LastMeta() RequestMeta {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lastMeta
+}
+
+// SetLastMeta replaces the metadata LastMeta reports, for callers that
+// combine several requests into one result.
+func (c *Client) SetLastMeta(meta RequestMeta) {
+	c.mu.Lock()
+	c.lastMeta = meta
+	c.mu.Unlock()
+}
+
+// token returns the cached x402 credit token.
+func (c *Client) token() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.creditToken
+}
+
+// setToken caches (or with "" drops) the x402 credit token.
+func (c *Client) setToken(token string) {
+	c.mu.Lock()
+	c.creditToken = token
+	c.mu.Unlock()
+}
+
+// clearToken drops the cached x402 credit token and reports whether there
+// was one. Checking and clearing happen under one lock, so when concurrent
+// requests get a 402 only one of them clears the token.
+func (c *Client) clearToken() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	had := c.creditToken != ""
+	c.creditToken = ""
+	return had
 }
 
 // HasWallet returns true if x402 wallet payment is configured.
@@ -54,6 +129,13 @@ func (c *Client) HasWallet() bool {
 	return c.paymentClient != nil
 }
 
+// MayPay reports whether the next request may make an on-chain x402
+// payment: a wallet is configured and there is neither an API key nor a
+// cached credit token to send instead.
+func (c *Client) MayPay() bool {
+	return c.paymentClient != nil && c.apiKey == "" && c.token() == ""
+}
+
 // NewClient creates a new API client from config.
 // The auth config field controls which auth method is used when both are set:
 //   - "auto" (default): API key if set, otherwise x402 wallet
@@ -77,11 +159,13 @@ func NewClient(cfg *config.Config) *Client {
 	}
 
 	c := &Client{
-		baseURL: strings.TrimRight(cfg.BaseURL, "/"),
-		apiKey:  apiKey,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		baseURL:    strings.TrimRight(cfg.BaseURL, "/"),
+		apiKey:     apiKey,
+		httpClient: &http.Client{},
+		MaxRetries: DefaultMaxRetries,
+		Timeout:    DefaultTimeout,
+		UserAgent:  cfg.UserAgent,
+		RetryOn:    RetryOnCodes(cfg.RetryOn),
 	}
 
 	// Initialize x402 payment client if wallet key is configured and not disabled
@@ -111,9 +195,13 @@ type APIError struct {
 	StatusCode int    `json:"status_code"`
 	Message    string `json:"message"`
 	Endpoint   string `json:"endpoint,omitempty"`
+	RequestID  string `json:"request_id,omitempty"` // X-Request-ID of the failed request
 }
 
 func (e *APIError) Error() string {
+	if e.RequestID != "" {
+		return fmt.Sprintf("API error %d: %s (request ID %s)", e.StatusCode, e.Message, e.RequestID)
+	}
 	return fmt.Sprintf("API error %d: %s", e.StatusCode, e.Message)
 }
 
@@ -140,12 +228,24 @@ func (e *NetworkError) Unwrap() error {
 	return e.Err
 }
 
+// TimeoutError is returned when a call runs past the client's Timeout.
+type TimeoutError struct {
+	After time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("Request timed out after %s. Retry with a longer --timeout.", e.After)
+}
+
 // Response wraps the raw API response with pagination info.
 type Response struct {
 	Data       json.RawMessage `json:"data"`
 	NextCursor string          `json:"next_cursor,omitempty"`
 }
 
+// MaxLimit is the largest page size (limit) the API accepts.
+const MaxLimit = 1000
+
 // RequestParams holds common query parameters.
 type RequestParams struct {
 	Exchange       string
@@ -154,7 +254,7 @@ type RequestParams struct {
 	Start          string
 	End            string
 	Resolution     string
-	Limit          int
+	Limit          int // 0 = server default; not sent
 	Cursor         string
 
 	// Options-specific
@@ -196,6 +296,19 @@ func (c *Client) buildURL(path string, params *RequestParams) string {
 		return u
 	}
 
+	if q := params.Values(); len(q) > 0 {
+		return u + "?" + q.Encode()
+	}
+	return u
+}
+
+// URL returns the full URL a request for path with params would use.
+func (c *Client) URL(path string, params *RequestParams) string {
+	return c.buildURL(path, params)
+}
+
+// Values returns the query parameters sent for p, keyed by API name.
+func (params *RequestParams) Values() url.Values {
 	q := url.Values{}
 
 	if params.Exchange != "" {
@@ -283,11 +396,7 @@ func (c *Client) buildURL(path string, params *RequestParams) string {
 	for k, v := range params.Extra {
 		q.Set(k, v)
 	}
-
-	if len(q) > 0 {
-		return u + "?" + q.Encode()
-	}
-	return u
+	return q
 }
 
 // isNetworkError checks if an error is a connectivity issue (DNS, TCP, timeout).
@@ -306,20 +415,155 @@ func isNetworkError(err error) bool {
 	return false
 }
 
-const maxRetries = 3
+// isRetryable reports whether a network error may clear up on its own
+// (reset connection, timeout, DNS blip). Invalid URLs, unsupported schemes
+// and TLS certificate failures fail the same way every time.
+func isRetryable(err error) bool {
+	// *url.Error is itself a net.Error, so judge what it wraps
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true // server or proxy dropped the connection
+	}
+	var addrErr *net.AddrError
+	if errors.As(err, &addrErr) {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// idempotent reports whether a request may be re-sent after a network
+// error. A POST whose connection dropped may already have been applied, so
+// only a status the server answered with (such as 429) retries it.
+func idempotent(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead
+}
+
+// shortNetErr strips the method and URL from a transport error for the
+// retry notice, e.g. "dial tcp: lookup api.laevitas.ch: no such host".
+func shortNetErr(err error) string {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err.Error()
+	}
+	return err.Error()
+}
+
+// DefaultTimeout is the per-call deadline unless a command or --timeout
+// sets another.
+const DefaultTimeout = 30 * time.Second
+
+// DefaultMaxRetries is how often a request is retried on a RetryOn status
+// or a transient network error before giving up.
+const DefaultMaxRetries = 3
+
+// RetryOnCodes parses a retry_on setting, falling back to config.DefaultRetryOn
+// when it is unset or invalid (config set rejects invalid values, so only a
+// hand-edited file gets here).
+func RetryOnCodes(spec string) []int {
+	if spec != "" {
+		if codes, err := config.ParseRetryOn(spec); err == nil {
+			return codes
+		}
+	}
+	codes, _ := config.ParseRetryOn(config.DefaultRetryOn)
+	return codes
+}
 
 // Do performs an authenticated API request and returns the raw body.
-// It automatically retries on 429 with exponential backoff and wraps
-// network errors with user-friendly messages.
-func (c *Client) Do(method, path string, params *RequestParams) ([]byte, error) {
+// It automatically retries on the RetryOn statuses (429 by default) with
+// exponential backoff and wraps
+// network errors with user-friendly messages. Cancelling ctx aborts the
+// request (and any backoff wait) and returns ctx.Err().
+func (c *Client) Do(ctx context.Context, method, path string, params *RequestParams) ([]byte, error) {
+	return c.do(ctx, method, path, params, nil)
+}
+
+// newRequest builds a request, attaching body as JSON when non-nil.
+func newRequest(ctx context.Context, method, url string, body []byte) (*http.Request, error) {
+	if body == nil {
+		return http.NewRequestWithContext(ctx, method, url, nil)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return req, nil
+}
+
+// setHeaders sets the headers every request carries and returns the
+// X-Request-ID generated for it, which the API logs for correlation.
+func (c *Client) setHeaders(req *http.Request) string {
+	ua := c.UserAgent
+	if ua == "" {
+		ua = fmt.Sprintf("laevitas-cli/%s (+https://github.com/laevitas/cli)", version.Version)
+	}
+	id := newRequestID()
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Accept-Encoding", "gzip")
+	req.Header.Set("User-Agent", ua)
+	req.Header.Set("X-Request-ID", id)
+	return id
+}
+
+// newRequestID returns a random (version 4) UUID.
+func newRequestID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// do is Do with an optional JSON request body, which is re-sent on every
+// retry and on the x402 payment retry. It records the call in LastMeta.
+func (c *Client) do(ctx context.Context, method, path string, params *RequestParams, reqBody []byte) ([]byte, error) {
+	var meta RequestMeta
+	body, err := c.send(ctx, method, path, params, reqBody, &meta)
+	c.SetLastMeta(meta)
+	return body, err
+}
+
+// send performs the request, filling in meta for this call only.
+func (c *Client) send(ctx context.Context, method, path string, params *RequestParams, reqBody []byte, meta *RequestMeta) (body []byte, err error) {
+	// Registered first so it runs last, after the timeout error rewrite
+	var status, retries int
+	if c.Log != nil {
+		logStart := time.Now()
+		defer func() {
+			c.logRequest(method, path, logStart, status, retries, meta.RequestID, len(body), err)
+		}()
+	}
+
+	// The x402 retry gets its own deadline once the payment is approved,
+	// so time spent at the confirmation prompt doesn't count
+	parent := ctx
+	if c.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.Timeout)
+		defer cancel()
+		defer func() {
+			if errors.Is(err, context.DeadlineExceeded) && parent.Err() == nil {
+				body, err = nil, &TimeoutError{After: c.Timeout}
+			}
+		}()
+	}
+
 	fullURL := c.buildURL(path, params)
-	c.LastMeta = RequestMeta{} // reset for each call
 	startTime := time.Now()
 
 	usedCredit := false
 
-	for attempt := 0; attempt <= maxRetries; attempt++ {
-		req, err := http.NewRequest(method, fullURL, nil)
+	for attempt := 0; attempt <= c.MaxRetries; attempt++ {
+		retries = attempt
+		req, err := newRequest(ctx, method, fullURL, reqBody)
 		if err != nil {
 			return nil, fmt.Errorf("creating request: %w", err)
 		}
@@ -329,12 +573,13 @@ func (c *Client) Do(method, path string, params *RequestParams) ([]byte, error)
 			req.Header.Set("apiKey", c.apiKey)
 		}
 		// Send cached x402 credit token if available (and no API key)
-		if c.apiKey == "" && c.creditToken != "" {
-			req.Header.Set("X-Credit-Token", c.creditToken)
+		token := c.token()
+		if c.apiKey == "" && token != "" {
+			req.Header.Set("X-Credit-Token", token)
 			usedCredit = true
 		}
-		req.Header.Set("Accept", "application/json")
-		req.Header.Set("User-Agent", fmt.Sprintf("laevitas-cli/%s (+https://github.com/laevitas/cli)", version.Version))
+		requestID := c.setHeaders(req)
+		meta.RequestID = requestID
 
 		if c.Verbose {
 			dump, _ := httputil.DumpRequestOut(req, false)
@@ -343,23 +588,45 @@ func (c *Client) Do(method, path string, params *RequestParams) ([]byte, error)
 			if c.apiKey != "" {
 				dumpStr = strings.Replace(dumpStr, c.apiKey, config.MaskKey(c.apiKey), -1)
 			}
-			if c.creditToken != "" {
-				dumpStr = strings.Replace(dumpStr, c.creditToken, config.MaskKey(c.creditToken), -1)
+			if token != "" {
+				dumpStr = strings.Replace(dumpStr, token, config.MaskKey(token), -1)
 			}
 			fmt.Fprintf(os.Stderr, "\n--- REQUEST ---\n%s", dumpStr)
 		}
 
+		timings := &Timings{}
+		req, reqStart := traceRequest(req, timings)
 		resp, err := c.httpClient.Do(req)
 		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			if isRetryable(err) && idempotent(method) && attempt < c.MaxRetries {
+				if err := c.waitRetry(ctx, path, attempt, err, backoff(attempt)); err != nil {
+					return nil, err
+				}
+				continue
+			}
 			if isNetworkError(err) {
 				return nil, &NetworkError{Err: err}
 			}
 			return nil, fmt.Errorf("request failed: %w", err)
 		}
 
-		body, err := io.ReadAll(resp.Body)
-		resp.Body.Close()
+		status = resp.StatusCode
+		body, wireSize, err := readBody(resp)
+		timings.Total = time.Since(reqStart)
 		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			// Connection dropped mid-body
+			if isRetryable(err) && idempotent(method) && attempt < c.MaxRetries {
+				if err := c.waitRetry(ctx, path, attempt, err, backoff(attempt)); err != nil {
+					return nil, err
+				}
+				continue
+			}
 			return nil, fmt.Errorf("reading response: %w", err)
 		}
 
@@ -380,25 +647,27 @@ func (c *Client) Do(method, path string, params *RequestParams) ([]byte, error)
 		}
 
 		// Cache credit token and credits remaining from any response
-		c.extractCreditHeaders(resp)
+		c.extractCreditHeaders(resp, meta)
 
 		if resp.StatusCode == http.StatusOK {
 			// Track request metadata
-			c.LastMeta.Duration = time.Since(startTime)
-			c.LastMeta.ResponseSize = len(body)
-			c.LastMeta.Retries = attempt
+			meta.Duration = time.Since(startTime)
+			meta.ResponseSize = len(body)
+			meta.WireSize = wireSize
+			meta.Retries = attempt
+			meta.Timings = timings
 			if c.apiKey != "" {
-				c.LastMeta.PaymentMethod = PaymentMethodAPIKey
+				meta.PaymentMethod = PaymentMethodAPIKey
 			} else if usedCredit {
-				c.LastMeta.PaymentMethod = PaymentMethodCredit
+				meta.PaymentMethod = PaymentMethodCredit
 			}
 			return body, nil
 		}
 
 		// 402: Payment Required — try x402 payment
 		if resp.StatusCode == http.StatusPaymentRequired {
-			result, err := c.handlePaymentRequired(method, fullURL, resp, body, path)
-			c.LastMeta.Duration = time.Since(startTime)
+			result, err := c.handlePaymentRequired(parent, method, fullURL, reqBody, resp, body, path, meta)
+			meta.Duration = time.Since(startTime)
 			return result, err
 		}
 
@@ -406,25 +675,37 @@ func (c *Client) Do(method, path string, params *RequestParams) ([]byte, error)
 			StatusCode: resp.StatusCode,
 			Message:    string(body),
 			Endpoint:   path,
+			RequestID:  requestID,
 		}
 
 		// 401/403: auth error
 		if apiErr.IsAuthError() {
 			// If wallet is configured (no API key), treat 401 as 402 — trigger x402 payment
 			if c.apiKey == "" && c.paymentClient != nil {
-				result, err := c.handlePaymentRequired(method, fullURL, resp, body, path)
-				c.LastMeta.Duration = time.Since(startTime)
+				result, err := c.handlePaymentRequired(parent, method, fullURL, reqBody, resp, body, path, meta)
+				meta.Duration = time.Since(startTime)
 				return result, err
 			}
 			apiErr.Message = "API key invalid or expired. Run `laevitas config init` to update."
 			return nil, apiErr
 		}
 
-		// 429: rate limited — retry with backoff
-		if apiErr.IsRateLimit() && attempt < maxRetries {
+		// RetryOn statuses (429 unless configured): retry with backoff
+		if slices.Contains(c.RetryOn, apiErr.StatusCode) && attempt < c.MaxRetries {
 			wait := retryDelay(resp, attempt)
-			fmt.Fprintf(os.Stderr, "\033[33m⚠ Rate limited. Retrying in %s...\033[0m\n", wait.Round(time.Second))
-			time.Sleep(wait)
+			notice := "Rate limited"
+			if !apiErr.IsRateLimit() {
+				notice = fmt.Sprintf("Server returned %d", apiErr.StatusCode)
+			}
+			if !c.Quiet {
+				fmt.Fprintf(os.Stderr, "\033[33m⚠ %s. Retrying in %s...\033[0m\n", notice, wait.Round(time.Second))
+			}
+			c.logRetry(path, attempt, wait, strings.ToLower(notice))
+			select {
+			case <-time.After(wait):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
 			continue
 		}
 
@@ -438,22 +719,50 @@ func (c *Client) Do(method, path string, params *RequestParams) ([]byte, error)
 	}
 }
 
+// readBody reads and closes resp.Body. Since Do sets Accept-Encoding itself,
+// the transport no longer decompresses transparently, so gzip bodies are
+// inflated here. wireSize is the compressed size, or 0 if not compressed.
+func readBody(resp *http.Response) (body []byte, wireSize int, err error) {
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return raw, 0, nil
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(raw))
+	if err != nil {
+		return nil, 0, fmt.Errorf("decompressing response: %w", err)
+	}
+	defer zr.Close()
+	body, err = io.ReadAll(zr)
+	if err != nil {
+		return nil, 0, fmt.Errorf("decompressing response: %w", err)
+	}
+	return body, len(raw), nil
+}
+
 // extractCreditHeaders caches x402 credit token and remaining credits from response.
-func (c *Client) extractCreditHeaders(resp *http.Response) {
+func (c *Client) extractCreditHeaders(resp *http.Response, meta *RequestMeta) {
 	if token := resp.Header.Get("X-Credit-Token"); token != "" {
-		c.creditToken = token
+		c.setToken(token)
 		_ = config.SaveCreditToken(token)
 	}
 	if remaining := resp.Header.Get("X-Credits-Remaining"); remaining != "" {
-		c.LastMeta.Credits = remaining
+		meta.Credits = remaining
+		_ = config.SaveCreditsSeen(remaining)
 	}
 }
 
 // handlePaymentRequired processes a 402 response by signing an x402 payment and retrying.
-func (c *Client) handlePaymentRequired(method, fullURL string, resp *http.Response, body []byte, path string) ([]byte, error) {
+// ctx carries no per-call deadline: the --timeout of the paid retry starts
+// after the payment is approved.
+func (c *Client) handlePaymentRequired(ctx context.Context, method, fullURL string, reqBody []byte, resp *http.Response, body []byte, path string, meta *RequestMeta) ([]byte, error) {
 	// If we sent a credit token that was rejected, clear it
-	if c.creditToken != "" {
-		c.creditToken = ""
+	if c.clearToken() {
 		config.ClearCreditToken()
 	}
 
@@ -473,7 +782,15 @@ func (c *Client) handlePaymentRequired(method, fullURL string, resp *http.Respon
 		fmt.Fprintf(os.Stderr, "\n--- x402: Signing payment with wallet %s ---\n", walletAddr)
 	}
 
-	paymentHeaders, err := c.paymentClient.HandlePaymentRequired(resp, body)
+	var payment x402.Payment
+	paymentHeaders, err := c.paymentClient.HandlePaymentRequired(resp, body, func(p x402.Payment) error {
+		payment = p
+		return c.approvePayment(p, path)
+	})
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return nil, apiErr
+	}
 	if err != nil {
 		// Provide specific guidance based on the failure
 		msg := fmt.Sprintf("x402 payment signing failed: %s\n", err)
@@ -487,8 +804,14 @@ func (c *Client) handlePaymentRequired(method, fullURL string, resp *http.Respon
 		}
 	}
 
+	if c.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.Timeout)
+		defer cancel()
+	}
+
 	// Retry request with payment signature
-	retryReq, err := http.NewRequest(method, fullURL, nil)
+	retryReq, err := newRequest(ctx, method, fullURL, reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("creating retry request: %w", err)
 	}
@@ -496,8 +819,7 @@ func (c *Client) handlePaymentRequired(method, fullURL string, resp *http.Respon
 	if c.apiKey != "" {
 		retryReq.Header.Set("apiKey", c.apiKey)
 	}
-	retryReq.Header.Set("Accept", "application/json")
-	retryReq.Header.Set("User-Agent", fmt.Sprintf("laevitas-cli/%s (+https://github.com/laevitas/cli)", version.Version))
+	requestID := c.setHeaders(retryReq)
 
 	// Add payment signature headers
 	for k, v := range paymentHeaders {
@@ -516,14 +838,16 @@ func (c *Client) handlePaymentRequired(method, fullURL string, resp *http.Respon
 
 	retryResp, err := c.httpClient.Do(retryReq)
 	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
 		if isNetworkError(err) {
 			return nil, &NetworkError{Err: err}
 		}
 		return nil, fmt.Errorf("x402 retry failed: %w", err)
 	}
 
-	retryBody, err := io.ReadAll(retryResp.Body)
-	retryResp.Body.Close()
+	retryBody, retryWireSize, err := readBody(retryResp)
 	if err != nil {
 		return nil, fmt.Errorf("reading x402 retry response: %w", err)
 	}
@@ -538,11 +862,14 @@ func (c *Client) handlePaymentRequired(method, fullURL string, resp *http.Respon
 	}
 
 	// Cache credit token from retry response
-	c.extractCreditHeaders(retryResp)
+	c.extractCreditHeaders(retryResp, meta)
 
 	if retryResp.StatusCode == http.StatusOK {
-		c.LastMeta.PaymentMethod = PaymentMethodOnChain
-		c.LastMeta.ResponseSize = len(retryBody)
+		meta.PaymentMethod = PaymentMethodOnChain
+		meta.RequestID = requestID
+		meta.ResponseSize = len(retryBody)
+		meta.WireSize = retryWireSize
+		c.logPayment(payment, path, walletAddr, retryResp)
 		return retryBody, nil
 	}
 
@@ -552,9 +879,63 @@ func (c *Client) handlePaymentRequired(method, fullURL string, resp *http.Respon
 		StatusCode: retryResp.StatusCode,
 		Message:    msg,
 		Endpoint:   path,
+		RequestID:  requestID,
 	}
 }
 
+// logPayment appends a successful on-chain payment to payments.log. A
+// failure to write is only a warning: the data has been paid for already.
+func (c *Client) logPayment(p x402.Payment, path, walletAddr string, resp *http.Response) {
+	rec := config.PaymentRecord{
+		Time:     time.Now().UTC(),
+		Endpoint: path,
+		Wallet:   walletAddr,
+		Network:  p.Network,
+		Tx:       x402.SettlementTx(resp.Header),
+	}
+	if p.Amount != nil {
+		rec.AmountUSDC = x402.FormatUSDC(p.Amount)
+	}
+	if token := resp.Header.Get("X-Credit-Token"); len(token) > 10 {
+		rec.CreditToken = token[:10] + "..."
+	}
+	c.Log.Log(LogEntry{
+		Event:      "payment",
+		Endpoint:   path,
+		Status:     resp.StatusCode,
+		AmountUSDC: rec.AmountUSDC,
+		Tx:         rec.Tx,
+	})
+	if err := config.AppendPayment(rec); err != nil && !c.Quiet {
+		fmt.Fprintf(os.Stderr, "\033[33m⚠ Could not record payment in payments.log: %s\033[0m\n", err)
+	}
+}
+
+// approvePayment enforces MaxPaymentUSD and asks ConfirmPayment before an
+// x402 payment is signed. A refusal is reported as a 402 APIError.
+func (c *Client) approvePayment(p x402.Payment, path string) error {
+	resource := p.Resource
+	if resource == "" {
+		resource = path
+	}
+	if c.MaxPaymentUSD > 0 && p.USD() > c.MaxPaymentUSD {
+		return &APIError{
+			StatusCode: http.StatusPaymentRequired,
+			Message: fmt.Sprintf("x402 payment of %s USDC for %s exceeds max_payment_usd (%s). Raise the cap with `laevitas config set max_payment_usd <amount>`.",
+				x402.FormatUSDC(p.Amount), resource, strconv.FormatFloat(c.MaxPaymentUSD, 'f', -1, 64)),
+			Endpoint: path,
+		}
+	}
+	if c.ConfirmPayment != nil && !c.ConfirmPayment(p) {
+		return &APIError{
+			StatusCode: http.StatusPaymentRequired,
+			Message:    fmt.Sprintf("x402 payment of %s USDC for %s declined (--yes pays without asking).", x402.FormatUSDC(p.Amount), resource),
+			Endpoint:   path,
+		}
+	}
+	return nil
+}
+
 // buildPaymentErrorMessage creates a user-friendly error for rejected x402 payments.
 func (c *Client) buildPaymentErrorMessage(statusCode int, body []byte, walletAddr string) string {
 	// Try to extract error details from response body
@@ -601,7 +982,7 @@ func (c *Client) buildPaymentErrorMessage(statusCode int, body []byte, walletAdd
 	}
 }
 
-// retryDelay calculates how long to wait before retrying a 429.
+// retryDelay calculates how long to wait before retrying a RetryOn status.
 // Uses Retry-After header if present, otherwise exponential backoff.
 func retryDelay(resp *http.Response, attempt int) time.Duration {
 	if ra := resp.Header.Get("Retry-After"); ra != "" {
@@ -609,10 +990,73 @@ func retryDelay(resp *http.Response, attempt int) time.Duration {
 			return time.Duration(secs) * time.Second
 		}
 	}
-	// Exponential backoff: 2s, 4s, 8s
+	return backoff(attempt)
+}
+
+// backoff is the exponential retry delay: 2s, 4s, 8s, ... capped at 30s.
+func backoff(attempt int) time.Duration {
+	if attempt >= 4 {
+		return 30 * time.Second
+	}
 	return time.Duration(1<<uint(attempt+1)) * time.Second
 }
 
+// waitRetry announces a retry after a network error and sleeps for wait,
+// returning early with ctx's error if it is cancelled.
+func (c *Client) waitRetry(ctx context.Context, path string, attempt int, err error, wait time.Duration) error {
+	if !c.Quiet {
+		fmt.Fprintf(os.Stderr, "\033[33m⚠ Network error (%s). Retrying in %s...\033[0m\n", shortNetErr(err), wait.Round(time.Second))
+	}
+	c.logRetry(path, attempt, wait, shortNetErr(err))
+	select {
+	case <-time.After(wait):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// logRequest records the outcome of one send call in the --log-file.
+func (c *Client) logRequest(method, path string, start time.Time, status, retries int, requestID string, size int, err error) {
+	e := LogEntry{
+		Event:      "request",
+		Method:     method,
+		Endpoint:   path,
+		Status:     status,
+		DurationMS: time.Since(start).Milliseconds(),
+		Retries:    retries,
+		RequestID:  requestID,
+		Bytes:      size,
+	}
+	if err != nil {
+		e.Level = LogError
+		e.Error = err.Error()
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			e.Status = apiErr.StatusCode
+			e.Error = apiErr.Message
+			if apiErr.RequestID != "" {
+				e.RequestID = apiErr.RequestID
+			}
+		}
+	} else {
+		e.Status = http.StatusOK
+	}
+	c.Log.Log(e)
+}
+
+// logRetry records a retry about to happen after wait.
+func (c *Client) logRetry(path string, attempt int, wait time.Duration, reason string) {
+	c.Log.Log(LogEntry{
+		Level:      LogWarn,
+		Event:      "retry",
+		Endpoint:   path,
+		DurationMS: wait.Milliseconds(),
+		Retries:    attempt + 1,
+		Error:      reason,
+	})
+}
+
 // APIResponse is the standard V2 API response wrapper.
 type APIResponse struct {
 	Data       json.RawMessage `json:"data"`
@@ -627,13 +1071,33 @@ type ResponseMeta struct {
 }
 
 // Get is a convenience wrapper for GET requests.
-func (c *Client) Get(path string, params *RequestParams) ([]byte, error) {
-	return c.Do(http.MethodGet, path, params)
+func (c *Client) Get(ctx context.Context, path string, params *RequestParams) ([]byte, error) {
+	return c.Do(ctx, http.MethodGet, path, params)
+}
+
+// GetWithMeta is Get returning the request's metadata. Unlike LastMeta,
+// the metadata belongs to this call alone, so concurrent callers each get
+// their own. It leaves LastMeta untouched.
+func (c *Client) GetWithMeta(ctx context.Context, path string, params *RequestParams) ([]byte, RequestMeta, error) {
+	var meta RequestMeta
+	body, err := c.send(ctx, http.MethodGet, path, params, nil, &meta)
+	return body, meta, err
+}
+
+// Post sends body as JSON with the given query params. It goes through the
+// same auth, 429 retry and x402 payment handling as Get, but is not re-sent
+// after a network error.
+func (c *Client) Post(ctx context.Context, path string, body interface{}, params *RequestParams) ([]byte, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("encoding request body: %w", err)
+	}
+	return c.do(ctx, http.MethodPost, path, params, data)
 }
 
 // GetJSON performs a GET and unmarshals into the provided target.
-func (c *Client) GetJSON(path string, params *RequestParams, target interface{}) error {
-	body, err := c.Get(path, params)
+func (c *Client) GetJSON(ctx context.Context, path string, params *RequestParams, target interface{}) error {
+	body, err := c.Get(ctx, path, params)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/laevitas/cli/internal/config"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return NewClient(&config.Config{Settings: config.Settings{BaseURL: srv.URL}})
+}
+
+// TestConcurrentGet runs Get and GetWithMeta from many goroutines on one
+// client (as batch fetches do); run with -race. Each GetWithMeta must get
+// its own request's metadata.
+func TestConcurrentGet(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Credits-Remaining", "100")
+		json.NewEncoder(w).Encode(map[string]string{
+			"path": r.URL.Path,
+			"id":   r.Header.Get("X-Request-ID"),
+		})
+	})
+
+	const n = 32
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			path := fmt.Sprintf("/api/v1/test/%d", i)
+			if i%2 == 0 {
+				if _, err := c.Get(context.Background(), path, nil); err != nil {
+					errs <- err
+				}
+				_ = c.LastMeta()
+				return
+			}
+			body, meta, err := c.GetWithMeta(context.Background(), path, nil)
+			if err != nil {
+				errs <- err
+				return
+			}
+			var got map[string]string
+			if err := json.Unmarshal(body, &got); err != nil {
+				errs <- err
+				return
+			}
+			if got["path"] != path {
+				errs <- fmt.Errorf("%s: got body for %s", path, got["path"])
+			}
+			if meta.RequestID != got["id"] {
+				errs <- fmt.Errorf("%s: meta request ID %q, server saw %q", path, meta.RequestID, got["id"])
+			}
+			if meta.Credits != "100" {
+				errs <- fmt.Errorf("%s: meta credits %q, want 100", path, meta.Credits)
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+// TestConcurrentPaymentRequired sends a rejected credit token from many
+// goroutines at once; the token is dropped and every call reports the 402.
+func TestConcurrentPaymentRequired(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusPaymentRequired)
+	})
+	c.creditToken = "expired-token"
+
+	const n = 16
+	var wg sync.WaitGroup
+	errs := make([]error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, _, errs[i] = c.GetWithMeta(context.Background(), "/api/v1/test", nil)
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusPaymentRequired {
+			t.Errorf("call %d: got %v, want a 402 APIError", i, err)
+		}
+	}
+	if tok := c.token(); tok != "" {
+		t.Errorf("credit token %q still cached after 402", tok)
+	}
+}
+
+// TestPostNotRetriedOnDroppedConnection drops the connection before any
+// response; a POST may already have been applied, so it is sent once.
+func TestPostNotRetriedOnDroppedConnection(t *testing.T) {
+	var mu sync.Mutex
+	hits := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		hits++
+		mu.Unlock()
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		conn.Close()
+	})
+
+	if _, err := c.Post(context.Background(), "/api/v1/test", map[string]int{"n": 1}, nil); err == nil {
+		t.Fatal("Post succeeded on a dropped connection")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if hits != 1 {
+		t.Errorf("POST sent %d times, want 1", hits)
+	}
+}
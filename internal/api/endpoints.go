@@ -64,6 +64,7 @@ const (
 	OptionsVolatility     = "/api/v1/options/volatility"
 	OptionsMetadata       = "/api/v1/options/metadata"
 	OptionsTradesSummary  = "/api/v1/options/trades/summary"
+	OptionsLiquidations   = "/api/v1/options/liquidations"
 )
 
 // ─── Volatility Surface (under /options/) ───────────────────────────────
@@ -85,4 +86,5 @@ const (
 	PredictionsTickerHistory = "/api/v1/predictions/ticker-history"
 	PredictionsOrderbookRaw  = "/api/v1/predictions/orderbook-raw"
 	PredictionsMetadata      = "/api/v1/predictions/metadata"
+	PredictionsFlow          = "/api/v1/predictions/flow"
 )
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+)
+
+// Log levels used in LogEntry.Level.
+const (
+	LogInfo  = "info"
+	LogWarn  = "warn"
+	LogError = "error"
+)
+
+// LogEntry is one line of the --log-file. Fields that don't apply to an
+// event are left out.
+type LogEntry struct {
+	Time       time.Time `json:"time"`
+	Level      string    `json:"level"`
+	Event      string    `json:"event"` // request, retry, payment, command
+	Command    string    `json:"command,omitempty"`
+	Method     string    `json:"method,omitempty"`
+	Endpoint   string    `json:"endpoint,omitempty"`
+	Status     int       `json:"status,omitempty"`
+	DurationMS int64     `json:"duration_ms"` // request time, or the wait before a retry
+	Retries    int       `json:"retries"`
+	RequestID  string    `json:"request_id,omitempty"`
+	Bytes      int       `json:"bytes,omitempty"`
+	AmountUSDC string    `json:"amount_usdc,omitempty"`
+	Tx         string    `json:"tx,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// Logger appends LogEntry values to a file as JSON lines, for automated
+// deployments that want a machine-readable record next to the human
+// stderr output. A nil *Logger discards everything.
+type Logger struct {
+	Path string
+
+	mu sync.Mutex
+	f  *os.File
+}
+
+// OpenLog opens (or creates) path for appending.
+func OpenLog(path string) (*Logger, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &Logger{Path: path, f: f}, nil
+}
+
+// Log writes e as one line, stamping the time if it isn't set. Write
+// errors are ignored: logging must never fail a request.
+func (l *Logger) Log(e LogEntry) {
+	if l == nil {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+	if e.Level == "" {
+		e.Level = LogInfo
+	}
+	line, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.f.Write(append(line, '\n'))
+}
+
+// Close closes the underlying file.
+func (l *Logger) Close() error {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.f.Close()
+}
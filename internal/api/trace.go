@@ -0,0 +1,45 @@
+package api
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"time"
+)
+
+// Timings breaks one HTTP request down into phases (--timings). DNS,
+// Connect and TLS are zero when a pooled connection was reused.
+type Timings struct {
+	DNS       time.Duration
+	Connect   time.Duration
+	TLS       time.Duration
+	FirstByte time.Duration // request start to first response byte
+	Total     time.Duration // request start to body fully read
+	Reused    bool          // connection came from the pool
+}
+
+// traceRequest returns req with a ClientTrace recording into t, and the
+// time the request starts. The caller sets t.Total once the body is read.
+func traceRequest(req *http.Request, t *Timings) (*http.Request, time.Time) {
+	start := time.Now()
+	var dnsStart, connStart, tlsStart time.Time
+	trace := &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) { dnsStart = time.Now() },
+		DNSDone:  func(httptrace.DNSDoneInfo) { t.DNS = time.Since(dnsStart) },
+		ConnectStart: func(network, addr string) {
+			connStart = time.Now()
+		},
+		ConnectDone: func(network, addr string, err error) {
+			t.Connect = time.Since(connStart)
+		},
+		TLSHandshakeStart: func() { tlsStart = time.Now() },
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			t.TLS = time.Since(tlsStart)
+		},
+		GotConn: func(info httptrace.GotConnInfo) { t.Reused = info.Reused },
+		GotFirstResponseByte: func() {
+			t.FirstByte = time.Since(start)
+		},
+	}
+	return req.WithContext(httptrace.WithClientTrace(req.Context(), trace)), start
+}
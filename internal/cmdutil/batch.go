@@ -0,0 +1,251 @@
+package cmdutil
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/laevitas/cli/internal/api"
+	"github.com/laevitas/cli/internal/output"
+)
+
+// InstrumentsFile runs an instrument command once per instrument listed in
+// this file ("-" = stdin) and merges the results (--instruments-file).
+var InstrumentsFile string
+
+// InstrumentArgs validates the <instrument> argument of instrument
+// commands: exactly one, or none when --instruments-file supplies them.
+func InstrumentArgs(cmd *cobra.Command, args []string) error {
+	if f, _ := cmd.Flags().GetString("instruments-file"); f != "" {
+		if len(args) > 0 {
+			return fmt.Errorf("give either <instrument> or --instruments-file, not both")
+		}
+		return nil
+	}
+	return cobra.ExactArgs(1)(cmd, args)
+}
+
+// InstrumentArg returns the <instrument> argument, or "" when the
+// instruments come from --instruments-file.
+func InstrumentArg(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	return args[0]
+}
+
+// readInstruments reads instrument names from path ("-" = stdin): one or
+// more per line, separated by spaces or commas. Blank lines and lines
+// starting with # are skipped.
+func readInstruments(path string) ([]string, error) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var names []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		names = append(names, strings.FieldsFunc(line, func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t'
+		})...)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no instruments in %s", path)
+	}
+	return names, nil
+}
+
+// Concurrency is how many batch requests run at once (--concurrency).
+var Concurrency = DefaultConcurrency
+
+const (
+	// DefaultConcurrency is the --concurrency default.
+	DefaultConcurrency = 4
+	// MaxConcurrency caps --concurrency so a batch doesn't trip rate limits.
+	MaxConcurrency = 8
+)
+
+// fetchBatch queries endpoint once per instrument in InstrumentsFile and
+// merges the results (see fetchEach).
+func fetchBatch(ctx context.Context, client *api.Client, endpoint string, params *api.RequestParams) ([]byte, error) {
+	names, err := readInstruments(InstrumentsFile)
+	if err != nil {
+		return nil, fmt.Errorf("--instruments-file: %w", err)
+	}
+	return fetchEach(ctx, client, endpoint, params, names, "instrument_name", "instruments", func(p *api.RequestParams, name string) {
+		p.InstrumentName = name
+	})
+}
+
+// splitCurrencies splits a --currency list such as "BTC,ETH". It returns
+// nil for a single currency.
+func splitCurrencies(s string) []string {
+	if !strings.Contains(s, ",") {
+		return nil
+	}
+	var out []string
+	for _, c := range strings.Split(s, ",") {
+		if c = strings.TrimSpace(c); c != "" {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// fetchCurrencies queries endpoint once per currency in the comma-separated
+// params.Currency and merges the results (see fetchEach).
+func fetchCurrencies(ctx context.Context, client *api.Client, endpoint string, params *api.RequestParams) ([]byte, error) {
+	return fetchEach(ctx, client, endpoint, params, splitCurrencies(params.Currency), "currency", "currencies", func(p *api.RequestParams, name string) {
+		p.Currency = name
+	})
+}
+
+// fetchEach queries endpoint once per name, with set applying the name to
+// a copy of params, running up to Concurrency requests at a time on the
+// shared client. The rows are merged in names order into one
+// {"data": [...]} response; rows without a keyColumn field get the name
+// they were fetched for. A failing name is reported and skipped; the batch
+// only fails if every one does. LastMeta is set to the batch totals.
+//
+// When the first request may pay with the x402 wallet, it runs alone so
+// the rest reuse the credit token it returns instead of each paying.
+func fetchEach(ctx context.Context, client *api.Client, endpoint string, params *api.RequestParams, names []string, keyColumn, noun string, set func(*api.RequestParams, string)) ([]byte, error) {
+	type result struct {
+		data []byte
+		meta api.RequestMeta
+		err  error
+	}
+	results := make([]result, len(names))
+	start := time.Now()
+	fetch := func(i int) {
+		p := *params
+		set(&p, names[i])
+		data, meta, err := client.GetWithMeta(ctx, endpoint, &p)
+		results[i] = result{data, meta, err}
+	}
+
+	first := 0
+	if client.MayPay() {
+		fetch(0)
+		first = 1
+	}
+
+	workers := min(max(Concurrency, 1), len(names)-first)
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				fetch(i)
+			}
+		}()
+	}
+	for i := first; i < len(names); i++ {
+		if ctx.Err() != nil {
+			break
+		}
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var merged []interface{}
+	var lastErr error
+	total := api.RequestMeta{Duration: time.Since(start)}
+	failed := 0
+	for i, r := range results {
+		name := names[i]
+		total.ResponseSize += r.meta.ResponseSize
+		total.WireSize += r.meta.WireSize
+		total.Retries += r.meta.Retries
+		if r.meta.PaymentMethod != "" {
+			total.PaymentMethod = r.meta.PaymentMethod
+		}
+		if r.meta.Credits != "" {
+			total.Credits = r.meta.Credits
+		}
+
+		err := r.err
+		var rows []interface{}
+		if err == nil {
+			rows, err = ResponseRows(r.data)
+		}
+		if err != nil {
+			output.Warnf("%s: %s", name, err)
+			lastErr = err
+			failed++
+			continue
+		}
+		for _, row := range rows {
+			if m, ok := row.(map[string]interface{}); ok {
+				if _, has := m[keyColumn]; !has {
+					m[keyColumn] = name
+				}
+			}
+			merged = append(merged, row)
+		}
+	}
+	client.SetLastMeta(total)
+
+	if failed == len(names) {
+		return nil, lastErr
+	}
+	if failed > 0 {
+		output.Warnf("%d of %d %s failed", failed, len(names), noun)
+	}
+	if merged == nil {
+		merged = []interface{}{}
+	}
+	return json.Marshal(struct {
+		Data  []interface{} `json:"data"`
+		Count int           `json:"count"`
+	}{merged, len(merged)})
+}
+
+// ResponseRows extracts the records from an API response: either a bare
+// array or an object whose "data" field is one.
+func ResponseRows(data []byte) ([]interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var parsed interface{}
+	if err := dec.Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("parsing response: %w", err)
+	}
+	switch v := parsed.(type) {
+	case []interface{}:
+		return v, nil
+	case map[string]interface{}:
+		if rows, ok := v["data"].([]interface{}); ok {
+			return rows, nil
+		}
+		return []interface{}{v}, nil
+	}
+	return nil, fmt.Errorf("unexpected response shape")
+}
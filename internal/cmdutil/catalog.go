@@ -0,0 +1,176 @@
+package cmdutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/laevitas/cli/internal/api"
+)
+
+// ─── Catalog filters ────────────────────────────────────────────────────────
+
+// CatalogFlags narrows a catalog client-side. The catalog endpoints take no
+// currency, type or expiry params, so rows are filtered after the fetch
+// using their fields and, failing that, the instrument name.
+type CatalogFlags struct {
+	Currency      string
+	ExpiresBefore string
+	Type          string
+
+	expiresBefore time.Time
+}
+
+// Columns a catalog row may carry its currency, expiry or type in; the
+// instrument name is parsed when none is present.
+var (
+	catalogCurrencyColumns = []string{"currency", "base_currency", "underlying", "base"}
+	catalogExpiryColumns   = []string{"expiry", "expiration", "expiration_timestamp", "expiry_date", "maturity"}
+	catalogTypeColumns     = []string{"type", "kind", "instrument_type", "contract_type", "settlement", "option_type"}
+)
+
+// AddCatalogFlags registers the catalog filters on cmd. dated adds
+// --expires-before, which perpetuals have no use for; types lists the
+// --type values for the help text.
+func AddCatalogFlags(cmd *cobra.Command, f *CatalogFlags, dated bool, types string) {
+	cmd.Flags().StringVar(&f.Currency, "currency", "", "Only instruments of this base currency (BTC, ETH)")
+	cmd.Flags().StringVar(&f.Type, "type", "", "Only instruments of this type ("+types+")")
+	if dated {
+		cmd.Flags().StringVar(&f.ExpiresBefore, "expires-before", "", "Only instruments expiring before a date, maturity (27MAR26) or period from now (30d)")
+	}
+	cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		return f.Validate()
+	}
+}
+
+// Validate parses --expires-before.
+func (f *CatalogFlags) Validate() error {
+	f.expiresBefore = time.Time{}
+	if f.ExpiresBefore == "" {
+		return nil
+	}
+	if d, ok := parsePeriod(f.ExpiresBefore); ok {
+		f.expiresBefore = time.Now().UTC().Add(d)
+		return nil
+	}
+	t, ok := parseExpiry(f.ExpiresBefore)
+	if !ok {
+		return fmt.Errorf("invalid --expires-before %q (use a date like 2026-03-27, a maturity like 27MAR26, or a period like 30d)", f.ExpiresBefore)
+	}
+	f.expiresBefore = t
+	return nil
+}
+
+func (f *CatalogFlags) active() bool {
+	return f.Currency != "" || f.Type != "" || !f.expiresBefore.IsZero()
+}
+
+// RunCatalog is RunAndPrint for catalog endpoints, dropping the rows that
+// don't match f before anything is printed.
+func RunCatalog(client *api.Client, endpoint string, params *api.RequestParams, f *CatalogFlags) {
+	if f.active() {
+		keepRow = f.matches
+		defer func() { keepRow = nil }()
+	}
+	RunAndPrint(client, endpoint, params)
+}
+
+// matches reports whether a catalog row passes every filter set.
+func (f *CatalogFlags) matches(row map[string]interface{}) bool {
+	name, _ := row["instrument_name"].(string)
+	parts := strings.FieldsFunc(name, func(r rune) bool { return r == '-' || r == '_' })
+
+	if f.Currency != "" {
+		cur, ok := firstString(row, catalogCurrencyColumns)
+		switch {
+		case ok:
+			if !strings.EqualFold(cur, f.Currency) {
+				return false
+			}
+		case len(parts) > 1:
+			if !strings.EqualFold(parts[0], f.Currency) {
+				return false
+			}
+		case !strings.HasPrefix(strings.ToUpper(name), strings.ToUpper(f.Currency)):
+			return false // e.g. BTCUSDT
+		}
+	}
+
+	if f.Type != "" {
+		want := strings.ToLower(f.Type)
+		typ, ok := firstString(row, catalogTypeColumns)
+		if !ok && len(parts) == 4 {
+			// Options: BTC-27MAR26-70000-C
+			typ, ok = map[string]string{"C": "call", "P": "put"}[strings.ToUpper(parts[3])]
+		}
+		typ = strings.ToLower(typ)
+		if !ok || (typ != want && !(len(want) == 1 && strings.HasPrefix(typ, want))) {
+			return false
+		}
+	}
+
+	if !f.expiresBefore.IsZero() {
+		expiry, ok := time.Time{}, false
+		for _, col := range catalogExpiryColumns {
+			if v, has := row[col]; has && v != nil {
+				if expiry, ok = parseExpiry(fmt.Sprint(v)); ok {
+					break
+				}
+			}
+		}
+		if !ok && len(parts) > 1 {
+			expiry, ok = parseExpiry(parts[1])
+		}
+		if !ok || !expiry.Before(f.expiresBefore) {
+			return false // perpetuals and unknown expiries never match
+		}
+	}
+	return true
+}
+
+// parseExpiry accepts what parseTime does plus Deribit-style maturities
+// (27MAR26), which expire at 08:00 UTC.
+func parseExpiry(s string) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	if t, ok := parseTime(s); ok {
+		return t, true
+	}
+	if t, err := time.Parse("2Jan06", s); err == nil {
+		return t.Add(8 * time.Hour), true
+	}
+	return time.Time{}, false
+}
+
+func firstString(row map[string]interface{}, columns []string) (string, bool) {
+	for _, col := range columns {
+		if s, ok := row[col].(string); ok && s != "" {
+			return s, true
+		}
+	}
+	return "", false
+}
+
+// keepRow, when set, filters the rows of the response RunAndPrint prints.
+var keepRow func(map[string]interface{}) bool
+
+// filterResponse keeps the rows of data that pass keep, returning a
+// {"data": [...], "count": n} response like fetchBatch does.
+func filterResponse(data []byte, keep func(map[string]interface{}) bool) ([]byte, error) {
+	rows, err := ResponseRows(data)
+	if err != nil {
+		return nil, err
+	}
+	kept := []interface{}{}
+	for _, row := range rows {
+		if m, ok := row.(map[string]interface{}); ok && keep(m) {
+			kept = append(kept, row)
+		}
+	}
+	return json.Marshal(struct {
+		Data  []interface{} `json:"data"`
+		Count int           `json:"count"`
+	}{kept, len(kept)})
+}
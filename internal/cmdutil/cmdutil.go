@@ -2,18 +2,26 @@ package cmdutil
 
 import (
 	"bufio"
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/briandowns/spinner"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 
 	"github.com/laevitas/cli/internal/api"
 	"github.com/laevitas/cli/internal/config"
 	"github.com/laevitas/cli/internal/output"
+	"github.com/laevitas/cli/internal/x402"
 )
 
 // ─── Global state (set by root command) ─────────────────────────────────────
@@ -23,6 +31,48 @@ var (
 	Exchange     string
 	Verbose      bool
 	NoChart      bool
+	Stats        bool
+
+	// ChartColumn overrides the auto-selected chart series (--chart-column).
+	ChartColumn string
+	// SparkColumn adds a one-line sparkline of this column below tables (--spark).
+	SparkColumn string
+
+	// Timings prints the DNS/connect/TLS/first-byte breakdown of the
+	// request to stderr (--timings).
+	Timings bool
+
+	// Log is the JSON-lines event log opened by --log-file (nil when off).
+	Log *api.Logger
+	// CommandPath and CommandStart identify the running command in Log.
+	CommandPath  string
+	CommandStart time.Time
+
+	// UserAgent overrides the User-Agent header and user_agent config (--user-agent).
+	UserAgent string
+
+	// CountOnly prints just the number of records instead of the data (--count).
+	CountOnly bool
+
+	// AssumeYes skips the x402 payment confirmation prompt (--yes).
+	AssumeYes bool
+
+	// MaxRetries bounds retries on RetryOn statuses and transient network
+	// errors (--max-retries).
+	MaxRetries = api.DefaultMaxRetries
+
+	// RetryOn overrides the retry_on config: the statuses retried with
+	// backoff, e.g. "429,503" or "none" (--retry-on).
+	RetryOn string
+
+	// Timeout is the per-call deadline: --timeout, else the running
+	// command's suggested timeout (SuggestTimeout), else api.DefaultTimeout.
+	Timeout = api.DefaultTimeout
+
+	// lowCredits is the x402 credit balance below which RunAndPrint warns
+	// (0 = off); lowCreditsWarned keeps that to once per process/session.
+	lowCredits       int
+	lowCreditsWarned bool
 
 	// InteractiveMode is true when running inside the REPL.
 	// Commands should avoid os.Exit and return errors instead.
@@ -31,15 +81,63 @@ var (
 	// SharedClient is the persistent API client used in REPL mode.
 	SharedClient *api.Client
 
-	// SpinnerInstance is the active spinner during REPL command execution.
+	// SharedProfile is the config profile SharedClient was created for.
+	SharedProfile string
+
+	// SpinnerInstance is the spinner shown while a command's requests run:
+	// the REPL's, or ProgressSpinner's for a one-shot run in a terminal.
 	SpinnerInstance *spinner.Spinner
+
+	// LastResponse is the body RunAndPrint last printed in the REPL, kept
+	// so "cols" can re-render it without fetching again.
+	LastResponse []byte
 )
 
+// ─── Spinner ────────────────────────────────────────────────────────────────
+
+// ProgressSpinner returns the spinner for a one-shot (non-REPL) run: on
+// stderr, with the elapsed time once a request passes a second. It is nil
+// when output is piped or with --quiet, so scripts see nothing extra.
+func ProgressSpinner() *spinner.Spinner {
+	if output.Quiet || !term.IsTerminal(int(os.Stdout.Fd())) || !term.IsTerminal(int(os.Stderr.Fd())) {
+		return nil
+	}
+	s := spinner.New(spinner.CharSets[14], 100*time.Millisecond, spinner.WithWriterFile(os.Stderr))
+	s.Suffix = " Loading..."
+	s.Color("cyan")
+	var started time.Time
+	s.PreUpdate = func(s *spinner.Spinner) {
+		if started.IsZero() {
+			started = time.Now()
+		}
+		if elapsed := time.Since(started); elapsed >= time.Second {
+			s.Suffix = fmt.Sprintf(" Loading... %s", elapsed.Truncate(time.Second))
+		}
+	}
+	return s
+}
+
+// StartSpinner shows SpinnerInstance, if there is one.
+func StartSpinner() {
+	if SpinnerInstance != nil {
+		SpinnerInstance.Start()
+	}
+}
+
+// StopSpinner clears SpinnerInstance from the terminal, if there is one.
+func StopSpinner() {
+	if SpinnerInstance != nil {
+		SpinnerInstance.Stop()
+	}
+}
+
 // ─── Common flags for time-series commands ──────────────────────────────────
 
 // CommonFlags holds flags shared across data commands.
 type CommonFlags struct {
 	Period     string
+	Since      string
+	For        string
 	Start      string
 	End        string
 	Resolution string
@@ -51,12 +149,151 @@ type CommonFlags struct {
 // AddCommonFlags registers the shared flags on a command.
 func AddCommonFlags(cmd *cobra.Command, f *CommonFlags) {
 	cmd.Flags().StringVarP(&f.Period, "period", "p", "", "Lookback period: 1h, 6h, 24h, 3d, 7d, 30d (default 7d)")
+	cmd.Flags().StringVar(&f.Since, "since", "", "Relative start up to now, e.g. 2h, 3d (alternative to --start)")
+	cmd.Flags().StringVar(&f.For, "for", "", "Window length after --start, e.g. 6h, 2d (alternative to --end)")
 	cmd.Flags().StringVar(&f.Start, "start", "", "Start datetime (ISO 8601)")
 	cmd.Flags().StringVar(&f.End, "end", "", "End datetime (ISO 8601)")
 	cmd.Flags().StringVarP(&f.Resolution, "resolution", "r", "", "Candle resolution: 1m, 5m, 15m, 1h, 4h, 1d")
-	cmd.Flags().IntVarP(&f.Limit, "limit", "n", 0, "Number of records (1-1000)")
+	cmd.Flags().IntVarP(&f.Limit, "limit", "n", 0, "Number of records (1-1000; 0 = server default)")
 	cmd.Flags().StringVar(&f.Cursor, "cursor", "", "Pagination cursor from previous response")
 	cmd.Flags().StringVar(&f.Currency, "currency", "", "Base currency filter (BTC, ETH)")
+	cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		return f.Validate()
+	}
+	commonFlags[cmd] = f
+}
+
+// commonFlags maps each command to the CommonFlags AddCommonFlags bound.
+var commonFlags = map[*cobra.Command]*CommonFlags{}
+
+// CommonFlagsOf returns the CommonFlags registered on cmd by AddCommonFlags,
+// or nil, for code that runs a command's request without its RunE (watch,
+// diff).
+func CommonFlagsOf(cmd *cobra.Command) *CommonFlags {
+	return commonFlags[cmd]
+}
+
+// Validate rejects malformed durations and time-range flag combinations
+// where one flag would silently override another, and clamps --limit to
+// the API maximum.
+func (f *CommonFlags) Validate() error {
+	if f.Limit < 0 {
+		return fmt.Errorf("invalid --limit %d (must be 1-%d; omit it for the server default)", f.Limit, api.MaxLimit)
+	}
+	f.Limit = ClampLimit(f.Limit)
+
+	for _, d := range []struct{ flag, value string }{
+		{"--period", f.Period}, {"--since", f.Since}, {"--for", f.For},
+	} {
+		if d.value != "" {
+			if _, ok := parsePeriod(d.value); !ok {
+				return fmt.Errorf("invalid %s %q (use e.g. 6h, 3d, 2w)", d.flag, d.value)
+			}
+		}
+	}
+	var bounds [2]time.Time
+	for i, t := range []struct{ flag, value string }{
+		{"--start", f.Start}, {"--end", f.End},
+	} {
+		if t.value == "" {
+			continue
+		}
+		ts, ok := parseTime(t.value)
+		if !ok {
+			return fmt.Errorf("invalid %s %q (use ISO 8601 like 2026-01-14T00:00:00Z, a date, or epoch seconds/ms)", t.flag, t.value)
+		}
+		if ts.After(time.Now().Add(futureSlack)) {
+			return fmt.Errorf("%s %s is in the future", t.flag, ts.UTC().Format(time.RFC3339))
+		}
+		bounds[i] = ts
+	}
+	if f.Start != "" && f.End != "" && bounds[0].After(bounds[1]) {
+		return fmt.Errorf("--start %s is after --end %s; swap them", bounds[0].UTC().Format(time.RFC3339), bounds[1].UTC().Format(time.RFC3339))
+	}
+
+	switch {
+	case f.Period != "" && f.Start != "":
+		return fmt.Errorf("--period looks back from --end or now and cannot be combined with --start; use --for for a window after --start")
+	case f.Since != "" && (f.Start != "" || f.End != "" || f.Period != ""):
+		return fmt.Errorf("--since ends at now; it cannot be combined with --start, --end or --period")
+	case f.For != "" && f.Start == "":
+		return fmt.Errorf("--for needs --start (use --since for a window ending now)")
+	case f.For != "" && (f.End != "" || f.Period != ""):
+		return fmt.Errorf("--for sets the end from --start; it cannot be combined with --end or --period")
+	}
+	return nil
+}
+
+// ClampLimit caps n at api.MaxLimit, warning when it does: the API would
+// otherwise reject or silently truncate the request.
+func ClampLimit(n int) int {
+	if n > api.MaxLimit {
+		output.Warnf("--limit %d is above the API maximum of %d; using %d (page with --cursor for more)", n, api.MaxLimit, api.MaxLimit)
+		return api.MaxLimit
+	}
+	return n
+}
+
+// futureSlack is how far past now --start/--end may lie, to absorb clock skew.
+const futureSlack = 5 * time.Minute
+
+// WarnWindow warns when the time range in p is longer than max, the
+// documented cap of the endpoint, since the API rejects such requests.
+func WarnWindow(p *api.RequestParams, max time.Duration, what string) {
+	start, ok := parseTime(p.Start)
+	if !ok {
+		return
+	}
+	end, ok := parseTime(p.End)
+	if !ok {
+		return
+	}
+	if window := end.Sub(start); window > max {
+		output.Warnf("%s allows at most a %s window; %s requested", what, formatWindow(max), formatWindow(window))
+	}
+}
+
+// parseTime accepts a full ISO 8601 timestamp, a bare date (UTC midnight),
+// or Unix epoch seconds / milliseconds.
+func parseTime(s string) (time.Time, bool) {
+	if t, ok := parseEpoch(s); ok {
+		return t, true
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, true
+	}
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+// parseEpoch interprets a bare integer as Unix seconds, or milliseconds
+// when it has 13 digits (anything after year 5138 in seconds).
+func parseEpoch(s string) (time.Time, bool) {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n <= 0 {
+		return time.Time{}, false
+	}
+	switch {
+	case n < 1e11:
+		return time.Unix(n, 0).UTC(), true
+	case n < 1e14:
+		return time.UnixMilli(n).UTC(), true
+	}
+	return time.Time{}, false
+}
+
+// isoDatetime rewrites epoch seconds/ms and bare dates (UTC midnight) in
+// layout; anything else is returned unchanged.
+func isoDatetime(s, layout string) string {
+	if t, ok := parseEpoch(s); ok {
+		return t.Format(layout)
+	}
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t.Format(layout)
+	}
+	return s
 }
 
 // parsePeriod converts a shorthand like "24h", "3d", "30d" into a time.Duration.
@@ -66,13 +303,9 @@ func parsePeriod(s string) (time.Duration, bool) {
 		return 0, false
 	}
 	unit := s[len(s)-1]
-	n, err := fmt.Sscanf(s[:len(s)-1], "%d", new(int))
-	if err != nil || n != 1 {
-		return 0, false
-	}
-	var val int
-	fmt.Sscanf(s[:len(s)-1], "%d", &val)
-	if val <= 0 {
+	// Atoi rejects anything but the whole number, so "1.5d" or "2xh" fail
+	val, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil || val <= 0 {
 		return 0, false
 	}
 	switch unit {
@@ -97,12 +330,26 @@ func (f *CommonFlags) ToParams() *api.RequestParams {
 	start := f.Start
 	end := f.End
 
+	// The API expects ISO 8601 datetimes — convert epoch and bare-date
+	// input, pass the rest through
+	start = isoDatetime(start, layout)
+	end = isoDatetime(end, layout)
+
+	// --since N is "the last N, up to now"; --for N is "N after --start"
+	period := f.Period
+	if f.Since != "" {
+		period = f.Since
+	}
+	if f.For != "" {
+		period = f.For
+	}
+
 	// --start/--end take priority if both provided
 	if start == "" || end == "" {
 		// Determine the window from --period or fallback to default
 		window := defaultWindow
-		if f.Period != "" {
-			if d, ok := parsePeriod(f.Period); ok {
+		if period != "" {
+			if d, ok := parsePeriod(period); ok {
 				window = d
 			}
 		}
@@ -112,11 +359,11 @@ func (f *CommonFlags) ToParams() *api.RequestParams {
 			end = now.Format(layout)
 			start = now.Add(-window).Format(layout)
 		case start != "" && end == "":
-			if t, err := time.Parse(time.RFC3339, start); err == nil {
+			if t, ok := parseTime(start); ok {
 				end = t.Add(window).Format(layout)
 			}
 		case start == "" && end != "":
-			if t, err := time.Parse(time.RFC3339, end); err == nil {
+			if t, ok := parseTime(end); ok {
 				start = t.Add(-window).Format(layout)
 			}
 		}
@@ -136,6 +383,25 @@ func (f *CommonFlags) ToParams() *api.RequestParams {
 	return p
 }
 
+// LogCommand records the end of the running command in the --log-file,
+// if one is open. Call it before any os.Exit that skips Execute's return.
+func LogCommand(err error) {
+	if Log == nil || CommandPath == "" {
+		return
+	}
+	e := api.LogEntry{
+		Event:      "command",
+		Command:    CommandPath,
+		DurationMS: time.Since(CommandStart).Milliseconds(),
+	}
+	if err != nil {
+		e.Level = api.LogError
+		e.Error = err.Error()
+	}
+	Log.Log(e)
+	CommandPath = ""
+}
+
 // ─── Client / Printer helpers ───────────────────────────────────────────────
 
 // MustClient loads config and creates an API client, exiting on error.
@@ -161,7 +427,8 @@ func MustClient() (*api.Client, *config.Config) {
 	}
 
 	// Require either an API key or a wallet key for authentication
-	if cfg.APIKey == "" && cfg.WalletKey == "" {
+	// (not for --replay, which makes no request)
+	if cfg.APIKey == "" && cfg.WalletKey == "" && Replay == "" {
 		if !promptOnboarding(cfg) {
 			if !InteractiveMode {
 				os.Exit(1)
@@ -170,20 +437,97 @@ func MustClient() (*api.Client, *config.Config) {
 		}
 	}
 
-	// Reuse persistent client in REPL mode
-	if InteractiveMode && SharedClient != nil {
+	// Flag overrides are applied after onboarding so its Save doesn't
+	// persist them.
+	if UserAgent != "" {
+		cfg.UserAgent = UserAgent
+	}
+	if RetryOn != "" {
+		cfg.RetryOn = RetryOn
+	}
+
+	// Reuse persistent client in REPL mode (unless --profile selects another)
+	if InteractiveMode && SharedClient != nil && SharedProfile == cfg.Profile {
 		SharedClient.Verbose = Verbose
+		SharedClient.Quiet = output.Quiet
+		SharedClient.MaxRetries = MaxRetries
+		SharedClient.Timeout = Timeout
+		SharedClient.UserAgent = cfg.UserAgent
+		SharedClient.RetryOn = api.RetryOnCodes(cfg.RetryOn)
+		SharedClient.Log = Log
+		setPaymentGuards(SharedClient, cfg)
+		applyOutputConfig(cfg)
 		return SharedClient, cfg
 	}
 
 	client := api.NewClient(cfg)
 	client.Verbose = Verbose
-	if InteractiveMode {
+	client.Quiet = output.Quiet
+	client.MaxRetries = MaxRetries
+	client.Timeout = Timeout
+	client.Log = Log
+	setPaymentGuards(client, cfg)
+	applyOutputConfig(cfg)
+	if InteractiveMode && (SharedClient == nil || SharedProfile == cfg.Profile) {
 		SharedClient = client
+		SharedProfile = cfg.Profile
 	}
 	return client, cfg
 }
 
+// applyOutputConfig pushes the display settings from config into output.
+func applyOutputConfig(cfg *config.Config) {
+	output.PagerCommand = cfg.Pager
+	rules, err := output.ParseColorRules(cfg.ColorRules)
+	if err != nil {
+		output.Warnf("Ignoring color_rules in config: %s", err)
+	}
+	output.ColorRules = rules
+}
+
+// setPaymentGuards applies the max_payment_usd cap and, unless --yes is set
+// or stdin isn't a terminal, asks before each x402 payment is signed.
+func setPaymentGuards(client *api.Client, cfg *config.Config) {
+	client.MaxPaymentUSD = cfg.MaxPaymentUSD
+	lowCredits = cfg.LowCreditsThreshold()
+	client.ConfirmPayment = nil
+	if !AssumeYes && term.IsTerminal(int(os.Stdin.Fd())) {
+		client.ConfirmPayment = confirmPayment
+	}
+}
+
+// RefusePayments turns the x402 payment prompt into a refusal, for modes
+// that hold stdin in raw mode and read it themselves (watch), where the
+// prompt could never be answered. With --yes, payments are still made.
+func RefusePayments(client *api.Client) {
+	if client.ConfirmPayment != nil {
+		client.ConfirmPayment = func(x402.Payment) bool { return false }
+	}
+}
+
+// confirmMu keeps concurrent batch requests from prompting at once.
+var confirmMu sync.Mutex
+
+// confirmPayment shows an x402 payment on stderr and asks to approve it.
+func confirmPayment(p x402.Payment) bool {
+	confirmMu.Lock()
+	defer confirmMu.Unlock()
+	if SpinnerInstance != nil {
+		SpinnerInstance.Stop()
+	}
+	fmt.Fprintf(os.Stderr, "\n  x402 payment required\n")
+	fmt.Fprintf(os.Stderr, "    Amount:    %s USDC\n", x402.FormatUSDC(p.Amount))
+	if p.Resource != "" {
+		fmt.Fprintf(os.Stderr, "    Resource:  %s\n", p.Resource)
+	}
+	if p.PayTo != "" {
+		fmt.Fprintf(os.Stderr, "    Pay to:    %s\n", p.PayTo)
+	}
+	fmt.Fprintf(os.Stderr, "  Pay? [y/N] ")
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	return strings.HasPrefix(strings.ToLower(strings.TrimSpace(answer)), "y")
+}
+
 // promptOnboarding runs the first-run API key setup inline.
 // Returns true if a key was successfully configured.
 func promptOnboarding(cfg *config.Config) bool {
@@ -216,7 +560,7 @@ func promptOnboarding(cfg *config.Config) bool {
 	// Verify API key
 	fmt.Print("  Verifying API key... ")
 	client := api.NewClient(cfg)
-	_, err = client.Get(api.Health, nil)
+	_, err = client.Get(context.Background(), api.Health, nil)
 	if err != nil {
 		fmt.Println("✗")
 		output.Warnf("API key verification failed: %v", err)
@@ -229,6 +573,118 @@ func promptOnboarding(cfg *config.Config) bool {
 	return true
 }
 
+// SnapshotTimeout is the suggested timeout of full-market snapshot commands.
+const SnapshotTimeout = 2 * time.Minute
+
+// timeoutAnnotation holds a command's suggested timeout (SuggestTimeout).
+const timeoutAnnotation = "laevitas:timeout"
+
+// SuggestTimeout gives cmd a longer (or shorter) default per-call timeout
+// than api.DefaultTimeout, e.g. for snapshot endpoints that are slow by
+// nature. --timeout still overrides it.
+func SuggestTimeout(cmd *cobra.Command, d time.Duration) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[timeoutAnnotation] = d.String()
+}
+
+// CommandTimeout returns cmd's suggested timeout, or api.DefaultTimeout.
+func CommandTimeout(cmd *cobra.Command) time.Duration {
+	if d, err := time.ParseDuration(cmd.Annotations[timeoutAnnotation]); err == nil {
+		return d
+	}
+	return api.DefaultTimeout
+}
+
+// instrumentsFileAnnotation marks commands that take --instruments-file
+// (AllowInstrumentsFile).
+const instrumentsFileAnnotation = "laevitas:instruments-file"
+
+// AllowInstrumentsFile marks instrument commands that can run once per
+// instrument of --instruments-file; other commands reject the flag.
+func AllowInstrumentsFile(cmds ...*cobra.Command) {
+	for _, cmd := range cmds {
+		if cmd.Annotations == nil {
+			cmd.Annotations = map[string]string{}
+		}
+		cmd.Annotations[instrumentsFileAnnotation] = "true"
+	}
+}
+
+// AllowsInstrumentsFile reports whether cmd takes --instruments-file.
+func AllowsInstrumentsFile(cmd *cobra.Command) bool {
+	return cmd.Annotations[instrumentsFileAnnotation] == "true"
+}
+
+// computedAnnotation marks commands that print rows computed from API
+// responses (ComputedOutput).
+const computedAnnotation = "laevitas:computed"
+
+// ComputedOutput marks commands that print rows they compute rather than
+// an API response. They reject --raw, --save-response and --replay, which
+// need the single response RunAndPrint prints, and print with PrintComputed.
+func ComputedOutput(cmds ...*cobra.Command) {
+	for _, cmd := range cmds {
+		if cmd.Annotations == nil {
+			cmd.Annotations = map[string]string{}
+		}
+		cmd.Annotations[computedAnnotation] = "true"
+	}
+}
+
+// IsComputedOutput reports whether cmd prints computed rows.
+func IsComputedOutput(cmd *cobra.Command) bool {
+	return cmd.Annotations[computedAnnotation] == "true"
+}
+
+// SignalContext returns a context that is cancelled on Ctrl+C, so an
+// in-flight request is aborted instead of waiting for the HTTP timeout.
+// Callers must call the returned stop function to release the handler.
+func SignalContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt)
+}
+
+// IsCancelled reports whether err came from a Ctrl+C cancellation.
+func IsCancelled(err error) bool {
+	return errors.Is(err, context.Canceled)
+}
+
+// Process exit codes, so scripts can tell failure classes apart.
+const (
+	ExitError     = 1   // anything else (bad params, 4xx/5xx)
+	ExitAuth      = 2   // 401/403 — API key invalid or missing
+	ExitRateLimit = 4   // 429 after retries
+	ExitNetwork   = 5   // cannot reach the API, or --timeout hit
+	ExitPayment   = 6   // 402 — x402 payment required or rejected
+	ExitCancelled = 130 // Ctrl+C
+)
+
+// ExitCode maps an error to the process exit code.
+func ExitCode(err error) int {
+	var apiErr *api.APIError
+	var netErr *api.NetworkError
+	var timeoutErr *api.TimeoutError
+	switch {
+	case err == nil:
+		return 0
+	case IsCancelled(err):
+		return ExitCancelled
+	case errors.As(err, &netErr), errors.As(err, &timeoutErr):
+		return ExitNetwork
+	case errors.As(err, &apiErr):
+		switch {
+		case apiErr.IsAuthError():
+			return ExitAuth
+		case apiErr.IsRateLimit():
+			return ExitRateLimit
+		case apiErr.StatusCode == 402:
+			return ExitPayment
+		}
+	}
+	return ExitError
+}
+
 // MustPrinter returns a printer configured from global flags.
 func MustPrinter() *output.Printer {
 	return output.NewPrinter(OutputFormat)
@@ -247,32 +703,93 @@ func Ftoa(f float64) string {
 // RunAndPrint fetches data, prints it, and handles errors.
 func RunAndPrint(client *api.Client, endpoint string, params *api.RequestParams) {
 	// Warn if instrument is specified but exchange is missing
-	if params != nil && params.InstrumentName != "" && params.Exchange == "" {
-		output.Warnf("No --exchange specified. Add --exchange <name> (e.g. --exchange deribit, --exchange binance) for accurate results.")
+	if params != nil && (params.InstrumentName != "" || InstrumentsFile != "") && params.Exchange == "" {
+		output.Warnf("No --exchange specified. Add --exchange <name> (e.g. --exchange deribit, --exchange bybit) for accurate results.")
+	}
+
+	if ExplainRequest(client, endpoint, params) {
+		return
+	}
+	if Describe {
+		runDescribe(client, endpoint, params)
+		return
 	}
 
 	p := MustPrinter()
 
-	// Start spinner in interactive mode
-	if InteractiveMode && SpinnerInstance != nil {
-		SpinnerInstance.Start()
-	}
+	// Spinner on stderr while the request runs
+	StartSpinner()
 
-	data, err := client.Get(endpoint, params)
+	ctx, stop := SignalContext()
+	var data []byte
+	var err error
+	switch {
+	case Replay != "":
+		data, err = readReplay(client)
+	case InstrumentsFile != "":
+		data, err = fetchBatch(ctx, client, endpoint, params)
+	case params != nil && splitCurrencies(params.Currency) != nil:
+		data, err = fetchCurrencies(ctx, client, endpoint, params)
+	default:
+		data, err = client.Get(ctx, endpoint, params)
+	}
+	stop()
 
 	// Stop spinner before printing output
-	if InteractiveMode && SpinnerInstance != nil {
-		SpinnerInstance.Stop()
-	}
+	StopSpinner()
 
+	if IsCancelled(err) {
+		output.Warnf("Cancelled")
+		if !InteractiveMode {
+			LogCommand(err)
+			os.Exit(ExitCancelled)
+		}
+		return
+	}
 	if err != nil {
 		output.PrintError(p.Format, err)
 		if !InteractiveMode {
-			os.Exit(1)
+			LogCommand(err)
+			os.Exit(ExitCode(err))
 		}
 		return
 	}
 
+	if SaveResponse != "" {
+		if err := saveResponse(data); err != nil {
+			output.Errorf("Saving response: %s", err)
+		}
+	}
+
+	// --raw: the body exactly as received — no chart, hints or footer
+	if output.Raw {
+		if err := p.Print(data); err != nil {
+			output.Errorf("Writing output: %s", err)
+		}
+		if Stats {
+			printStats(client.LastMeta())
+		}
+		if Timings {
+			printTimings(client.LastMeta())
+		}
+		return
+	}
+
+	// Catalog filters (RunCatalog)
+	if keepRow != nil {
+		if data, err = filterResponse(data, keepRow); err != nil {
+			output.PrintError(p.Format, err)
+			if !InteractiveMode {
+				os.Exit(ExitError)
+			}
+			return
+		}
+	}
+
+	if InteractiveMode {
+		LastResponse = data
+	}
+
 	// Extract record counts from API response metadata
 	var recordCount, totalCount int
 	var wrapper struct {
@@ -290,8 +807,21 @@ func RunAndPrint(client *api.Client, endpoint string, params *api.RequestParams)
 		}
 	}
 
-	// Set total count on printer for table footer
-	if p.Format == output.FormatTable {
+	if CountOnly {
+		printCount(p, data, recordCount, totalCount)
+		if Stats {
+			printStats(client.LastMeta())
+		}
+		if Timings {
+			printTimings(client.LastMeta())
+		}
+		warnLowCredits(client)
+		return
+	}
+
+	// Set total count on printer for table footer (meaningless once rows
+	// have been filtered or regrouped client-side)
+	if p.Format == output.FormatTable && !output.RowSetChanged() {
 		if totalCount > 0 {
 			p.TotalCount = totalCount
 		} else if recordCount > 0 {
@@ -299,6 +829,14 @@ func RunAndPrint(client *api.Client, endpoint string, params *api.RequestParams)
 		}
 	}
 
+	// Long tables go through the pager, chart and sparkline included. Not
+	// in the REPL, whose line editor keeps reading the terminal.
+	var paged *bytes.Buffer
+	if p.Format == output.FormatTable && !InteractiveMode && output.PagerEnabled() {
+		paged = &bytes.Buffer{}
+		p.Writer = paged
+	}
+
 	if err := p.Print(data); err != nil {
 		output.Errorf("Formatting output: %s", err)
 		if !InteractiveMode {
@@ -307,15 +845,44 @@ func RunAndPrint(client *api.Client, endpoint string, params *api.RequestParams)
 		return
 	}
 
-	// Render inline chart for time-series data in table mode
-	if p.Format == output.FormatTable && !NoChart {
-		if col, caption := output.ChartableEndpoint(endpoint); col != "" {
-			output.RenderChart(p.Writer, data, col, caption)
+	// Render inline chart for time-series data in table mode (one series
+	// only — a merged --instruments-file result would interleave several)
+	if p.Format == output.FormatTable && !NoChart && !output.RowSetChanged() && InstrumentsFile == "" {
+		col, caption := output.ChartableEndpoint(endpoint)
+		if ChartColumn != "" {
+			if err := output.CheckChartColumn(data, ChartColumn); err != nil {
+				output.Warnf("--chart-column: %s", err)
+			} else {
+				output.RenderChart(p.Writer, data, ChartColumn, ChartColumn)
+				col = ""
+			}
+		}
+		if col != "" {
+			if output.CandlestickEndpoint(endpoint) {
+				output.RenderCandlestick(p.Writer, data, caption)
+			} else {
+				output.RenderChart(p.Writer, data, col, caption)
+			}
+		}
+	}
+
+	// Summary sparkline, independent of --no-chart since it's asked for explicitly
+	if p.Format == output.FormatTable && SparkColumn != "" {
+		if err := output.CheckChartColumn(data, SparkColumn); err != nil {
+			output.Warnf("--spark: %s", err)
+		} else {
+			output.RenderSparkline(p.Writer, data, SparkColumn)
+		}
+	}
+
+	if paged != nil {
+		if err := output.Page(paged.Bytes()); err != nil {
+			output.Errorf("Writing output: %s", err)
 		}
 	}
 
 	// Show pagination hint for table/csv output
-	if p.Format != output.FormatJSON {
+	if p.Format != output.FormatJSON && !output.Quiet {
 		var cursorWrapper struct {
 			Meta *struct {
 				NextCursor string `json:"next_cursor"`
@@ -336,12 +903,145 @@ func RunAndPrint(client *api.Client, endpoint string, params *api.RequestParams)
 	}
 
 	// Show request metadata footer
-	printRequestMeta(client, endpoint, params, recordCount, totalCount)
+	if !output.Quiet {
+		printRequestMeta(client, endpoint, params, recordCount, totalCount)
+	}
+
+	if Stats {
+		printStats(client.LastMeta())
+	}
+	if Timings {
+		printTimings(client.LastMeta())
+	}
+
+	warnLowCredits(client)
+}
+
+// printCount prints the record count for --count: meta.total, else the
+// response's count, else the rows themselves. Client-side filters and
+// grouping change the row set, so then the transformed rows are counted.
+func printCount(p *output.Printer, data []byte, recordCount, totalCount int) {
+	n := totalCount
+	if n == 0 {
+		n = recordCount
+	}
+	var err error
+	if output.RowSetChanged() {
+		n, err = output.RowCount(data)
+	} else if n == 0 {
+		var rows []interface{}
+		rows, err = ResponseRows(data)
+		n = len(rows)
+	}
+	if err != nil {
+		output.PrintError(p.Format, err)
+		if !InteractiveMode {
+			os.Exit(ExitError)
+		}
+		return
+	}
+	if err := p.PrintCount(n); err != nil {
+		output.Errorf("Writing output: %s", err)
+	}
+}
+
+// warnLowCredits warns once when the x402 credit balance — from this
+// response, or else the last one seen — is below the low_credits threshold.
+func warnLowCredits(client *api.Client) {
+	if lowCreditsWarned || lowCredits <= 0 || !client.HasWallet() {
+		return
+	}
+	remaining := client.LastMeta().Credits
+	if remaining == "" {
+		seen, ok := config.LoadCreditsSeen()
+		if !ok {
+			return
+		}
+		remaining = seen.Remaining
+	}
+	n, err := strconv.ParseFloat(remaining, 64)
+	if err != nil || n >= float64(lowCredits) {
+		return
+	}
+	lowCreditsWarned = true
+	output.Warnf("Only %s x402 credits left (warning below %d). Once they run out, requests are paid on-chain again.", output.FormatNumber(remaining), lowCredits)
+}
+
+// PrintComputed prints the rows of a ComputedOutput command, or with
+// --count just their number. --stats and --timings describe client's last
+// request.
+func PrintComputed(p *output.Printer, client *api.Client, rows interface{}) error {
+	var err error
+	if CountOnly {
+		var n int
+		if n, err = output.RowCount(rows); err == nil {
+			err = p.PrintCount(n)
+		}
+	} else {
+		err = p.Print(rows)
+	}
+	if Stats {
+		printStats(client.LastMeta())
+	}
+	if Timings {
+		printTimings(client.LastMeta())
+	}
+	warnLowCredits(client)
+	return err
+}
+
+// printStats writes a one-line timing/payment summary to stderr (--stats).
+// e.g. "⧖ 243ms · api-key · 12.3 KB · 1 retry · 9,980 credits left"
+func printStats(meta api.RequestMeta) {
+	parts := []string{"⧖ " + formatDuration(meta.Duration)}
+
+	if meta.PaymentMethod != "" {
+		parts = append(parts, meta.PaymentMethod)
+	}
+
+	parts = append(parts, formatResponseSize(meta))
+
+	switch {
+	case meta.Retries == 1:
+		parts = append(parts, "1 retry")
+	case meta.Retries > 1:
+		parts = append(parts, fmt.Sprintf("%d retries", meta.Retries))
+	}
+
+	if meta.Credits != "" {
+		parts = append(parts, fmt.Sprintf("%s credits left", output.FormatNumber(meta.Credits)))
+	}
+
+	fmt.Fprintf(os.Stderr, "\033[2m%s\033[0m\n", strings.Join(parts, " · "))
+}
+
+// printTimings shows where the request's time went, for --timings.
+func printTimings(meta api.RequestMeta) {
+	t := meta.Timings
+	if t == nil {
+		output.Warnf("--timings: no per-request breakdown for this command")
+		return
+	}
+	var parts []string
+	if t.Reused {
+		parts = append(parts, "reused connection")
+	} else {
+		parts = append(parts,
+			"dns "+formatDuration(t.DNS),
+			"connect "+formatDuration(t.Connect))
+		if t.TLS > 0 {
+			parts = append(parts, "tls "+formatDuration(t.TLS))
+		}
+	}
+	parts = append(parts,
+		"first byte "+formatDuration(t.FirstByte),
+		"total "+formatDuration(t.Total))
+	fmt.Fprintf(os.Stderr, "\033[2m⏱ %s\033[0m\n", strings.Join(parts, " · "))
 }
 
 // printRequestMeta shows a compact metadata line on stderr after each request.
 func printRequestMeta(client *api.Client, endpoint string, params *api.RequestParams, recordCount, totalCount int) {
-	meta := client.LastMeta
+	meta := client.LastMeta()
 	if meta.PaymentMethod == "" {
 		return
 	}
@@ -381,7 +1081,7 @@ func printRequestMeta(client *api.Client, endpoint string, params *api.RequestPa
 	// Verbose: show endpoint, response size, retries
 	if Verbose {
 		parts = append(parts, endpoint)
-		parts = append(parts, formatBytes(meta.ResponseSize))
+		parts = append(parts, formatResponseSize(meta))
 		if meta.Retries > 0 {
 			parts = append(parts, fmt.Sprintf("%d retries", meta.Retries))
 		}
@@ -410,3 +1110,12 @@ func formatBytes(b int) string {
 		return fmt.Sprintf("%d B", b)
 	}
 }
+
+// formatResponseSize shows the decompressed size, plus the bytes actually
+// transferred when the response was gzip-encoded (e.g. "2.1 MB (310.4 KB gzip)").
+func formatResponseSize(meta api.RequestMeta) string {
+	if meta.WireSize > 0 {
+		return fmt.Sprintf("%s (%s gzip)", formatBytes(meta.ResponseSize), formatBytes(meta.WireSize))
+	}
+	return formatBytes(meta.ResponseSize)
+}
@@ -0,0 +1,63 @@
+package cmdutil
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParsePeriod(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want time.Duration
+		ok   bool
+	}{
+		{"24h", 24 * time.Hour, true},
+		{"3d", 72 * time.Hour, true},
+		{"2w", 14 * 24 * time.Hour, true},
+		{"1.5d", 0, false},
+		{"2xh", 0, false},
+		{"h", 0, false},
+		{"0d", 0, false},
+		{"-3d", 0, false},
+		{"3 d", 0, false},
+		{"3m", 0, false},
+		{"", 0, false},
+	} {
+		got, ok := parsePeriod(tc.in)
+		if ok != tc.ok || got != tc.want {
+			t.Errorf("parsePeriod(%q) = %s, %v; want %s, %v", tc.in, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestValidateRejectsMalformedPeriod(t *testing.T) {
+	for _, f := range []CommonFlags{
+		{Period: "1.5d"},
+		{Since: "2xh"},
+		{Start: "2026-01-01T00:00:00Z", For: "1d2"},
+	} {
+		if err := f.Validate(); err == nil {
+			t.Errorf("%+v: no error", f)
+		}
+	}
+}
+
+// TestToParamsNormalizesTimes checks epoch and bare-date input reach the
+// API as ISO 8601 datetimes, with the window filled in from them.
+func TestToParamsNormalizesTimes(t *testing.T) {
+	for _, tc := range []struct {
+		f          CommonFlags
+		start, end string
+	}{
+		{CommonFlags{Start: "1709000000"}, "2024-02-27T02:13:20Z", "2024-03-05T02:13:20Z"},
+		{CommonFlags{Start: "1709000000000", End: "1709086400"}, "2024-02-27T02:13:20Z", "2024-02-28T02:13:20Z"},
+		{CommonFlags{Start: "2026-01-14", End: "2026-01-15"}, "2026-01-14T00:00:00Z", "2026-01-15T00:00:00Z"},
+		{CommonFlags{Start: "2026-01-14", For: "1d"}, "2026-01-14T00:00:00Z", "2026-01-15T00:00:00Z"},
+		{CommonFlags{Start: "2026-01-14T06:00:00Z", End: "2026-01-15T06:00:00Z"}, "2026-01-14T06:00:00Z", "2026-01-15T06:00:00Z"},
+	} {
+		p := tc.f.ToParams()
+		if p.Start != tc.start || p.End != tc.end {
+			t.Errorf("%+v: got %s – %s, want %s – %s", tc.f, p.Start, p.End, tc.start, tc.end)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package cmdutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/laevitas/cli/internal/api"
+	"github.com/laevitas/cli/internal/output"
+)
+
+// Describe lists the fields a command returns instead of its data (--describe).
+var Describe bool
+
+// describeField is one row of --describe output.
+type describeField struct {
+	Field    string `json:"field"`
+	Type     string `json:"type"`
+	Priority int    `json:"priority"`
+	Example  string `json:"example"`
+}
+
+// runDescribe fetches a single record from endpoint and prints its field
+// names (flattened like table columns, e.g. greeks.delta), inferred types
+// and table priorities — the names --filter, --sort-by and cols accept.
+func runDescribe(client *api.Client, endpoint string, params *api.RequestParams) {
+	p := MustPrinter()
+
+	one := api.RequestParams{}
+	if params != nil {
+		one = *params
+	}
+	one.Limit = 1
+
+	ctx, stop := SignalContext()
+	var data []byte
+	var err error
+	if Replay != "" {
+		data, err = readReplay(client)
+	} else {
+		data, err = client.Get(ctx, endpoint, &one)
+	}
+	stop()
+
+	var fields []describeField
+	if err == nil {
+		fields, err = describeFields(data)
+	}
+	if err != nil {
+		output.PrintError(p.Format, err)
+		if !InteractiveMode {
+			LogCommand(err)
+			os.Exit(ExitCode(err))
+		}
+		return
+	}
+	if len(fields) == 0 {
+		output.Warnf("No records returned — nothing to describe. Try a wider time range.")
+		return
+	}
+	// Through JSON so tables see the json field names
+	out, _ := json.Marshal(fields)
+	if err := p.Print(out); err != nil {
+		output.Errorf("Formatting output: %s", err)
+	}
+}
+
+// describeFields infers the fields of the records in data. A field's type
+// comes from its first non-null value across all records.
+func describeFields(data []byte) ([]describeField, error) {
+	rows, err := ResponseRows(data)
+	if err != nil {
+		return nil, err
+	}
+	byName := map[string]*describeField{}
+	for _, row := range rows {
+		rec, ok := row.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		// Single-object responses: {"data": {...}}
+		if inner, ok := rec["data"].(map[string]interface{}); ok && len(rows) == 1 {
+			rec = inner
+		}
+		flat := map[string]interface{}{}
+		flattenRecord("", rec, flat)
+		for name, v := range flat {
+			f := byName[name]
+			if f == nil {
+				f = &describeField{Field: name, Type: "null", Priority: output.ColumnPriority(name)}
+				byName[name] = f
+			}
+			if f.Type == "null" && v != nil {
+				f.Type = valueType(v)
+				f.Example = exampleValue(v)
+			}
+		}
+	}
+
+	fields := make([]describeField, 0, len(byName))
+	for _, f := range byName {
+		fields = append(fields, *f)
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		if fields[i].Priority != fields[j].Priority {
+			return fields[i].Priority < fields[j].Priority
+		}
+		return fields[i].Field < fields[j].Field
+	})
+	return fields, nil
+}
+
+// flattenRecord turns nested objects into dotted keys, as tables do.
+func flattenRecord(prefix string, m map[string]interface{}, out map[string]interface{}) {
+	for k, v := range m {
+		if prefix != "" {
+			k = prefix + "." + k
+		}
+		if inner, ok := v.(map[string]interface{}); ok && len(inner) > 0 {
+			flattenRecord(k, inner, out)
+			continue
+		}
+		out[k] = v
+	}
+}
+
+func valueType(v interface{}) string {
+	switch v := v.(type) {
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	case bool:
+		return "boolean"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	case string:
+		if _, err := time.Parse(time.RFC3339, v); err == nil {
+			return "time"
+		}
+		return "string"
+	}
+	return "null"
+}
+
+func exampleValue(v interface{}) string {
+	switch v := v.(type) {
+	case []interface{}, map[string]interface{}:
+		b, _ := json.Marshal(v)
+		s := string(b)
+		if len(s) > 40 {
+			s = s[:37] + "..."
+		}
+		return s
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,170 @@
+package cmdutil
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/laevitas/cli/internal/api"
+	"github.com/laevitas/cli/internal/output"
+)
+
+// Explain prints what a command would query instead of running it (--explain).
+var Explain bool
+
+// explainParams lists the query parameters --explain describes by name, in
+// display order. Anything else is shown under "Other".
+var explainParams = []struct{ key, label string }{
+	{"exchange", "Exchange"},
+	{"instrument_name", "Instrument"},
+	{"currency", "Currency"},
+	{"maturity", "Maturity"},
+	{"option_type", "Option type"},
+	{"date", "Date"},
+	{"resolution", "Resolution"},
+	{"limit", "Limit"},
+	{"cursor", "Cursor"},
+	{"direction", "Direction"},
+	{"position_side", "Position side"},
+	{"strategy", "Strategy"},
+	{"group_by", "Group by"},
+	{"top_n", "Top N"},
+	{"min_premium_usd", "Min premium"},
+	{"min_amount_usd", "Min amount"},
+	{"min_amount", "Min amount"},
+	{"min_notional", "Min notional"},
+	{"block_only", "Block only"},
+	{"opening_only", "Opening only"},
+	{"sort", "Sort"},
+	{"sort_dir", "Sort direction"},
+	{"category", "Category"},
+	{"event_slug", "Event"},
+	{"keyword", "Keyword"},
+}
+
+// ExplainRequest handles --explain for commands that call the client
+// directly instead of through RunAndPrint: when set, it describes the
+// request for endpoint and params and returns true, and the caller must
+// not send it. Commands making several requests call it for each.
+func ExplainRequest(client *api.Client, endpoint string, params *api.RequestParams) bool {
+	if !Explain {
+		return false
+	}
+	printExplain(os.Stdout, client, endpoint, params)
+	return true
+}
+
+// printExplain describes the request RunAndPrint would make for endpoint
+// and params, using the same query parameters the real request sends.
+func printExplain(w io.Writer, client *api.Client, endpoint string, params *api.RequestParams) {
+	if params == nil {
+		params = &api.RequestParams{}
+	}
+	q := params.Values()
+
+	line := func(label, value string) {
+		fmt.Fprintf(w, "  %-14s %s\n", label, value)
+	}
+
+	fmt.Fprintln(w, "This command would query:")
+	fmt.Fprintln(w)
+	line("Endpoint", "GET "+endpoint)
+
+	start, end := q.Get("start"), q.Get("end")
+	switch {
+	case start != "" || end != "":
+		line("Time range", describeRange(start, end))
+	default:
+		line("Time range", "(endpoint default)")
+	}
+	q.Del("start")
+	q.Del("end")
+
+	for _, p := range explainParams {
+		v := q.Get(p.key)
+		switch {
+		case v != "":
+			line(p.label, v)
+		case p.key == "exchange":
+			line(p.label, "(not set)")
+		case p.key == "resolution" && (start != "" || end != ""):
+			line(p.label, "(endpoint default)")
+		}
+		q.Del(p.key)
+	}
+
+	if len(q) > 0 {
+		keys := make([]string, 0, len(q))
+		for k := range q {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var other []string
+		for _, k := range keys {
+			other = append(other, k+"="+q.Get(k))
+		}
+		line("Other", strings.Join(other, ", "))
+	}
+
+	// Client-side steps applied to the response
+	var local []string
+	for _, f := range output.Filters {
+		local = append(local, "filter "+f.Expr)
+	}
+	if output.AggregateBy != "" {
+		var aggs []string
+		for _, a := range output.Aggs {
+			aggs = append(aggs, a.Name())
+		}
+		local = append(local, fmt.Sprintf("group by %s (%s)", output.AggregateBy, strings.Join(aggs, ", ")))
+	}
+	for _, k := range output.SortKeys {
+		dir := "asc"
+		if k.Desc {
+			dir = "desc"
+		}
+		local = append(local, fmt.Sprintf("sort %s %s", k.Column, dir))
+	}
+	if len(local) > 0 {
+		line("Client-side", strings.Join(local, "; "))
+	}
+
+	line("URL", client.URL(endpoint, params))
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Nothing was sent. Drop --explain to run it.")
+}
+
+// describeRange renders a start/end pair with the window length, e.g.
+// "2026-01-07 00:00 → 2026-01-14 00:00 UTC (7d)".
+func describeRange(start, end string) string {
+	const layout = "2006-01-02 15:04"
+	s, okS := parseTime(start)
+	e, okE := parseTime(end)
+	if !okS || !okE {
+		return fmt.Sprintf("%s → %s", orOpen(start), orOpen(end))
+	}
+	return fmt.Sprintf("%s → %s UTC (%s)", s.UTC().Format(layout), e.UTC().Format(layout), formatWindow(e.Sub(s)))
+}
+
+func orOpen(s string) string {
+	if s == "" {
+		return "(open)"
+	}
+	return s
+}
+
+// formatWindow renders a duration in the units --period accepts.
+func formatWindow(d time.Duration) string {
+	switch {
+	case d <= 0:
+		return d.String()
+	case d%(24*time.Hour) == 0:
+		return fmt.Sprintf("%dd", d/(24*time.Hour))
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	}
+	return d.String()
+}
@@ -0,0 +1,40 @@
+package cmdutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/laevitas/cli/internal/api"
+)
+
+// ─── Saved responses ────────────────────────────────────────────────────────
+
+var (
+	// SaveResponse is a file RunAndPrint writes the raw response body to
+	// (--save-response), for debugging or attaching to a bug report.
+	SaveResponse string
+
+	// Replay is a file saved with --save-response that RunAndPrint prints
+	// instead of calling the API (--replay).
+	Replay string
+)
+
+// readReplay loads the --replay body. The client's last meta is reset so
+// no latency or credits from an earlier call are reported for it.
+func readReplay(client *api.Client) ([]byte, error) {
+	data, err := os.ReadFile(Replay)
+	if err != nil {
+		return nil, err
+	}
+	if !json.Valid(data) {
+		return nil, fmt.Errorf("%s is not a saved JSON response", Replay)
+	}
+	client.SetLastMeta(api.RequestMeta{ResponseSize: len(data)})
+	return data, nil
+}
+
+// saveResponse writes the body for --save-response, exactly as received.
+func saveResponse(data []byte) error {
+	return os.WriteFile(SaveResponse, data, 0644)
+}
@@ -1,16 +1,20 @@
 package completer
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
 	"github.com/laevitas/cli/internal/api"
+	"github.com/laevitas/cli/internal/config"
+	"github.com/laevitas/cli/internal/output"
 )
 
 // ─── Command tree definition ─────────────────────────────────────────────────
@@ -35,6 +39,7 @@ var commandTree = map[string][]subCmd{
 		{Name: "volume", NeedsInstrument: true},
 		{Name: "level1", NeedsInstrument: true},
 		{Name: "orderbook", NeedsInstrument: true},
+		{Name: "orderbook-raw", NeedsInstrument: true},
 		{Name: "ticker", NeedsInstrument: true},
 		{Name: "ref-price", NeedsInstrument: true},
 		{Name: "metadata", NeedsInstrument: true},
@@ -46,12 +51,15 @@ var commandTree = map[string][]subCmd{
 		{Name: "catalog"},
 		{Name: "snapshot"},
 		{Name: "carry", NeedsInstrument: true},
+		{Name: "carry-compare"},
+		{Name: "spread", NeedsInstrument: true},
 		{Name: "ohlcvt", NeedsInstrument: true},
 		{Name: "oi", NeedsInstrument: true},
 		{Name: "trades", NeedsInstrument: true},
 		{Name: "volume", NeedsInstrument: true},
 		{Name: "level1", NeedsInstrument: true},
 		{Name: "orderbook", NeedsInstrument: true},
+		{Name: "orderbook-raw", NeedsInstrument: true},
 		{Name: "ticker", NeedsInstrument: true},
 		{Name: "ref-price", NeedsInstrument: true},
 		{Name: "metadata", NeedsInstrument: true},
@@ -62,9 +70,13 @@ var commandTree = map[string][]subCmd{
 	"options": {
 		{Name: "catalog"},
 		{Name: "snapshot"},
+		{Name: "pcr"},
+		{Name: "max-pain"},
+		{Name: "expiry-calendar"},
 		{Name: "flow"},
 		{Name: "trades"},
 		{Name: "trades-summary"},
+		{Name: "liquidations"},
 		{Name: "ohlcvt", NeedsInstrument: true},
 		{Name: "oi", NeedsInstrument: true},
 		{Name: "volatility", NeedsInstrument: true},
@@ -84,6 +96,7 @@ var commandTree = map[string][]subCmd{
 		{Name: "ticker", NeedsInstrument: true},
 		{Name: "orderbook", NeedsInstrument: true},
 		{Name: "metadata", NeedsInstrument: true},
+		{Name: "flow"},
 	},
 	"config": {
 		{Name: "init"},
@@ -91,15 +104,38 @@ var commandTree = map[string][]subCmd{
 		{Name: "set"},
 		{Name: "unset"},
 		{Name: "path"},
+		{Name: "export"},
+		{Name: "import"},
+		{Name: "profile"},
+		{Name: "alias"},
+		{Name: "doctor"},
+		{Name: "wallet-balance"},
+		{Name: "payments"},
+		{Name: "credits"},
+	},
+	"catalog": {
+		{Name: "refresh"},
+	},
+	"exchanges": {
+		{Name: "futures"},
+		{Name: "perps"},
+		{Name: "options"},
+	},
+	"saves": {
+		{Name: "export"},
+		{Name: "import"},
 	},
 	"watch": {},
+	"diff":  {},
 }
 
 // topLevelCommands includes both tree commands and REPL built-ins.
 var topLevelCommands = []string{
 	"futures", "perps", "options", "predictions",
-	"config", "watch", "version", "search",
-	"save", "run", "saves", "unsave",
+	"config", "watch", "diff", "version", "search", "resolve", "health", "catalog",
+	"exchanges",
+	"save", "run", "saves", "unsave", "cols",
+	"json", "csv", "tsv", "table", "markdown", "save-last", "history", "record",
 	"help", "quit", "exit", "clear",
 }
 
@@ -112,19 +148,33 @@ var savedNameCommands = map[string]bool{
 
 // configSetKeys are valid keys for "config set <key>".
 var configSetKeys = []string{
-	"api_key", "exchange", "output", "base_url", "wallet_key", "auth",
+	"api_key", "exchange", "output", "base_url", "wallet_key", "auth", "secrets",
+	"user_agent", "retry_on",
 }
 
 // configUnsetKeys are valid keys for "config unset <key>".
 var configUnsetKeys = []string{
-	"api_key", "wallet_key",
+	"api_key", "wallet_key", "user_agent", "retry_on",
 }
 
+// profileSubcommands are valid subcommands for "config profile <sub>".
+var profileSubcommands = []string{"list", "use", "delete"}
+
 // configValueOptions maps config keys to their valid values for "config set <key> <value>".
 var configValueOptions = map[string][]string{
 	"auth":     {"auto", "api-key", "x402"},
-	"output":   {"auto", "json", "table", "csv"},
-	"exchange": {"deribit", "binance", "bybit", "okx"},
+	"secrets":  {config.SecretsFile, config.SecretsKeychain},
+	"output":   {"auto", "json", "table", "csv", "tsv", "markdown", "template"},
+	"exchange": config.Exchanges,
+}
+
+// flagValueOptions maps flag names to their valid values for "--flag <value>".
+var flagValueOptions = map[string][]string{
+	"resolution": {"1m", "5m", "15m", "1h", "4h", "1d"},
+	"direction":  {"buy", "sell"},
+	"sort-dir":   {"ASC", "DESC"},
+	"type":       {"C", "P"},
+	"exchange":   config.Exchanges,
 }
 
 // catalogEndpoints maps top-level command to the API endpoint for its catalog.
@@ -143,19 +193,39 @@ type Completer struct {
 	rootCmd *cobra.Command // for flag completion
 
 	mu       sync.RWMutex
-	catalogs map[string][]string // "futures" → ["BTC-28MAR25", ...]
+	catalogs map[string]config.CachedCatalog // "futures" → ["BTC-28MAR25", ...]
+	saveMu   sync.Mutex                      // serializes writes of the disk cache
 
 	// SavedNamesFunc returns the names of saved queries for tab-completion.
 	// Set by the caller (interactive.go) after loading saved queries.
 	SavedNamesFunc func() []string
+
+	// ReplaceFunc replaces the whole input line. readline completion can only
+	// append to the typed text, so substring matches (e.g. "70000" →
+	// "BTC-27MAR26-70000-C") need this hook to rewrite the partial token.
+	// Set by the caller (interactive.go); without it only prefix matching is used.
+	ReplaceFunc func(line string)
+
+	// pending remembers the last substring match set so the next Tab on the
+	// rewritten line keeps cycling through it instead of re-matching the
+	// (shorter) token against the whole catalog.
+	pending *fuzzyMatch
+}
+
+// fuzzyMatch is a substring match set applied to the input line.
+type fuzzyMatch struct {
+	line    string   // input line after rewriting
+	token   string   // rewritten token (common prefix of matches)
+	matches []string // instruments containing the originally typed text
 }
 
 // New creates a new Completer backed by the given API client.
+// Catalogs cached on disk by earlier sessions are loaded immediately.
 func New(client *api.Client, rootCmd *cobra.Command) *Completer {
 	return &Completer{
 		client:   client,
 		rootCmd:  rootCmd,
-		catalogs: make(map[string][]string),
+		catalogs: config.LoadCatalogs(),
 	}
 }
 
@@ -165,11 +235,42 @@ func New(client *api.Client, rootCmd *cobra.Command) *Completer {
 func (c *Completer) Do(line []rune, pos int) ([][]rune, int) {
 	// Only complete up to the cursor position
 	lineStr := string(line[:pos])
+	atEnd := pos == len(line)
+
+	// Continue a substring match set if the line is unchanged since the rewrite
+	if p := c.pending; p != nil {
+		c.pending = nil
+		if atEnd && lineStr == p.line {
+			c.pending = p
+			return filterCompletions(p.matches, p.token)
+		}
+	}
 
 	// Split into segments
 	segments := splitLine(lineStr)
 	trailing := len(lineStr) > 0 && lineStr[len(lineStr)-1] == ' '
 
+	// Flag value completion: "--resolution " or "-r 1" or "--resolution=1"
+	if len(segments) > 0 {
+		last := segments[len(segments)-1]
+		switch {
+		case trailing && strings.HasPrefix(last, "-"):
+			if opts, ok := c.flagValues(segments[:len(segments)-1], last); ok {
+				return filterCompletions(opts, "")
+			}
+		case !trailing && strings.HasPrefix(last, "-") && strings.Contains(last, "="):
+			eq := strings.Index(last, "=")
+			if opts, ok := c.flagValues(segments[:len(segments)-1], last[:eq]); ok {
+				return filterCompletions(opts, last[eq+1:])
+			}
+			return nil, 0
+		case !trailing && len(segments) > 1 && strings.HasPrefix(segments[len(segments)-2], "-"):
+			if opts, ok := c.flagValues(segments[:len(segments)-2], segments[len(segments)-2]); ok {
+				return filterCompletions(opts, last)
+			}
+		}
+	}
+
 	// Flag completion: if the last segment starts with "-", complete flag names
 	if len(segments) > 0 && !trailing {
 		last := segments[len(segments)-1]
@@ -209,7 +310,7 @@ func (c *Completer) Do(line []rune, pos int) ([][]rune, int) {
 		if strings.ToLower(segments[0]) == "config" {
 			return c.completeConfigKey(segments[1], "")
 		}
-		return c.completeInstrument(segments[0], segments[1], "")
+		return c.completeInstrument(segments[0], segments[1], "", "")
 
 	case len(segments) == 3 && !trailing:
 		// Partially typed 3rd arg — config key or instrument
@@ -218,7 +319,7 @@ func (c *Completer) Do(line []rune, pos int) ([][]rune, int) {
 		}
 		last := segments[2]
 		if !strings.HasPrefix(last, "-") {
-			return c.completeInstrument(segments[0], segments[1], last)
+			return c.completeInstrument(segments[0], segments[1], last, lineHead(lineStr, last, atEnd))
 		}
 		return nil, 0
 
@@ -240,7 +341,7 @@ func (c *Completer) Do(line []rune, pos int) ([][]rune, int) {
 		// Could be partially typed instrument
 		last := segments[len(segments)-1]
 		if !strings.HasPrefix(last, "-") {
-			return c.completeInstrument(segments[0], segments[1], last)
+			return c.completeInstrument(segments[0], segments[1], last, lineHead(lineStr, last, atEnd))
 		}
 		return nil, 0
 
@@ -266,7 +367,10 @@ func (c *Completer) completeSubcommand(parent, prefix string) ([][]rune, int) {
 
 // completeInstrument returns completions for instrument names.
 // It fetches and caches the catalog for the given parent command.
-func (c *Completer) completeInstrument(parent, sub, prefix string) ([][]rune, int) {
+// Prefix matches always win; when there are none, instruments containing the
+// typed text anywhere are offered instead (requires ReplaceFunc and a non-empty
+// head, the line text preceding the token).
+func (c *Completer) completeInstrument(parent, sub, prefix, head string) ([][]rune, int) {
 	parent = strings.ToLower(parent)
 	sub = strings.ToLower(sub)
 
@@ -291,7 +395,28 @@ func (c *Completer) completeInstrument(parent, sub, prefix string) ([][]rune, in
 		return nil, 0
 	}
 
-	return filterCompletions(instruments, strings.ToUpper(prefix))
+	token := strings.ToUpper(prefix)
+	matches := rankMatches(instruments, token)
+	if len(matches) == 0 || token == "" || c.ReplaceFunc == nil || head == "" ||
+		strings.HasPrefix(strings.ToUpper(matches[0]), token) {
+		return filterCompletions(instruments, token)
+	}
+
+	// Substring matches only: rewrite the token to the longest common prefix
+	// of the matches, then complete from there.
+	if len(matches) == 1 {
+		c.ReplaceFunc(head + matches[0] + " ")
+		return nil, 0
+	}
+	common := commonPrefix(matches)
+	if len(common) <= len(token) {
+		// Rewriting would lose part of the typed token (e.g. "70000" over
+		// BTC and ETH strikes has no common prefix), so only list them
+		return filterCompletions(matches, "")
+	}
+	c.ReplaceFunc(head + common)
+	c.pending = &fuzzyMatch{line: head + common, token: common, matches: matches}
+	return filterCompletions(matches, common)
 }
 
 // completeConfigKey returns completions for config key names.
@@ -301,12 +426,21 @@ func (c *Completer) completeConfigKey(subCmd, prefix string) ([][]rune, int) {
 		return filterCompletions(configSetKeys, prefix)
 	case "unset":
 		return filterCompletions(configUnsetKeys, prefix)
+	case "profile":
+		return filterCompletions(profileSubcommands, prefix)
 	}
 	return nil, 0
 }
 
 // completeConfigValue returns completions for config values given a key.
 func (c *Completer) completeConfigValue(key, prefix string) ([][]rune, int) {
+	// "config profile use <name>" / "config profile delete <name>"
+	switch strings.ToLower(key) {
+	case "use", "delete":
+		names, _ := config.ProfileNames()
+		return filterCompletions(names, prefix)
+	}
+
 	opts, ok := configValueOptions[strings.ToLower(key)]
 	if !ok {
 		return nil, 0
@@ -324,16 +458,23 @@ func (c *Completer) completeSavedNames(prefix string) ([][]rune, int) {
 }
 
 // getCatalog returns cached instruments for the given category,
-// fetching from the API on first access.
+// fetching from the API on first access. Stale disk-cached entries are
+// returned as-is; PreloadCatalogs takes care of refreshing them.
 func (c *Completer) getCatalog(category string) []string {
 	c.mu.RLock()
 	cached, ok := c.catalogs[category]
 	c.mu.RUnlock()
 	if ok {
-		return cached
+		return cached.Instruments
 	}
+	return c.fetchCatalog(category, c.client.Verbose)
+}
 
-	// Fetch from API
+// fetchCatalog downloads the catalog for a category, stores it in memory,
+// and persists the cache to disk. Returns nil if the fetch fails. verbose
+// is read by the caller: the REPL resets client.Verbose for each command
+// while background fetches run.
+func (c *Completer) fetchCatalog(category string, verbose bool) []string {
 	endpoint, ok := catalogEndpoints[category]
 	if !ok {
 		return nil
@@ -344,13 +485,53 @@ func (c *Completer) getCatalog(category string) []string {
 		return nil
 	}
 
+	// saveMu is held from the snapshot through the write, so concurrent
+	// fetches write their snapshots in the order they were taken and the
+	// last write holds every catalog.
+	c.saveMu.Lock()
 	c.mu.Lock()
-	c.catalogs[category] = instruments
+	c.catalogs[category] = config.CachedCatalog{
+		FetchedAt:   time.Now(),
+		Instruments: instruments,
+	}
+	snapshot := make(map[string]config.CachedCatalog, len(c.catalogs))
+	for k, v := range c.catalogs {
+		snapshot[k] = v
+	}
 	c.mu.Unlock()
+	err := config.SaveCatalogs(snapshot)
+	c.saveMu.Unlock()
+	if err != nil && verbose {
+		output.Warnf("Could not save the instrument cache: %s", err)
+	}
 
 	return instruments
 }
 
+// Refresh re-downloads every catalog, ignoring the cache.
+// Returns the number of instruments per category; failed fetches are omitted.
+func (c *Completer) Refresh() map[string]int {
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		counts  = make(map[string]int)
+		verbose = c.client.Verbose
+	)
+	for cat := range catalogEndpoints {
+		wg.Add(1)
+		go func(cat string) {
+			defer wg.Done()
+			if instruments := c.fetchCatalog(cat, verbose); instruments != nil {
+				mu.Lock()
+				counts[cat] = len(instruments)
+				mu.Unlock()
+			}
+		}(cat)
+	}
+	wg.Wait()
+	return counts
+}
+
 // GetAllInstruments returns all cached instruments across all categories.
 // It triggers fetching for any category not yet cached.
 func (c *Completer) GetAllInstruments() map[string][]string {
@@ -393,14 +574,20 @@ func (c *Completer) Search(keywords []string) []SearchResult {
 
 // SearchResult holds a single search match.
 type SearchResult struct {
-	Category   string
-	Instrument string
+	Category   string `json:"category"`
+	Instrument string `json:"instrument"`
 }
 
-// PreloadCatalogs fetches all catalogs in the background.
+// PreloadCatalogs fetches missing or stale catalogs in the background.
 func (c *Completer) PreloadCatalogs() {
+	verbose := c.client.Verbose
 	for cat := range catalogEndpoints {
-		go c.getCatalog(cat)
+		c.mu.RLock()
+		cached, ok := c.catalogs[cat]
+		c.mu.RUnlock()
+		if !ok || cached.Stale() {
+			go c.fetchCatalog(cat, verbose)
+		}
 	}
 }
 
@@ -454,12 +641,51 @@ func (c *Completer) completeFlags(segments []string, prefix string) ([][]rune, i
 	return filterCompletions(flagNames, prefix)
 }
 
+// flagValues returns the value options for a flag token such as "--resolution"
+// or "-r". Shorthands are resolved against the Cobra command given by segments.
+func (c *Completer) flagValues(segments []string, flag string) ([]string, bool) {
+	name := strings.TrimPrefix(flag, "--")
+	if name == flag {
+		// Single-dash shorthand
+		name = c.flagForShorthand(segments, strings.TrimPrefix(flag, "-"))
+	}
+	opts, ok := flagValueOptions[name]
+	return opts, ok
+}
+
+// flagForShorthand returns the long name of a shorthand flag on the command
+// resolved from segments, or "" if it cannot be resolved.
+func (c *Completer) flagForShorthand(segments []string, short string) string {
+	if c.rootCmd == nil || len(short) != 1 {
+		return ""
+	}
+
+	cmdSegments := make([]string, 0, len(segments))
+	for _, seg := range segments {
+		if !strings.HasPrefix(seg, "-") {
+			cmdSegments = append(cmdSegments, seg)
+		}
+	}
+	cmd, _, err := c.rootCmd.Find(cmdSegments)
+	if err != nil || cmd == nil {
+		return ""
+	}
+
+	if f := cmd.Flags().ShorthandLookup(short); f != nil {
+		return f.Name
+	}
+	if f := cmd.InheritedFlags().ShorthandLookup(short); f != nil {
+		return f.Name
+	}
+	return ""
+}
+
 // ─── Helpers ─────────────────────────────────────────────────────────────────
 
 // fetchInstrumentNames calls the catalog endpoint and extracts instrument_name
 // from each record in the response.
 func fetchInstrumentNames(client *api.Client, endpoint string) []string {
-	data, err := client.Get(endpoint, nil)
+	data, err := client.Get(context.Background(), endpoint, nil)
 	if err != nil {
 		return nil
 	}
@@ -513,6 +739,47 @@ func filterCompletions(candidates []string, prefix string) ([][]rune, int) {
 	return matches, len(prefix)
 }
 
+// rankMatches returns the candidates that contain token (case-insensitive),
+// with prefix matches ahead of substring matches. Order within each group
+// follows the input order.
+func rankMatches(candidates []string, token string) []string {
+	token = strings.ToUpper(token)
+	var prefixed, contained []string
+	for _, c := range candidates {
+		upper := strings.ToUpper(c)
+		switch {
+		case strings.HasPrefix(upper, token):
+			prefixed = append(prefixed, c)
+		case strings.Contains(upper, token):
+			contained = append(contained, c)
+		}
+	}
+	return append(prefixed, contained...)
+}
+
+// commonPrefix returns the longest prefix shared by all strings.
+func commonPrefix(ss []string) string {
+	if len(ss) == 0 {
+		return ""
+	}
+	prefix := ss[0]
+	for _, s := range ss[1:] {
+		for !strings.HasPrefix(s, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
+
+// lineHead returns the part of line preceding the trailing token, or "" when
+// the cursor is not at the end of the line (the line cannot be rewritten safely).
+func lineHead(line, token string, atEnd bool) string {
+	if !atEnd || !strings.HasSuffix(line, token) {
+		return ""
+	}
+	return line[:len(line)-len(token)]
+}
+
 // matchesAll returns true if all keywords are found as case-insensitive
 // substrings in the given string.
 func matchesAll(s string, keywords []string) bool {
@@ -0,0 +1,35 @@
+package completer
+
+import (
+	"testing"
+
+	"github.com/laevitas/cli/internal/config"
+)
+
+// TestCompleteInstrumentKeepsToken checks a substring token whose matches
+// share no longer prefix is listed, not rewritten away.
+func TestCompleteInstrumentKeepsToken(t *testing.T) {
+	var replaced []string
+	c := &Completer{
+		catalogs: map[string]config.CachedCatalog{"options": {Instruments: []string{
+			"BTC-27MAR26-70000-C", "BTC-27MAR26-70000-P", "ETH-27MAR26-70000-C",
+		}}},
+		ReplaceFunc: func(line string) { replaced = append(replaced, line) },
+	}
+
+	got, offset := c.Do([]rune("options ohlcvt 70000"), len("options ohlcvt 70000"))
+	if len(replaced) > 0 {
+		t.Errorf("line rewritten to %q", replaced)
+	}
+	if len(got) != 3 || offset != 0 {
+		t.Errorf("got %d candidates at offset %d, want all 3 listed", len(got), offset)
+	}
+
+	// Without the ETH strike the common prefix extends the token
+	replaced = nil
+	c.catalogs["options"] = config.CachedCatalog{Instruments: []string{"BTC-27MAR26-70000-C", "BTC-27MAR26-70000-P"}}
+	c.Do([]rune("options ohlcvt 70000"), len("options ohlcvt 70000"))
+	if want := "options ohlcvt BTC-27MAR26-70000-"; len(replaced) != 1 || replaced[0] != want {
+		t.Errorf("line rewritten to %q, want %q", replaced, want)
+	}
+}
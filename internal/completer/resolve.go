@@ -0,0 +1,149 @@
+package completer
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ─── Symbol resolution ──────────────────────────────────────────────────────
+
+// ResolveResult is an instrument matching a base currency, type and exchange.
+type ResolveResult struct {
+	Instrument string `json:"instrument"`
+	Category   string `json:"category"`
+	Exchange   string `json:"exchange,omitempty"`
+}
+
+// resolveKinds maps --type values to catalog categories.
+var resolveKinds = map[string]string{
+	"perp": "perps", "perps": "perps", "perpetual": "perps", "swap": "perps",
+	"future": "futures", "futures": "futures", "dated": "futures",
+	"option": "options", "options": "options",
+}
+
+// exchangeStyles are each exchange's instrument naming conventions per
+// category, with BASE standing for the currency. The catalogs are not split
+// by exchange, so names are attributed to an exchange by their shape.
+var exchangeStyles = map[string]map[string]string{
+	"deribit": {
+		"perps":   `^BASE(_USDC|_USDT)?-PERPETUAL$`,
+		"futures": `^BASE(_USDC)?-\d{1,2}[A-Z]{3}\d{2}$`,
+		"options": `^BASE(_USDC)?-\d{1,2}[A-Z]{3}\d{2}-[\d.]+-[CP]$`,
+	},
+	"binance": {
+		"perps":   `^BASE(USDT|USDC|USD_PERP)$`,
+		"futures": `^BASE(USDT|USD)_\d{6}$`,
+		"options": `^BASE-\d{6}-[\d.]+-[CP]$`,
+	},
+	"bybit": {
+		"perps":   `^BASE(USDT|USDC|USD|PERP)$`,
+		"futures": `^BASE(USDT|USDC)?-\d{1,2}[A-Z]{3}\d{2}$`,
+		"options": `^BASE-\d{1,2}[A-Z]{3}\d{2}-[\d.]+-[CP](-USDT)?$`,
+	},
+	"okx": {
+		"perps":   `^BASE-(USDT|USDC|USD)-SWAP$`,
+		"futures": `^BASE-(USDT|USD)-\d{6}$`,
+		"options": `^BASE-USD-\d{6}-[\d.]+-[CP]$`,
+	},
+}
+
+// ResolveKinds lists the accepted --type values for help text.
+const ResolveKinds = "perp, future, option"
+
+// Resolve finds the instruments of base currency (BTC) and kind (perp,
+// future, option; "" = all) in the cached catalogs that follow exchange's
+// naming ("" = any exchange). Results are ordered best first: the shortest,
+// plainest name of each category leads. fuzzy is true when nothing matched
+// the currency exactly and the results are substring matches instead.
+func (c *Completer) Resolve(base, kind, exchange string) (results []ResolveResult, fuzzy bool, err error) {
+	base = strings.ToUpper(strings.TrimSpace(base))
+	exchange = strings.ToLower(exchange)
+
+	var categories []string
+	if kind == "" {
+		categories = []string{"perps", "futures", "options"}
+	} else {
+		cat, ok := resolveKinds[strings.ToLower(kind)]
+		if !ok {
+			return nil, false, fmt.Errorf("unknown type %q (use %s)", kind, ResolveKinds)
+		}
+		categories = []string{cat}
+	}
+	if _, ok := exchangeStyles[exchange]; exchange != "" && !ok {
+		return nil, false, fmt.Errorf("no naming rules for exchange %q (known: deribit, binance, bybit, okx)", exchange)
+	}
+
+	for _, cat := range categories {
+		instruments := c.getCatalog(cat)
+		results = append(results, matchStyles(instruments, cat, base, exchange)...)
+	}
+
+	// Nothing for that exact currency: fall back to substring matches
+	// within the same categories, like search does.
+	if len(results) == 0 {
+		fuzzy = true
+		for _, cat := range categories {
+			for _, inst := range c.getCatalog(cat) {
+				if matchesAll(inst, []string{base}) {
+					results = append(results, ResolveResult{Instrument: inst, Category: cat})
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Category != b.Category {
+			return categoryRank(a.Category) < categoryRank(b.Category)
+		}
+		if len(a.Instrument) != len(b.Instrument) {
+			return len(a.Instrument) < len(b.Instrument)
+		}
+		return a.Instrument < b.Instrument
+	})
+	return results, fuzzy, nil
+}
+
+// matchStyles returns the instruments of one category whose names follow
+// an exchange's convention for base. With exchange "" every exchange's
+// conventions are tried.
+func matchStyles(instruments []string, category, base, exchange string) []ResolveResult {
+	exchanges := []string{exchange}
+	if exchange == "" {
+		exchanges = []string{"deribit", "binance", "bybit", "okx"}
+	}
+
+	var results []ResolveResult
+	seen := make(map[string]bool)
+	for _, ex := range exchanges {
+		pattern, ok := exchangeStyles[ex][category]
+		if !ok {
+			continue
+		}
+		re := regexp.MustCompile(strings.Replace(pattern, "BASE", regexp.QuoteMeta(base), 1))
+		for _, inst := range instruments {
+			if seen[inst] || !re.MatchString(strings.ToUpper(inst)) {
+				continue
+			}
+			seen[inst] = true
+			r := ResolveResult{Instrument: inst, Category: category}
+			if exchange != "" {
+				r.Exchange = exchange
+			}
+			results = append(results, r)
+		}
+	}
+	return results
+}
+
+func categoryRank(category string) int {
+	switch category {
+	case "perps":
+		return 0
+	case "futures":
+		return 1
+	}
+	return 2
+}
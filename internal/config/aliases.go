@@ -0,0 +1,53 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ─── Command aliases ────────────────────────────────────────────────────────
+//
+// Aliases live in config.json under "aliases", shared by all profiles:
+// {"bf": "perps carry BTC-PERPETUAL"}. A command line whose first word is an
+// alias has that word replaced by the alias's command.
+
+// Aliases returns the configured aliases.
+func Aliases() map[string]string {
+	return readFile().Aliases
+}
+
+// ValidateAliasName checks that name can be typed as the first word of a
+// command line.
+func ValidateAliasName(name string) error {
+	if name == "" || strings.ContainsAny(name, " \t\"'|") || strings.HasPrefix(name, "-") || strings.HasPrefix(name, "!") {
+		return fmt.Errorf("invalid alias name %q (one word, not starting with - or !)", name)
+	}
+	return nil
+}
+
+// SetAlias adds or replaces an alias. Returns true if it replaced one.
+func SetAlias(name, command string) (bool, error) {
+	if err := ValidateAliasName(name); err != nil {
+		return false, err
+	}
+	if strings.TrimSpace(command) == "" {
+		return false, fmt.Errorf("alias %s needs a command", name)
+	}
+	cfg := readFile()
+	_, existed := cfg.Aliases[name]
+	if cfg.Aliases == nil {
+		cfg.Aliases = make(map[string]string)
+	}
+	cfg.Aliases[name] = strings.TrimSpace(command)
+	return existed, writeFile(cfg)
+}
+
+// UnsetAlias removes an alias.
+func UnsetAlias(name string) error {
+	cfg := readFile()
+	if _, ok := cfg.Aliases[name]; !ok {
+		return fmt.Errorf("unknown alias: %s (see: laevitas config alias list)", name)
+	}
+	delete(cfg.Aliases, name)
+	return writeFile(cfg)
+}
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const catalogsFileName = "catalogs.json"
+
+// CatalogTTL is how long cached instrument catalogs are considered fresh.
+// Older entries are still used for completion but refreshed in the background.
+const CatalogTTL = 6 * time.Hour
+
+// CachedCatalog is the instrument list for one category and when it was fetched.
+type CachedCatalog struct {
+	FetchedAt   time.Time `json:"fetched_at"`
+	Instruments []string  `json:"instruments"`
+}
+
+// Stale reports whether the catalog is older than CatalogTTL.
+func (c CachedCatalog) Stale() bool {
+	return time.Since(c.FetchedAt) > CatalogTTL
+}
+
+// LoadCatalogs reads the instrument catalog cache from disk.
+// Returns an empty map if the file doesn't exist or can't be parsed.
+func LoadCatalogs() map[string]CachedCatalog {
+	catalogs := make(map[string]CachedCatalog)
+
+	dir, err := configDir()
+	if err != nil {
+		return catalogs
+	}
+	data, err := os.ReadFile(filepath.Join(dir, catalogsFileName))
+	if err != nil {
+		return catalogs
+	}
+	if json.Unmarshal(data, &catalogs) != nil {
+		return make(map[string]CachedCatalog)
+	}
+	return catalogs
+}
+
+// SaveCatalogs writes the instrument catalog cache to disk.
+func SaveCatalogs(catalogs map[string]CachedCatalog) error {
+	dir, err := configDir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("cannot create config directory: %w", err)
+	}
+
+	data, err := json.Marshal(catalogs)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, catalogsFileName), data, 0600)
+}
@@ -3,9 +3,16 @@ package config
 import (
 	"encoding/json"
 	"fmt"
+	"math"
+	"net/url"
 	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -14,6 +21,10 @@ const (
 	DefaultOutput   = "auto" // auto = table if TTY, json if piped
 	DefaultLimit    = 100
 
+	// DefaultLowCredits is the x402 credit balance below which a warning
+	// is shown, unless low_credits says otherwise.
+	DefaultLowCredits = 100
+
 	configDirName  = "laevitas"
 	configFileName = "config.json"
 )
@@ -25,16 +36,198 @@ const (
 	AuthTypeX402   = "x402"   // Always use x402 wallet payment
 )
 
-// Config holds all CLI configuration.
-type Config struct {
+// Exchanges lists the exchange values accepted by --exchange and `config set exchange`.
+var Exchanges = []string{"deribit", "binance", "bybit", "okx"}
+
+// NormalizeExchange lower-cases an exchange name and checks it against Exchanges.
+func NormalizeExchange(name string) (string, error) {
+	ex := strings.ToLower(strings.TrimSpace(name))
+	for _, known := range Exchanges {
+		if ex == known {
+			return ex, nil
+		}
+	}
+	return "", fmt.Errorf("unknown exchange: %s (valid: %s)", name, strings.Join(Exchanges, ", "))
+}
+
+// Outputs lists the values accepted by -o and `config set output`.
+var Outputs = []string{"auto", "json", "table", "csv", "tsv", "markdown"}
+
+// NormalizeOutput lower-cases an output format and checks it against Outputs.
+func NormalizeOutput(name string) (string, error) {
+	out := strings.ToLower(strings.TrimSpace(name))
+	for _, known := range Outputs {
+		if out == known {
+			return out, nil
+		}
+	}
+	return "", fmt.Errorf("invalid output format: %s (valid: %s)", name, strings.Join(Outputs, ", "))
+}
+
+// NormalizeBaseURL trims a base URL and checks that it is an absolute
+// http(s) URL with a host. A trailing slash is removed.
+func NormalizeBaseURL(raw string) (string, error) {
+	s := strings.TrimSpace(raw)
+	u, err := url.Parse(s)
+	if err != nil {
+		return "", fmt.Errorf("invalid base URL: %s (%v)", raw, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("invalid base URL: %s (must start with https:// or http://)", raw)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("invalid base URL: %s (missing host)", raw)
+	}
+	return strings.TrimRight(s, "/"), nil
+}
+
+// NormalizeSecret trims surrounding whitespace from a pasted key and rejects
+// empty values or keys with embedded whitespace.
+func NormalizeSecret(name, raw string) (string, error) {
+	s := strings.TrimSpace(raw)
+	if s == "" {
+		return "", fmt.Errorf("%s cannot be empty (use: laevitas config unset %s)", name, name)
+	}
+	if strings.ContainsAny(s, " \t\r\n") {
+		return "", fmt.Errorf("%s must not contain whitespace", name)
+	}
+	return s, nil
+}
+
+// NormalizeMaxPayment parses a max_payment_usd value: a non-negative dollar
+// amount, where 0 removes the cap. A leading "$" is accepted.
+func NormalizeMaxPayment(raw string) (float64, error) {
+	v, err := strconv.ParseFloat(strings.TrimPrefix(strings.TrimSpace(raw), "$"), 64)
+	if err != nil || v < 0 || math.IsInf(v, 0) || math.IsNaN(v) {
+		return 0, fmt.Errorf("invalid max_payment_usd: %s (must be a dollar amount >= 0, e.g. 0.05)", raw)
+	}
+	return v, nil
+}
+
+// NormalizeLowCredits parses a low_credits value: a credit count, or
+// "off" (or 0) to disable the warning, stored as -1.
+func NormalizeLowCredits(raw string) (int, error) {
+	s := strings.ToLower(strings.TrimSpace(raw))
+	if s == "off" || s == "none" {
+		return -1, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid low_credits: %s (must be a credit count >= 0, or off)", raw)
+	}
+	if n == 0 {
+		return -1, nil
+	}
+	return n, nil
+}
+
+// DefaultRetryOn is the retry_on used when none is configured: only rate
+// limits are retried.
+const DefaultRetryOn = "429"
+
+// ParseRetryOn parses a retry_on value: comma-separated 4xx/5xx status
+// codes that are retried with backoff, or "none" to retry on no status.
+// "none" yields an empty, non-nil slice.
+func ParseRetryOn(raw string) ([]int, error) {
+	s := strings.ToLower(strings.TrimSpace(raw))
+	codes := []int{}
+	if s == "none" || s == "off" {
+		return codes, nil
+	}
+	for _, part := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || n < 400 || n > 599 {
+			return nil, fmt.Errorf("invalid retry_on: %s (must be comma-separated 4xx/5xx status codes, e.g. 429,503, or none)", raw)
+		}
+		if !slices.Contains(codes, n) {
+			codes = append(codes, n)
+		}
+	}
+	return codes, nil
+}
+
+// NormalizeRetryOn validates a retry_on value and returns its canonical
+// form, e.g. "429,503" or "none".
+func NormalizeRetryOn(raw string) (string, error) {
+	codes, err := ParseRetryOn(raw)
+	if err != nil {
+		return "", err
+	}
+	if len(codes) == 0 {
+		return "none", nil
+	}
+	parts := make([]string, len(codes))
+	for i, n := range codes {
+		parts[i] = strconv.Itoa(n)
+	}
+	return strings.Join(parts, ","), nil
+}
+
+// Settings holds the values that can differ per profile.
+type Settings struct {
 	APIKey    string `json:"api_key,omitempty"`
 	BaseURL   string `json:"base_url,omitempty"`
 	Exchange  string `json:"exchange,omitempty"`
 	Output    string `json:"output,omitempty"`
 	WalletKey string `json:"wallet_key,omitempty"` // EVM private key for x402 payments
 	Auth      string `json:"auth,omitempty"`       // "auto", "api-key", or "x402"
+
+	MaxPaymentUSD float64 `json:"max_payment_usd,omitempty"` // cap per x402 payment (0 = no cap)
+	LowCredits    int     `json:"low_credits,omitempty"`     // warn below this many x402 credits (-1 = off)
+	Pager         string  `json:"pager,omitempty"`           // pager for long tables ("off" to disable)
+	UserAgent     string  `json:"user_agent,omitempty"`      // User-Agent header (default laevitas-cli/<version>)
+	RetryOn       string  `json:"retry_on,omitempty"`        // statuses retried with backoff, e.g. "429,503" or "none" (default 429)
+
+	// Unset lists the keys a profile clears explicitly (`config unset`, or
+	// `config set` to an empty/zero value), so they override a top-level
+	// value instead of inheriting it. Unused at the top level.
+	Unset []string `json:"unset,omitempty"`
 }
 
+// LowCreditsThreshold returns the credit balance that triggers the
+// low-credits warning, or 0 if the warning is disabled.
+func (s Settings) LowCreditsThreshold() int {
+	switch {
+	case s.LowCredits < 0:
+		return 0
+	case s.LowCredits == 0:
+		return DefaultLowCredits
+	}
+	return s.LowCredits
+}
+
+// Config holds all CLI configuration. The top-level settings are the
+// "default" profile; named profiles override them when active.
+type Config struct {
+	Settings
+	CurrentProfile string              `json:"current_profile,omitempty"`
+	Profiles       map[string]Settings `json:"profiles,omitempty"`
+	Secrets        string              `json:"secrets,omitempty"` // "file" (default) or "keychain"
+
+	// ColorRules colors table cells by threshold: column name to
+	// {"OP value": "color"}, e.g. {"iv": {">100": "red"}}.
+	ColorRules map[string]map[string]string `json:"color_rules,omitempty"`
+
+	// Aliases map a short first word to a command, e.g.
+	// {"bf": "perps carry BTC-PERPETUAL"} (see aliases.go).
+	Aliases map[string]string `json:"aliases,omitempty"`
+
+	// Profile is the active profile resolved by Load ("" = default).
+	// Save writes settings back into this profile.
+	Profile string `json:"-"`
+
+	// loaded is Settings as Load resolved them (profile and env applied),
+	// so Save can tell which values the caller changed.
+	loaded Settings
+}
+
+// DefaultProfile names the top-level settings in `config profile` commands.
+const DefaultProfile = "default"
+
+// ProfileOverride selects a profile for this invocation (--profile),
+// taking precedence over LAEVITAS_PROFILE and current_profile.
+var ProfileOverride string
+
 // configDir returns ~/.config/laevitas/
 func configDir() (string, error) {
 	home, err := os.UserHomeDir()
@@ -54,22 +247,35 @@ func configPath() (string, error) {
 }
 
 // Load reads config from disk, falling back to defaults.
-// Environment variables override file values:
+// The active profile (--profile, LAEVITAS_PROFILE, or current_profile) is
+// applied on top of the top-level settings. Environment variables override
+// file values:
 //
 //	LAEVITAS_API_KEY, LAEVITAS_BASE_URL, LAEVITAS_EXCHANGE, LAEVITAS_OUTPUT
 func Load() (*Config, error) {
-	cfg := &Config{
-		BaseURL: DefaultBaseURL,
-		Output:  DefaultOutput,
+	cfg := readFile()
+	if err := resolveSecrets("", &cfg.Settings); err != nil {
+		return cfg, err
 	}
 
-	// Read file if it exists
-	path, err := configPath()
-	if err == nil {
-		data, readErr := os.ReadFile(path)
-		if readErr == nil {
-			_ = json.Unmarshal(data, cfg)
+	// Resolve the active profile
+	name := cfg.CurrentProfile
+	if v := os.Getenv("LAEVITAS_PROFILE"); v != "" {
+		name = v
+	}
+	if ProfileOverride != "" {
+		name = ProfileOverride
+	}
+	if name != "" && name != DefaultProfile {
+		p, ok := cfg.Profiles[name]
+		if !ok {
+			return cfg, fmt.Errorf("unknown profile: %s (see: laevitas config profile list)", name)
+		}
+		if err := resolveSecrets(name, &p); err != nil {
+			return cfg, err
 		}
+		cfg.Profile = name
+		cfg.Settings = mergeSettings(cfg.Settings, p)
 	}
 
 	// Env overrides
@@ -92,9 +298,158 @@ func Load() (*Config, error) {
 		cfg.Auth = v
 	}
 
+	cfg.loaded = cfg.Settings
 	return cfg, nil
 }
 
+// CheckFile returns the config file path and an error if the file can't be
+// read or isn't valid JSON. A missing file yields an os.IsNotExist error.
+func CheckFile() (string, error) {
+	path, err := configPath()
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return path, err
+	}
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return path, fmt.Errorf("invalid JSON: %w", err)
+	}
+	return path, nil
+}
+
+// readFile reads config.json without applying profiles or env overrides.
+func readFile() *Config {
+	cfg := &Config{
+		Settings: Settings{
+			BaseURL: DefaultBaseURL,
+			Output:  DefaultOutput,
+		},
+	}
+
+	// Read file if it exists
+	path, err := configPath()
+	if err == nil {
+		data, readErr := os.ReadFile(path)
+		if readErr == nil {
+			_ = json.Unmarshal(data, cfg)
+		}
+	}
+	return cfg
+}
+
+// mergeSettings returns base with every non-empty field of override
+// applied, and the fields override lists in Unset cleared.
+func mergeSettings(base, override Settings) Settings {
+	bv, ov := reflect.ValueOf(&base).Elem(), reflect.ValueOf(override)
+	for i := 0; i < ov.NumField(); i++ {
+		key := settingKey(ov.Type().Field(i))
+		if key == "unset" {
+			continue
+		}
+		switch {
+		case !ov.Field(i).IsZero():
+			bv.Field(i).Set(ov.Field(i))
+			base.Unset = slices.DeleteFunc(base.Unset, func(k string) bool { return k == key })
+		case slices.Contains(override.Unset, key):
+			bv.Field(i).SetZero()
+			if !slices.Contains(base.Unset, key) {
+				base.Unset = append(base.Unset, key)
+			}
+		}
+	}
+	return base
+}
+
+// applyChanges writes into raw — a profile's (or the top level's) settings
+// as stored in the file — the fields of cur that differ from loaded, i.e.
+// those changed since Load. Values inherited from the top level or taken
+// from LAEVITAS_* variables are left out. In a profile, a field changed to
+// its zero value is recorded in Unset so it stays cleared.
+func applyChanges(raw, loaded, cur Settings, profile bool) Settings {
+	rv, lv, cv := reflect.ValueOf(&raw).Elem(), reflect.ValueOf(loaded), reflect.ValueOf(cur)
+	for i := 0; i < cv.NumField(); i++ {
+		key := settingKey(cv.Type().Field(i))
+		if key == "unset" || reflect.DeepEqual(cv.Field(i).Interface(), lv.Field(i).Interface()) {
+			continue
+		}
+		rv.Field(i).Set(cv.Field(i))
+		raw.Unset = slices.DeleteFunc(raw.Unset, func(k string) bool { return k == key })
+		if profile && cv.Field(i).IsZero() {
+			raw.Unset = append(raw.Unset, key)
+		}
+	}
+	return raw
+}
+
+// settingKey is the config key of a Settings field: its JSON name.
+func settingKey(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	return name
+}
+
+// ─── Profiles ───────────────────────────────────────────────────────────────
+
+// ProfileNames returns the sorted profile names (including "default") and
+// the name of the current profile.
+func ProfileNames() (names []string, current string) {
+	cfg := readFile()
+	names = append(names, DefaultProfile)
+	for name := range cfg.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names[1:])
+
+	current = cfg.CurrentProfile
+	if current == "" {
+		current = DefaultProfile
+	}
+	return names, current
+}
+
+// UseProfile makes name the current profile, creating it (empty, so it
+// inherits the default settings) if it doesn't exist. Returns true if the
+// profile was created.
+func UseProfile(name string) (bool, error) {
+	cfg := readFile()
+	created := false
+	if name == DefaultProfile {
+		cfg.CurrentProfile = ""
+	} else {
+		if _, ok := cfg.Profiles[name]; !ok {
+			if cfg.Profiles == nil {
+				cfg.Profiles = make(map[string]Settings)
+			}
+			cfg.Profiles[name] = Settings{}
+			created = true
+		}
+		cfg.CurrentProfile = name
+	}
+	return created, writeFile(cfg)
+}
+
+// DeleteProfile removes a named profile. If it was current, the default
+// profile becomes current.
+func DeleteProfile(name string) error {
+	if name == DefaultProfile {
+		return fmt.Errorf("cannot delete the default profile")
+	}
+	cfg := readFile()
+	if _, ok := cfg.Profiles[name]; !ok {
+		return fmt.Errorf("unknown profile: %s", name)
+	}
+	delete(cfg.Profiles, name)
+	if cfg.Secrets == SecretsKeychain {
+		deleteSecrets(name)
+	}
+	if cfg.CurrentProfile == name {
+		cfg.CurrentProfile = ""
+	}
+	return writeFile(cfg)
+}
+
 // ─── Credit token storage (x402) ────────────────────────────────────────────
 
 const creditTokenFile = "x402-token"
@@ -124,17 +479,94 @@ func SaveCreditToken(token string) error {
 	return os.WriteFile(filepath.Join(dir, creditTokenFile), []byte(token), 0600)
 }
 
-// ClearCreditToken removes the cached x402 credit token.
+// ClearCreditToken removes the cached x402 credit token, and with it the
+// last-seen credit balance that belonged to it.
 func ClearCreditToken() {
 	dir, err := configDir()
 	if err != nil {
 		return
 	}
 	os.Remove(filepath.Join(dir, creditTokenFile))
+	os.Remove(filepath.Join(dir, creditsFile))
 }
 
-// Save writes config to disk.
+const creditsFile = "x402-credits"
+
+// CreditsSeen is the last x402 credit balance reported by the API.
+type CreditsSeen struct {
+	Remaining string    `json:"remaining"`
+	SeenAt    time.Time `json:"seen_at"`
+}
+
+// LoadCreditsSeen reads the last-seen credit balance from disk.
+// ok is false if none has been recorded.
+func LoadCreditsSeen() (seen CreditsSeen, ok bool) {
+	dir, err := configDir()
+	if err != nil {
+		return seen, false
+	}
+	data, err := os.ReadFile(filepath.Join(dir, creditsFile))
+	if err != nil || json.Unmarshal(data, &seen) != nil || seen.Remaining == "" {
+		return seen, false
+	}
+	return seen, true
+}
+
+// SaveCreditsSeen records the credit balance the API just reported.
+func SaveCreditsSeen(remaining string) error {
+	dir, err := configDir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(CreditsSeen{Remaining: remaining, SeenAt: time.Now().UTC()})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, creditsFile), data, 0600)
+}
+
+// Save writes the settings changed since Load to disk. When a profile is
+// active, they are stored in that profile and the top-level settings are
+// left untouched; values the profile inherits or that came from LAEVITAS_*
+// variables are never written. With the keychain secrets backend, api_key
+// and wallet_key are written to the OS keychain and only a reference is
+// kept in the file.
 func Save(cfg *Config) error {
+	file := readFile()
+	raw := file.Settings
+	if cfg.Profile != "" {
+		raw = file.Profiles[cfg.Profile]
+	}
+	raw = applyChanges(raw, cfg.loaded, cfg.Settings, cfg.Profile != "")
+
+	if file.Secrets == SecretsKeychain {
+		stored, err := storeSecrets(cfg.Profile, raw)
+		if err != nil {
+			return err
+		}
+		raw = stored
+	}
+
+	if cfg.Profile != "" {
+		if file.Profiles == nil {
+			file.Profiles = make(map[string]Settings)
+		}
+		file.Profiles[cfg.Profile] = raw
+	} else {
+		file.Settings = raw
+	}
+	if err := writeFile(file); err != nil {
+		return err
+	}
+	cfg.loaded = cfg.Settings
+	return nil
+}
+
+// writeFile writes config.json as-is.
+func writeFile(cfg *Config) error {
 	dir, err := configDir()
 	if err != nil {
 		return err
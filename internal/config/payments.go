@@ -0,0 +1,89 @@
+package config
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const paymentsFileName = "payments.log"
+
+// PaymentRecord is one on-chain x402 payment, stored as a line of JSON in
+// payments.log.
+type PaymentRecord struct {
+	Time        time.Time `json:"time"`
+	Endpoint    string    `json:"endpoint"`
+	AmountUSDC  string    `json:"amount_usdc"`
+	Wallet      string    `json:"wallet"`
+	Network     string    `json:"network,omitempty"`
+	Tx          string    `json:"tx,omitempty"`
+	CreditToken string    `json:"credit_token,omitempty"` // prefix only
+}
+
+// PaymentsPath returns the full path to payments.log.
+func PaymentsPath() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, paymentsFileName), nil
+}
+
+// AppendPayment adds a record to payments.log, creating it if needed.
+func AppendPayment(rec PaymentRecord) error {
+	path, err := PaymentsPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("cannot create config directory: %w", err)
+	}
+
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// LoadPayments reads payments.log, oldest first.
+// Returns no records if the file doesn't exist yet.
+func LoadPayments() ([]PaymentRecord, error) {
+	path, err := PaymentsPath()
+	if err != nil {
+		return nil, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []PaymentRecord
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var rec PaymentRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return records, fmt.Errorf("parsing %s line %d: %w", paymentsFileName, n, err)
+		}
+		records = append(records, rec)
+	}
+	return records, scanner.Err()
+}
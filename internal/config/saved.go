@@ -7,14 +7,18 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
+	"unicode"
 )
 
 const savedFileName = "saved.json"
 
 // SavedQuery represents a bookmarked command with an optional variable template.
 type SavedQuery struct {
-	Name    string `json:"name"`
-	Command string `json:"command"`
+	Name    string    `json:"name"`
+	Command string    `json:"command"`
+	Tags    []string  `json:"tags,omitempty"`
+	LastRun time.Time `json:"last_run,omitzero"`
 }
 
 // SavedQueries holds the on-disk collection of saved queries.
@@ -72,6 +76,29 @@ func SaveQueries(sq *SavedQueries) error {
 	return os.WriteFile(path, data, 0600)
 }
 
+// ReadSavedFile reads a saved-queries file written by WriteSavedFile
+// (e.g. one shared by a teammate).
+func ReadSavedFile(path string) (*SavedQueries, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	sq := &SavedQueries{}
+	if err := json.Unmarshal(data, sq); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return sq, nil
+}
+
+// WriteSavedFile writes saved queries to an arbitrary path for sharing.
+func WriteSavedFile(path string, sq *SavedQueries) error {
+	data, err := json.MarshalIndent(sq, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
 // Get finds a saved query by name (case-insensitive).
 func (sq *SavedQueries) Get(name string) *SavedQuery {
 	nameLower := strings.ToLower(name)
@@ -95,6 +122,23 @@ func (sq *SavedQueries) Add(name, command string) {
 	sq.Queries = append(sq.Queries, SavedQuery{Name: name, Command: command})
 }
 
+// SetTags replaces the tags of a saved query. Returns false if not found.
+func (sq *SavedQueries) SetTags(name string, tags []string) bool {
+	q := sq.Get(name)
+	if q == nil {
+		return false
+	}
+	q.Tags = tags
+	return true
+}
+
+// MarkRun records the current time as the last run of a saved query.
+func (sq *SavedQueries) MarkRun(name string) {
+	if q := sq.Get(name); q != nil {
+		q.LastRun = time.Now()
+	}
+}
+
 // Remove deletes a saved query by name. Returns true if found.
 func (sq *SavedQueries) Remove(name string) bool {
 	nameLower := strings.ToLower(name)
@@ -117,46 +161,229 @@ func (sq *SavedQueries) Names() []string {
 	return names
 }
 
-// Expand substitutes {variable} placeholders in a saved command with
-// positional arguments. Variables are replaced left-to-right.
-// Example: "perps funding {instrument} -r 1d" with args ["BTC-PERPETUAL"]
-// becomes "perps funding BTC-PERPETUAL -r 1d".
-func Expand(command string, args []string) string {
-	result := command
-	argIdx := 0
-	for argIdx < len(args) {
-		// Find next {placeholder}
-		start := strings.Index(result, "{")
-		if start < 0 {
-			break
+// SplitCommands splits a saved query into its sub-commands. A saved query
+// may hold several commands separated by ";" or newlines, run in order.
+// Separators inside single or double quotes (e.g. a --filter expression or
+// a piped shell command) don't split, as with the REPL's "|".
+func SplitCommands(command string) []string {
+	var cmds []string
+	add := func(part string) {
+		if part = strings.TrimSpace(part); part != "" {
+			cmds = append(cmds, part)
 		}
-		end := strings.Index(result[start:], "}")
-		if end < 0 {
-			break
+	}
+	quoteChar := byte(0)
+	start := 0
+	for i := 0; i < len(command); i++ {
+		ch := command[i]
+		switch {
+		case quoteChar != 0:
+			if ch == quoteChar {
+				quoteChar = 0
+			}
+		case ch == '"' || ch == '\'':
+			quoteChar = ch
+		case ch == ';' || ch == '\n':
+			add(command[start:i])
+			start = i + 1
+		}
+	}
+	add(command[start:])
+	return cmds
+}
+
+// Placeholder is a {variable} in a saved command. Placeholders are written
+// as {name}, {name=default}, or {} (anonymous, positional only).
+type Placeholder struct {
+	Name       string
+	Default    string
+	HasDefault bool
+}
+
+// Placeholders returns the distinct variables in a command, in order of first
+// appearance. A named variable used more than once is listed once; each
+// anonymous {} is its own variable.
+func Placeholders(command string) []Placeholder {
+	var vars []Placeholder
+	seen := make(map[string]bool)
+	forEachPlaceholder(command, func(p Placeholder) {
+		if p.Name != "" {
+			if seen[p.Name] {
+				return
+			}
+			seen[p.Name] = true
 		}
-		end += start
+		vars = append(vars, p)
+	})
+	return vars
+}
 
-		result = result[:start] + args[argIdx] + result[end+1:]
-		argIdx++
+// forEachPlaceholder calls fn for every placeholder in command, left to right.
+func forEachPlaceholder(command string, fn func(Placeholder)) {
+	s := command
+	for {
+		_, end, p, ok := nextPlaceholder(s)
+		if !ok {
+			return
+		}
+		fn(p)
+		s = s[end:]
 	}
-	return result
 }
 
-// CountPlaceholders returns the number of {variable} placeholders in a command.
-func CountPlaceholders(command string) int {
-	count := 0
+// nextPlaceholder finds the first placeholder in s and returns its bounds
+// (from "{" to just past "}") and parsed form. Only {}, {name} and
+// {name=default} with an identifier name count: Go template actions such
+// as {{.instrument_name}} (for -o template) and other literal braces are
+// left alone.
+func nextPlaceholder(s string) (start, end int, p Placeholder, ok bool) {
+	i := 0
+	for {
+		j := strings.IndexByte(s[i:], '{')
+		if j < 0 {
+			return 0, 0, Placeholder{}, false
+		}
+		start = i + j
+		if strings.HasPrefix(s[start:], "{{") {
+			k := strings.Index(s[start+2:], "}}")
+			if k < 0 {
+				return 0, 0, Placeholder{}, false
+			}
+			i = start + 2 + k + 2
+			continue
+		}
+		k := strings.IndexByte(s[start+1:], '}')
+		if k < 0 {
+			return 0, 0, Placeholder{}, false
+		}
+		end = start + 1 + k + 1
+		token := s[start+1 : end-1]
+		p = parsePlaceholder(token)
+		if token == "" || isPlaceholderName(p.Name) {
+			return start, end, p, true
+		}
+		i = start + 1
+	}
+}
+
+// isPlaceholderName reports whether name is an identifier: a letter or
+// underscore followed by letters, digits, underscores and hyphens.
+func isPlaceholderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		switch {
+		case r == '_' || unicode.IsLetter(r):
+		case i > 0 && (r == '-' || unicode.IsDigit(r)):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// parsePlaceholder parses the inside of a {...} token.
+func parsePlaceholder(token string) Placeholder {
+	name, def, hasDefault := strings.Cut(token, "=")
+	return Placeholder{
+		Name:       strings.TrimSpace(name),
+		Default:    strings.TrimSpace(def),
+		HasDefault: hasDefault,
+	}
+}
+
+// Expand substitutes {variable} placeholders in a saved command.
+// Arguments of the form name=value set a named variable; the remaining
+// arguments fill the other variables positionally, left to right. Variables
+// still unset fall back to their {name=default}. It returns an error listing
+// any variables that have neither a value nor a default.
+// Example: "perps carry {instrument} -r {resolution=1h}" with args
+// ["BTC-PERPETUAL"] becomes "perps carry BTC-PERPETUAL -r 1h".
+func Expand(command string, args []string) (string, error) {
+	vars := Placeholders(command)
+	names := make(map[string]bool)
+	for _, v := range vars {
+		if v.Name != "" {
+			names[v.Name] = true
+		}
+	}
+
+	// Named arguments first
+	named := make(map[string]string)
+	var positional []string
+	for _, arg := range args {
+		if k, v, ok := strings.Cut(arg, "="); ok && names[k] {
+			named[k] = v
+			continue
+		}
+		positional = append(positional, arg)
+	}
+
+	// Resolve each variable: named, positional, then default
+	values := make([]string, len(vars))
+	var missing []string
+	for i, v := range vars {
+		if val, ok := named[v.Name]; ok && v.Name != "" {
+			values[i] = val
+			continue
+		}
+		if len(positional) > 0 {
+			values[i] = positional[0]
+			positional = positional[1:]
+			continue
+		}
+		if v.HasDefault {
+			values[i] = v.Default
+			continue
+		}
+		if v.Name != "" {
+			missing = append(missing, v.Name)
+		} else {
+			missing = append(missing, fmt.Sprintf("#%d", i+1))
+		}
+	}
+	if len(missing) > 0 {
+		return command, fmt.Errorf("missing value for %s", strings.Join(missing, ", "))
+	}
+
+	// Substitute every occurrence
+	var b strings.Builder
 	s := command
+	idx := 0
+	byName := make(map[string]string)
+	for i, v := range vars {
+		if v.Name != "" {
+			byName[v.Name] = values[i]
+		}
+	}
 	for {
-		start := strings.Index(s, "{")
-		if start < 0 {
+		start, end, p, ok := nextPlaceholder(s)
+		if !ok {
 			break
 		}
-		end := strings.Index(s[start:], "}")
-		if end < 0 {
-			break
+
+		b.WriteString(s[:start])
+		if p.Name != "" {
+			b.WriteString(byName[p.Name])
+		} else {
+			// Anonymous placeholders consume values in order
+			for idx < len(vars) && vars[idx].Name != "" {
+				idx++
+			}
+			if idx < len(vars) {
+				b.WriteString(values[idx])
+				idx++
+			}
 		}
-		count++
-		s = s[start+end+1:]
+		s = s[end:]
 	}
-	return count
+	b.WriteString(s)
+	return b.String(), nil
+}
+
+// CountPlaceholders returns the number of distinct {variable} placeholders
+// in a command.
+func CountPlaceholders(command string) int {
+	return len(Placeholders(command))
 }
@@ -0,0 +1,35 @@
+package config
+
+import "testing"
+
+func TestExpandPlaceholders(t *testing.T) {
+	for _, tc := range []struct {
+		command string
+		args    []string
+		vars    int
+		want    string
+	}{
+		{"perps carry {instrument} -r {resolution=1h}", []string{"BTC-PERPETUAL"}, 2, "perps carry BTC-PERPETUAL -r 1h"},
+		{"perps carry {} -n {}", []string{"ETH-PERPETUAL", "5"}, 2, "perps carry ETH-PERPETUAL -n 5"},
+		{"predictions flow --event {event-slug}", []string{"event-slug=fed"}, 1, "predictions flow --event fed"},
+		// Go template actions and literal braces are not variables
+		{"perps carry BTC-PERPETUAL -o template --template '{{.instrument_name}} {{.value}}'", nil, 0,
+			"perps carry BTC-PERPETUAL -o template --template '{{.instrument_name}} {{.value}}'"},
+		{"perps carry {instrument} -o template --template '{{printf \"%.4f\" .funding_rate_close}}'", []string{"BTC-PERPETUAL"}, 1,
+			"perps carry BTC-PERPETUAL -o template --template '{{printf \"%.4f\" .funding_rate_close}}'"},
+		{`perps carry {instrument} --filter '{"a": 1}'`, []string{"X"}, 1, `perps carry X --filter '{"a": 1}'`},
+		{"perps carry {.bad} {1x}", nil, 0, "perps carry {.bad} {1x}"},
+	} {
+		if n := CountPlaceholders(tc.command); n != tc.vars {
+			t.Errorf("%q: %d variables, want %d", tc.command, n, tc.vars)
+		}
+		got, err := Expand(tc.command, tc.args)
+		if err != nil {
+			t.Errorf("%q: %v", tc.command, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("%q:\n got %q\nwant %q", tc.command, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/zalando/go-keyring"
+)
+
+// Secret backends for api_key and wallet_key ("secrets" config key).
+const (
+	SecretsFile     = "file"     // plaintext in config.json (default)
+	SecretsKeychain = "keychain" // OS keychain via go-keyring
+)
+
+const (
+	keychainService = "laevitas-cli"
+
+	// keychainRef replaces a secret in config.json when it lives in the keychain.
+	keychainRef = "<keychain>"
+)
+
+// keychainAccount returns the keychain account name for a profile's secret,
+// e.g. "default/api_key" or "staging/wallet_key".
+func keychainAccount(profile, key string) string {
+	if profile == "" {
+		profile = DefaultProfile
+	}
+	return profile + "/" + key
+}
+
+// resolveSecrets replaces keychain references in s with the stored secrets.
+// Plaintext values are left as-is, so a config.json copied to a machine
+// without the keychain entries keeps working if it carries the keys inline.
+func resolveSecrets(profile string, s *Settings) error {
+	for key, field := range map[string]*string{"api_key": &s.APIKey, "wallet_key": &s.WalletKey} {
+		if *field != keychainRef {
+			continue
+		}
+		v, err := keyring.Get(keychainService, keychainAccount(profile, key))
+		if err != nil {
+			return fmt.Errorf("reading %s from keychain: %w", key, err)
+		}
+		*field = v
+	}
+	return nil
+}
+
+// storeSecrets moves the secrets in s into the keychain and returns a copy
+// holding references instead. Empty secrets are removed from the keychain.
+func storeSecrets(profile string, s Settings) (Settings, error) {
+	for key, field := range map[string]*string{"api_key": &s.APIKey, "wallet_key": &s.WalletKey} {
+		account := keychainAccount(profile, key)
+		switch *field {
+		case keychainRef:
+			// Already stored
+		case "":
+			if err := keyring.Delete(keychainService, account); err != nil && !errors.Is(err, keyring.ErrNotFound) {
+				return s, fmt.Errorf("removing %s from keychain: %w", key, err)
+			}
+		default:
+			if err := keyring.Set(keychainService, account, *field); err != nil {
+				return s, fmt.Errorf("storing %s in keychain: %w", key, err)
+			}
+			*field = keychainRef
+		}
+	}
+	return s, nil
+}
+
+// deleteSecrets removes a profile's secrets from the keychain (best effort).
+func deleteSecrets(profile string) {
+	keyring.Delete(keychainService, keychainAccount(profile, "api_key"))
+	keyring.Delete(keychainService, keychainAccount(profile, "wallet_key"))
+}
+
+// SetSecretsBackend switches where api_key and wallet_key are stored and
+// migrates the existing secrets of every profile to the new backend.
+func SetSecretsBackend(backend string) error {
+	if backend != SecretsFile && backend != SecretsKeychain {
+		return fmt.Errorf("invalid secrets backend: %s (valid: %s, %s)", backend, SecretsFile, SecretsKeychain)
+	}
+
+	cfg := readFile()
+	if cfg.Secrets == backend || (cfg.Secrets == "" && backend == SecretsFile) {
+		return nil
+	}
+
+	// Resolve everything from the current backend first
+	if err := resolveSecrets("", &cfg.Settings); err != nil {
+		return err
+	}
+	for name, p := range cfg.Profiles {
+		if err := resolveSecrets(name, &p); err != nil {
+			return err
+		}
+		cfg.Profiles[name] = p
+	}
+
+	if backend == SecretsKeychain {
+		s, err := storeSecrets("", cfg.Settings)
+		if err != nil {
+			return err
+		}
+		cfg.Settings = s
+		for name, p := range cfg.Profiles {
+			if p, err = storeSecrets(name, p); err != nil {
+				return err
+			}
+			cfg.Profiles[name] = p
+		}
+		cfg.Secrets = SecretsKeychain
+		return writeFile(cfg)
+	}
+
+	// Back to plaintext: only clean up the keychain once the file holds the
+	// keys — if the write fails, the keychain copies are all that's left
+	cfg.Secrets = ""
+	if err := writeFile(cfg); err != nil {
+		return err
+	}
+	deleteSecrets("")
+	for name := range cfg.Profiles {
+		deleteSecrets(name)
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// ─── Export / import ────────────────────────────────────────────────────────
+//
+// `config export` writes the whole config.json — every profile, aliases and
+// color rules — to a file in the same format; `config import` merges such a
+// file into the local config. The secrets backend is not carried over: it
+// belongs to the machine, and imported keys are stored the local way.
+
+// ExportConfig returns the configuration for export with keychain
+// references resolved. Unless includeSecrets is set, api_key and
+// wallet_key are left out. Defaults filled in by readFile are dropped so
+// they don't override the importing machine's settings.
+func ExportConfig(includeSecrets bool) (*Config, error) {
+	cfg := readFile()
+	cfg.Secrets = ""
+	if cfg.BaseURL == DefaultBaseURL {
+		cfg.BaseURL = ""
+	}
+	if cfg.Output == DefaultOutput {
+		cfg.Output = ""
+	}
+
+	export := func(profile string, s Settings) (Settings, error) {
+		if !includeSecrets {
+			s.APIKey, s.WalletKey = "", ""
+			return s, nil
+		}
+		err := resolveSecrets(profile, &s)
+		return s, err
+	}
+
+	var err error
+	if cfg.Settings, err = export("", cfg.Settings); err != nil {
+		return nil, err
+	}
+	for name, p := range cfg.Profiles {
+		if cfg.Profiles[name], err = export(name, p); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+// ReadConfigFile reads a file written by WriteConfigFile (or a copy of
+// another machine's config.json) and checks its settings.
+func ReadConfigFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &Config{}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if err := validateSettings(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for name, p := range cfg.Profiles {
+		if err := validateSettings(p); err != nil {
+			return nil, fmt.Errorf("%s: profile %s: %w", path, name, err)
+		}
+	}
+	for name := range cfg.Aliases {
+		if err := ValidateAliasName(name); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return cfg, nil
+}
+
+// WriteConfigFile writes an exported configuration to path. It may hold
+// keys, so it is only readable by the owner.
+func WriteConfigFile(path string, cfg *Config) error {
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0600)
+}
+
+// ImportConfig merges in into the config file. Every non-empty setting of
+// in — top-level and per profile — replaces the local value, so keys left
+// out of a redacted export keep their local values. Aliases and color rules
+// are merged by name; current_profile is only taken when none is set.
+func ImportConfig(in *Config) error {
+	cfg := readFile()
+
+	cfg.Settings = mergeSettings(cfg.Settings, importable(in.Settings))
+	for name, p := range in.Profiles {
+		if cfg.Profiles == nil {
+			cfg.Profiles = make(map[string]Settings)
+		}
+		cfg.Profiles[name] = mergeSettings(cfg.Profiles[name], importable(p))
+	}
+	if cfg.CurrentProfile == "" {
+		cfg.CurrentProfile = in.CurrentProfile
+	}
+	for name, command := range in.Aliases {
+		if cfg.Aliases == nil {
+			cfg.Aliases = make(map[string]string)
+		}
+		cfg.Aliases[name] = command
+	}
+	for column, rules := range in.ColorRules {
+		if cfg.ColorRules == nil {
+			cfg.ColorRules = make(map[string]map[string]string)
+		}
+		cfg.ColorRules[column] = rules
+	}
+
+	if cfg.Secrets == SecretsKeychain {
+		s, err := storeSecrets("", cfg.Settings)
+		if err != nil {
+			return err
+		}
+		cfg.Settings = s
+		for name, p := range cfg.Profiles {
+			if p, err = storeSecrets(name, p); err != nil {
+				return err
+			}
+			cfg.Profiles[name] = p
+		}
+	}
+	return writeFile(cfg)
+}
+
+// importable drops keychain references, which point into the exporting
+// machine's keychain and mean nothing here.
+func importable(s Settings) Settings {
+	if s.APIKey == keychainRef {
+		s.APIKey = ""
+	}
+	if s.WalletKey == keychainRef {
+		s.WalletKey = ""
+	}
+	return s
+}
+
+// validateSettings checks the settings `config set` would have rejected.
+func validateSettings(s Settings) error {
+	if s.Exchange != "" {
+		if _, err := NormalizeExchange(s.Exchange); err != nil {
+			return err
+		}
+	}
+	if s.Output != "" {
+		if _, err := NormalizeOutput(s.Output); err != nil {
+			return err
+		}
+	}
+	if s.BaseURL != "" {
+		if _, err := NormalizeBaseURL(s.BaseURL); err != nil {
+			return err
+		}
+	}
+	if s.RetryOn != "" {
+		if _, err := ParseRetryOn(s.RetryOn); err != nil {
+			return err
+		}
+	}
+	switch s.Auth {
+	case "", AuthTypeAuto, AuthTypeAPIKey, AuthTypeX402:
+	default:
+		return fmt.Errorf("invalid auth type: %s (valid: auto, api-key, x402)", s.Auth)
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"strconv"
 	"strings"
 
@@ -11,17 +12,32 @@ import (
 )
 
 const (
-	chartWidth  = 60
-	chartHeight = 15
+	defaultChartWidth  = 60
+	defaultChartHeight = 15
+
+	// chartLabelMargin approximates the y-axis label column, which asciigraph
+	// draws in addition to the plot width.
+	chartLabelMargin = 12
+)
+
+// ChartWidth and ChartHeight set the plot size (--chart-width, --chart-height).
+// A ChartWidth of 0 fills the terminal width.
+var (
+	ChartWidth  = defaultChartWidth
+	ChartHeight = defaultChartHeight
 )
 
+// ChartMA is the moving-average period overlaid on charts (--chart-ma).
+// 0 disables the overlay.
+var ChartMA int
+
 // chartColumnMap maps API endpoint keywords to the column name to plot.
 // The key is a substring that appears in the endpoint path.
 var chartColumnMap = map[string]struct {
 	column  string
 	caption string
 }{
-	"ohlcv":         {column: "close", caption: "Close Price"},
+	"ohlcv":         {column: "close", caption: "Price"},
 	"funding":       {column: "funding_rate_close", caption: "Funding Rate"},
 	"open-interest": {column: "oi_close", caption: "Open Interest"},
 	"basis":         {column: "annualized_carry", caption: "Annualized Carry"},
@@ -39,6 +55,42 @@ func ChartableEndpoint(endpoint string) (column, caption string) {
 	return "", ""
 }
 
+// CandlestickEndpoint reports whether the endpoint returns OHLC candles
+// that should be drawn as a candlestick chart rather than a close line.
+func CandlestickEndpoint(endpoint string) bool {
+	return strings.Contains(endpoint, "ohlcv")
+}
+
+// RenderCandlestick renders OHLC data as an ASCII candlestick chart, falling
+// back to a line chart of close prices if any of the open/high/low/close
+// columns is missing. Either way the chart is labeled caption.
+func RenderCandlestick(w io.Writer, data []byte, caption string) {
+	if !renderCandlestick(w, ExtractRecords(data), caption) {
+		RenderChart(w, data, "close", caption)
+	}
+}
+
+// CheckChartColumn verifies that column exists in the records and holds
+// numeric values, so a user-chosen chart series can be reported if unusable.
+func CheckChartColumn(data []byte, column string) error {
+	records := ExtractRecords(data)
+	found := false
+	for _, rec := range records {
+		v, ok := rec[column]
+		if !ok {
+			continue
+		}
+		found = true
+		if _, ok := ToFloat(v); ok {
+			return nil
+		}
+	}
+	if !found {
+		return fmt.Errorf("column %q not found", column)
+	}
+	return fmt.Errorf("column %q is not numeric", column)
+}
+
 // RenderChart extracts a numeric series from raw JSON data and renders
 // an ASCII line chart. It writes the chart to w.
 // Returns silently if the data doesn't contain the target column or has
@@ -59,11 +111,24 @@ func RenderChart(w io.Writer, data []byte, column, caption string) {
 		}
 	}
 
-	graph := asciigraph.Plot(values,
-		asciigraph.Width(chartWidth),
-		asciigraph.Height(chartHeight),
+	width, height := chartSize()
+	series := [][]float64{values}
+	colors := []asciigraph.AnsiColor{color}
+	if ChartMA > 0 {
+		if ChartMA < len(values) {
+			series = append(series, movingAverage(values, ChartMA))
+			colors = append(colors, asciigraph.Yellow)
+			caption = fmt.Sprintf("%s (MA %d)", caption, ChartMA)
+		} else {
+			Warnf("--chart-ma %d exceeds the %d data points; skipping moving average", ChartMA, len(values))
+		}
+	}
+
+	graph := asciigraph.PlotMany(series,
+		asciigraph.Width(width),
+		asciigraph.Height(height),
 		asciigraph.Caption(caption),
-		asciigraph.SeriesColors(color),
+		asciigraph.SeriesColors(colors...),
 		asciigraph.CaptionColor(asciigraph.White),
 		asciigraph.AxisColor(asciigraph.DarkGray),
 		asciigraph.LabelColor(asciigraph.DarkGray),
@@ -73,9 +138,237 @@ func RenderChart(w io.Writer, data []byte, column, caption string) {
 	fmt.Fprintln(w, graph)
 }
 
+// sparkBlocks are the eight block heights used by Sparkline, lowest first.
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders values as a one-line trend of Unicode block characters,
+// scaled between the series minimum and maximum. A flat series renders at
+// mid height.
+func Sparkline(values []float64) string {
+	if len(values) == 0 {
+		return ""
+	}
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+	var b strings.Builder
+	for _, v := range values {
+		idx := len(sparkBlocks) / 2
+		if hi > lo {
+			idx = int(math.Round((v - lo) / (hi - lo) * float64(len(sparkBlocks)-1)))
+		}
+		b.WriteRune(sparkBlocks[idx])
+	}
+	return b.String()
+}
+
+// RenderSparkline writes a one-line summary sparkline of column across all
+// records, with its first, last, min and max values (--spark).
+func RenderSparkline(w io.Writer, data []byte, column string) {
+	values := extractSeries(data, column)
+	if len(values) < 2 {
+		return
+	}
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+	format := func(v float64) string {
+		return formatNumber(strconv.FormatFloat(v, 'f', -1, 64))
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "%s  %s  %s\n",
+		headerStyle.Render(column),
+		Sparkline(values),
+		dimStyle.Render(fmt.Sprintf("%s → %s  (min %s, max %s)", format(values[0]), format(values[len(values)-1]), format(lo), format(hi))))
+}
+
+// chartSize returns the plot width and height, resolving ChartWidth 0 to the
+// terminal width (or the default when the width is unknown).
+func chartSize() (width, height int) {
+	width, height = ChartWidth, ChartHeight
+	if width == 0 {
+		width = defaultChartWidth
+		if tw := getTerminalWidth(); tw > chartLabelMargin+defaultChartWidth/2 {
+			width = tw - chartLabelMargin
+		}
+	}
+	if height < 2 {
+		height = defaultChartHeight
+	}
+	return width, height
+}
+
+// movingAverage returns the n-period simple moving average of values,
+// aligned to values: the first n-1 points are NaN (not plotted).
+func movingAverage(values []float64, n int) []float64 {
+	ma := make([]float64, len(values))
+	sum := 0.0
+	for i, v := range values {
+		sum += v
+		if i >= n {
+			sum -= values[i-n]
+		}
+		if i < n-1 {
+			ma[i] = math.NaN()
+		} else {
+			ma[i] = sum / float64(n)
+		}
+	}
+	return ma
+}
+
+// candle is a single OHLC period.
+type candle struct {
+	open, high, low, close float64
+}
+
+// renderCandlestick draws one column per candle (body ┃, wick │), green for
+// up periods and red for down periods, with the --chart-ma moving average of
+// closes dotted in yellow between them. When there are more candles than
+// the chart width, adjacent candles are merged. Returns false if the records
+// don't carry all four OHLC columns.
+func renderCandlestick(w io.Writer, records []map[string]interface{}, caption string) bool {
+	candles := make([]candle, 0, len(records))
+	for _, rec := range records {
+		o, ok1 := ToFloat(rec["open"])
+		h, ok2 := ToFloat(rec["high"])
+		l, ok3 := ToFloat(rec["low"])
+		c, ok4 := ToFloat(rec["close"])
+		if !ok1 || !ok2 || !ok3 || !ok4 {
+			return false
+		}
+		candles = append(candles, candle{o, h, l, c})
+	}
+	if len(candles) < 2 {
+		// Too few points to chart — same as the line chart
+		return true
+	}
+	width, height := chartSize()
+
+	// The average runs over every period; each merged candle shows its
+	// value at the candle's last period.
+	var ma []float64
+	if ChartMA > 0 {
+		if ChartMA < len(candles) {
+			closes := make([]float64, len(candles))
+			for i, c := range candles {
+				closes[i] = c.close
+			}
+			full := movingAverage(closes, ChartMA)
+			per := max((len(candles)+width-1)/width, 1)
+			for end := per; end < len(candles)+per; end += per {
+				ma = append(ma, full[min(end, len(candles))-1])
+			}
+			caption = fmt.Sprintf("%s (MA %d)", caption, ChartMA)
+		} else {
+			Warnf("--chart-ma %d exceeds the %d data points; skipping moving average", ChartMA, len(candles))
+		}
+	}
+	candles = mergeCandles(candles, width)
+
+	lo, hi := candles[0].low, candles[0].high
+	for _, c := range candles {
+		lo = math.Min(lo, c.low)
+		hi = math.Max(hi, c.high)
+	}
+	scale := (hi - lo) / float64(height-1)
+	rowOf := func(v float64) int {
+		if scale == 0 {
+			return height / 2
+		}
+		return int(math.Round((hi - v) / scale))
+	}
+
+	// Leave a gap between candles when there is room for it
+	gap := ""
+	if len(candles)*2 <= width {
+		gap = " "
+	}
+
+	precision := 2
+	if hi-lo < 1 {
+		precision = 6
+	}
+	labelWidth := len(strconv.FormatFloat(hi, 'f', precision, 64))
+	if lw := len(strconv.FormatFloat(lo, 'f', precision, 64)); lw > labelWidth {
+		labelWidth = lw
+	}
+
+	fmt.Fprintln(w)
+	for row := 0; row < height; row++ {
+		var b strings.Builder
+		label := hi - float64(row)*scale
+		fmt.Fprintf(&b, "%s%*.*f ┤%s", Dim, labelWidth, precision, label, Reset)
+		for i, c := range candles {
+			bodyTop, bodyBottom := rowOf(math.Max(c.open, c.close)), rowOf(math.Min(c.open, c.close))
+			color := Green
+			if c.close < c.open {
+				color = Red
+			}
+			switch {
+			case row >= bodyTop && row <= bodyBottom:
+				b.WriteString(color + "┃" + Reset)
+			case row >= rowOf(c.high) && row <= rowOf(c.low):
+				b.WriteString(color + "│" + Reset)
+			case ma != nil && !math.IsNaN(ma[i]) && rowOf(ma[i]) == row:
+				b.WriteString(Yellow + "•" + Reset)
+			default:
+				b.WriteString(" ")
+			}
+			b.WriteString(gap)
+		}
+		fmt.Fprintln(w, strings.TrimRight(b.String(), " "))
+	}
+	if caption != "" {
+		fmt.Fprintf(w, "%*s%s\n", labelWidth+3, "", caption)
+	}
+	return true
+}
+
+// mergeCandles combines adjacent candles so that at most max remain.
+func mergeCandles(candles []candle, max int) []candle {
+	if len(candles) <= max {
+		return candles
+	}
+	per := (len(candles) + max - 1) / max
+	merged := make([]candle, 0, max)
+	for i := 0; i < len(candles); i += per {
+		end := i + per
+		if end > len(candles) {
+			end = len(candles)
+		}
+		m := candles[i]
+		for _, c := range candles[i+1 : end] {
+			m.high = math.Max(m.high, c.high)
+			m.low = math.Min(m.low, c.low)
+			m.close = c.close
+		}
+		merged = append(merged, m)
+	}
+	return merged
+}
+
 // extractSeries parses raw JSON (expected to be an array of objects)
 // and extracts float64 values from the given column name.
 func extractSeries(data []byte, column string) []float64 {
+	records := ExtractRecords(data)
+	values := make([]float64, 0, len(records))
+	for _, rec := range records {
+		if f, ok := ToFloat(rec[column]); ok {
+			values = append(values, f)
+		}
+	}
+	return values
+}
+
+// ExtractRecords parses an API response as an array of objects, unwrapping
+// a { "data": [...] } envelope if present. It returns nil when the body is
+// neither, so callers can treat malformed and empty responses alike.
+func ExtractRecords(data []byte) []map[string]interface{} {
 	// Try parsing as array of objects directly
 	var records []map[string]interface{}
 	if err := json.Unmarshal(data, &records); err != nil {
@@ -90,21 +383,20 @@ func extractSeries(data []byte, column string) []float64 {
 			return nil
 		}
 	}
+	return records
+}
 
-	values := make([]float64, 0, len(records))
-	for _, rec := range records {
-		v, ok := rec[column]
-		if !ok {
-			continue
-		}
-		switch val := v.(type) {
-		case float64:
-			values = append(values, val)
-		case string:
-			if f, err := strconv.ParseFloat(val, 64); err == nil {
-				values = append(values, f)
-			}
+// ToFloat converts a decoded JSON value to float64. Numbers and numeric
+// strings (the API sends some decimals as strings) convert; anything else,
+// including nil for a missing field, reports false.
+func ToFloat(v interface{}) (float64, bool) {
+	switch val := v.(type) {
+	case float64:
+		return val, true
+	case string:
+		if f, err := strconv.ParseFloat(val, 64); err == nil {
+			return f, true
 		}
 	}
-	return values
+	return 0, false
 }
@@ -0,0 +1,108 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ColorRule colors table cells of one column that match a threshold, e.g.
+// iv > 100 in red. Rules come from the color_rules config key.
+type ColorRule struct {
+	Filter
+	Color string
+	style lipgloss.Style
+}
+
+// ColorRules are applied to table cells on top of the built-in coloring.
+var ColorRules []ColorRule
+
+// ruleColors maps color names to ANSI colors; 0-255 are accepted as-is.
+var ruleColors = map[string]string{
+	"black": "0", "red": "1", "green": "2", "yellow": "3",
+	"blue": "4", "magenta": "5", "cyan": "6", "white": "7",
+	"gray": "245", "grey": "245", "orange": "208",
+}
+
+// ParseColorRules turns the color_rules config value — column name to
+// {"OP value": "color"} — into rules, e.g.
+//
+//	{"iv": {">100": "red"}, "days_to_expiry": {"<7": "yellow"}}
+//
+// Rules are ordered by column then condition so the first match is stable.
+func ParseColorRules(spec map[string]map[string]string) ([]ColorRule, error) {
+	columns := make([]string, 0, len(spec))
+	for col := range spec {
+		columns = append(columns, col)
+	}
+	sort.Strings(columns)
+
+	var rules []ColorRule
+	for _, col := range columns {
+		conds := make([]string, 0, len(spec[col]))
+		for cond := range spec[col] {
+			conds = append(conds, cond)
+		}
+		sort.Strings(conds)
+
+		for _, cond := range conds {
+			f, err := ParseFilter(col + strings.TrimSpace(cond))
+			if err != nil {
+				return nil, fmt.Errorf("invalid color rule %s %q: use OP value with OP one of > < >= <= == !=", col, cond)
+			}
+			name := strings.ToLower(strings.TrimSpace(spec[col][cond]))
+			color, ok := ruleColors[name]
+			if !ok {
+				if n, err := strconv.Atoi(name); err == nil && n >= 0 && n <= 255 {
+					color, ok = name, true
+				}
+			}
+			if !ok {
+				return nil, fmt.Errorf("invalid color %q for %s %s (use red, green, yellow, blue, magenta, cyan, white, gray, orange or 0-255)", spec[col][cond], col, cond)
+			}
+			rules = append(rules, ColorRule{
+				Filter: f,
+				Color:  name,
+				style:  lipgloss.NewStyle().Foreground(lipgloss.Color(color)),
+			})
+		}
+	}
+	return rules, nil
+}
+
+// columnRules returns the rules that apply to each column of headers.
+func columnRules(headers []string) [][]ColorRule {
+	if len(ColorRules) == 0 {
+		return nil
+	}
+	byCol := make([][]ColorRule, len(headers))
+	for c, h := range headers {
+		for _, r := range ColorRules {
+			if strings.EqualFold(r.Column, h) {
+				byCol[c] = append(byCol[c], r)
+			}
+		}
+	}
+	return byCol
+}
+
+// matchColorRule returns the style of the first rule matching cell.
+func matchColorRule(rules []ColorRule, cell string) (lipgloss.Style, bool) {
+	for _, r := range rules {
+		if cell != "" && r.Matches(cell) {
+			return r.style, true
+		}
+	}
+	return lipgloss.Style{}, false
+}
+
+// ruleFor returns column c's rules from columnRules, which may be nil.
+func ruleFor(byCol [][]ColorRule, c int) []ColorRule {
+	if c < len(byCol) {
+		return byCol[c]
+	}
+	return nil
+}
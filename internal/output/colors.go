@@ -2,7 +2,10 @@ package output
 
 import (
 	"os"
+	"sync"
 
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 	"golang.org/x/term"
 )
 
@@ -34,7 +37,37 @@ func Colorize(text, color string, noColor bool) string {
 	return color + text + Reset
 }
 
+// NoColor turns off colored output (--no-color). The NO_COLOR environment
+// variable does the same.
+var NoColor bool
+
+// detectedProfile is the color profile lipgloss picked for the terminal,
+// restored when --no-color is turned back off.
+var detectedProfile = sync.OnceValue(lipgloss.ColorProfile)
+
+// SetNoColor sets NoColor and the color profile lipgloss renders tables
+// and charts with.
+func SetNoColor(on bool) {
+	NoColor = on
+	profile := detectedProfile()
+	if on {
+		profile = termenv.Ascii
+	}
+	lipgloss.SetColorProfile(profile)
+}
+
+// ColorEnabled reports whether stdout should get ANSI colors: it is a
+// terminal and neither --no-color nor NO_COLOR is set.
+func ColorEnabled() bool {
+	return !NoColor && os.Getenv("NO_COLOR") == "" && IsTTY()
+}
+
+// Terminal is the file whose terminal-ness decides auto format, colors and
+// table width. It is os.Stdout, except while the REPL tees stdout into a
+// transcript and os.Stdout is a pipe standing in for it.
+var Terminal = os.Stdout
+
 // IsTTY returns true if stdout is an interactive terminal.
 func IsTTY() bool {
-	return term.IsTerminal(int(os.Stdout.Fd()))
+	return term.IsTerminal(int(Terminal.Fd()))
 }
@@ -0,0 +1,71 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Envelope wraps JSON output as {"data": [...], "count": N, "next_cursor": ...}
+// so scripts can page from stdout alone (--meta). next_cursor is null on
+// the last page.
+var Envelope bool
+
+// printEnvelope prints data inside the --meta wrapper. When the API already
+// sent a wrapper object its other fields (meta, ...) are kept as they were.
+func (p *Printer) printEnvelope(data interface{}) error {
+	raw, ok := data.([]byte)
+	if !ok {
+		b, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+		raw = b
+	}
+
+	obj := map[string]json.RawMessage{}
+	trimmed := bytes.TrimSpace(raw)
+	switch {
+	case len(trimmed) > 0 && trimmed[0] == '{':
+		if err := json.Unmarshal(trimmed, &obj); err != nil {
+			return fmt.Errorf("parsing response: %w", err)
+		}
+		if _, ok := obj["data"]; !ok {
+			obj = map[string]json.RawMessage{"data": trimmed}
+		}
+	default:
+		obj["data"] = trimmed
+	}
+
+	if TransformsActive() {
+		records, err := p.transformedRecords(raw)
+		if err != nil {
+			return err
+		}
+		b, err := json.Marshal(records)
+		if err != nil {
+			return err
+		}
+		obj["data"] = b
+		obj["count"] = json.RawMessage(fmt.Sprint(len(records)))
+	} else if _, ok := obj["count"]; !ok {
+		var rows []json.RawMessage
+		if json.Unmarshal(obj["data"], &rows) == nil {
+			obj["count"] = json.RawMessage(fmt.Sprint(len(rows)))
+		} else {
+			obj["count"] = json.RawMessage("1")
+		}
+	}
+
+	if _, ok := obj["next_cursor"]; !ok {
+		obj["next_cursor"] = json.RawMessage("null")
+		var meta struct {
+			NextCursor string `json:"next_cursor"`
+		}
+		if json.Unmarshal(obj["meta"], &meta) == nil && meta.NextCursor != "" {
+			b, _ := json.Marshal(meta.NextCursor)
+			obj["next_cursor"] = b
+		}
+	}
+	return p.printJSON(obj)
+}
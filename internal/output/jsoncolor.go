@@ -0,0 +1,86 @@
+package output
+
+import (
+	"bytes"
+	"io"
+	"os"
+)
+
+// JSON syntax colors for terminal output.
+const (
+	jsonKeyColor    = Cyan
+	jsonStringColor = Green
+	jsonNumberColor = Yellow
+	jsonBoolColor   = "\033[35m" // magenta
+	jsonNullColor   = Dim
+)
+
+// writeJSON writes encoded JSON to p.Writer, syntax-highlighted when it is
+// the terminal and color is enabled.
+func (p *Printer) writeJSON(data []byte) error {
+	if p.Writer == io.Writer(os.Stdout) && ColorEnabled() {
+		data = colorizeJSON(data)
+	}
+	_, err := p.Writer.Write(data)
+	return err
+}
+
+// colorizeJSON adds ANSI colors to valid, already-indented JSON: object
+// keys, strings, numbers, booleans and null. Punctuation and whitespace
+// are copied as-is.
+func colorizeJSON(data []byte) []byte {
+	var b bytes.Buffer
+	b.Grow(len(data) * 2)
+	for i := 0; i < len(data); {
+		c := data[i]
+		switch {
+		case c == '"':
+			end := i + 1
+			for end < len(data) && data[end] != '"' {
+				if data[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			end = min(end+1, len(data))
+
+			// A string followed by ':' is a key
+			next := end
+			for next < len(data) && (data[next] == ' ' || data[next] == '\n' || data[next] == '\t' || data[next] == '\r') {
+				next++
+			}
+			color := jsonStringColor
+			if next < len(data) && data[next] == ':' {
+				color = jsonKeyColor
+			}
+			writeColored(&b, data[i:end], color)
+			i = end
+		case c == '-' || (c >= '0' && c <= '9'):
+			end := i + 1
+			for end < len(data) && bytes.IndexByte([]byte("0123456789.eE+-"), data[end]) >= 0 {
+				end++
+			}
+			writeColored(&b, data[i:end], jsonNumberColor)
+			i = end
+		case bytes.HasPrefix(data[i:], []byte("true")):
+			writeColored(&b, data[i:i+4], jsonBoolColor)
+			i += 4
+		case bytes.HasPrefix(data[i:], []byte("false")):
+			writeColored(&b, data[i:i+5], jsonBoolColor)
+			i += 5
+		case bytes.HasPrefix(data[i:], []byte("null")):
+			writeColored(&b, data[i:i+4], jsonNullColor)
+			i += 4
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return b.Bytes()
+}
+
+func writeColored(b *bytes.Buffer, token []byte, color string) {
+	b.WriteString(color)
+	b.Write(token)
+	b.WriteString(Reset)
+}
@@ -0,0 +1,86 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// ─── Locale ─────────────────────────────────────────────────────────────────
+
+// Locale is the language numbers, the table footer and month names are
+// formatted for (--locale). JSON and CSV output are not localized.
+var Locale = language.English
+
+// decimalSep is Locale's decimal separator, used by --humanize.
+var decimalSep = "."
+
+// SetLocale selects the locale from a BCP 47 tag such as de, fr-FR or pt-BR.
+func SetLocale(tag string) error {
+	t := language.English
+	if tag != "" {
+		var err error
+		if t, err = language.Parse(tag); err != nil {
+			return fmt.Errorf("invalid --locale %q: not a BCP 47 language tag", tag)
+		}
+	}
+	Locale = t
+	numberPrinter = message.NewPrinter(t)
+	decimalSep = strings.Trim(numberPrinter.Sprintf("%.1f", 1.5), "15")
+	return nil
+}
+
+// dateNames are abbreviated month and weekday names for the languages with
+// a translation; anything else keeps Go's English names.
+type dateNames struct {
+	months [12]string
+	days   [7]string // Sunday first, like time.Weekday
+}
+
+var localDateNames = map[string]dateNames{
+	"de": {
+		[12]string{"Jan", "Feb", "Mär", "Apr", "Mai", "Jun", "Jul", "Aug", "Sep", "Okt", "Nov", "Dez"},
+		[7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+	},
+	"fr": {
+		[12]string{"janv", "févr", "mars", "avr", "mai", "juin", "juil", "août", "sept", "oct", "nov", "déc"},
+		[7]string{"dim", "lun", "mar", "mer", "jeu", "ven", "sam"},
+	},
+	"es": {
+		[12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"},
+		[7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+	},
+	"it": {
+		[12]string{"gen", "feb", "mar", "apr", "mag", "giu", "lug", "ago", "set", "ott", "nov", "dic"},
+		[7]string{"dom", "lun", "mar", "mer", "gio", "ven", "sab"},
+	},
+	"pt": {
+		[12]string{"jan", "fev", "mar", "abr", "mai", "jun", "jul", "ago", "set", "out", "nov", "dez"},
+		[7]string{"dom", "seg", "ter", "qua", "qui", "sex", "sáb"},
+	},
+	"nl": {
+		[12]string{"jan", "feb", "mrt", "apr", "mei", "jun", "jul", "aug", "sep", "okt", "nov", "dec"},
+		[7]string{"zo", "ma", "di", "wo", "do", "vr", "za"},
+	},
+}
+
+// formatDate is t.Format with the month and weekday names ("Jan", "Mon")
+// in layout translated for Locale.
+func formatDate(t time.Time, layout string) string {
+	s := t.Format(layout)
+	base, _ := Locale.Base()
+	names, ok := localDateNames[base.String()]
+	if !ok {
+		return s
+	}
+	if strings.Contains(layout, "Jan") {
+		s = strings.Replace(s, t.Month().String()[:3], names.months[t.Month()-1], 1)
+	}
+	if strings.Contains(layout, "Mon") {
+		s = strings.Replace(s, t.Weekday().String()[:3], names.days[t.Weekday()], 1)
+	}
+	return s
+}
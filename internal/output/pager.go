@@ -0,0 +1,75 @@
+package output
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+var (
+	// NoPager disables paging of long table output (--no-pager).
+	NoPager bool
+
+	// PagerCommand is the pager from the "pager" config key. Empty falls
+	// back to $PAGER, then "less". "off" or "cat" disables paging.
+	PagerCommand string
+)
+
+// pagerCommand resolves the pager to run, or "" when paging is off.
+func pagerCommand() string {
+	cmd := strings.TrimSpace(PagerCommand)
+	if cmd == "" {
+		cmd = strings.TrimSpace(os.Getenv("PAGER"))
+	}
+	if cmd == "" {
+		cmd = "less"
+	}
+	if cmd == "off" || cmd == "cat" {
+		return ""
+	}
+	return cmd
+}
+
+// PagerEnabled reports whether output should be buffered for Page: stdout
+// must be a terminal and paging not turned off.
+func PagerEnabled() bool {
+	return !NoPager && IsTTY() && pagerCommand() != ""
+}
+
+// Page writes out to stdout, through the pager if it is taller than the
+// terminal. Like git, less gets LESS=FRX unless LESS is already set, so
+// colors pass through and the screen isn't cleared on exit. If the pager
+// can't be started the text is written directly.
+func Page(out []byte) error {
+	_, height, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || height <= 0 || bytes.Count(out, []byte("\n")) < height {
+		_, err := os.Stdout.Write(out)
+		return err
+	}
+
+	args := strings.Fields(pagerCommand())
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = bytes.NewReader(out)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = os.Environ()
+	if os.Getenv("LESS") == "" {
+		cmd.Env = append(cmd.Env, "LESS=FRX")
+	}
+
+	// Ctrl+C belongs to the pager while it runs
+	signal.Ignore(os.Interrupt)
+	defer signal.Reset(os.Interrupt)
+	if err := cmd.Run(); err != nil {
+		if _, ok := err.(*exec.ExitError); ok {
+			return nil
+		}
+		_, err := os.Stdout.Write(out)
+		return err
+	}
+	return nil
+}
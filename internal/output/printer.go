@@ -1,8 +1,11 @@
 package output
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math"
@@ -13,17 +16,60 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
 	"golang.org/x/term"
 	"golang.org/x/text/language"
 	"golang.org/x/text/message"
+
+	"github.com/laevitas/cli/internal/api"
 )
 
 // WidthOverride controls terminal width for table truncation.
 // -1 = auto-detect (default), 0 = no truncation (--wide), >0 = exact width (--width N).
 var WidthOverride int = -1
 
+// Quiet suppresses informational stderr output (warnings, success lines)
+// and the table footer. Errors are always printed.
+var Quiet bool
+
+// Raw writes API responses byte for byte, bypassing every format (--raw).
+var Raw bool
+
+// Wrap continues long text cells on extra lines within their column
+// instead of cutting them with an ellipsis (--wrap).
+var Wrap bool
+
+// NoFooter drops the "N records" line under tables (--no-footer).
+var NoFooter bool
+
+// NoSeparator drops the rule under the table header and the dotted line
+// every 5 rows (--no-separator).
+var NoSeparator bool
+
+// Compact separates table columns with one space instead of two and drops
+// the dotted line every 5 rows, fitting more columns before truncation
+// (--compact). The watch view honors it too.
+var Compact bool
+
+// ColumnGap is the space between table columns.
+func ColumnGap() string {
+	if Compact {
+		return " "
+	}
+	return "  "
+}
+
+// MaxColWidth caps the width of text columns in tables; longer cells are
+// truncated, or wrapped with Wrap (--max-width, 0 = no cap).
+var MaxColWidth int
+
+// Humanize abbreviates large numbers in tables as 12.3K, 4.5M, 1.23B
+// (--humanize). JSON and CSV keep full precision.
+var Humanize bool
+
 // Format determines the output format.
 type Format string
 
@@ -32,6 +78,15 @@ const (
 	FormatTable Format = "table"
 	FormatJSON  Format = "json"
 	FormatCSV   Format = "csv"
+
+	// FormatTSV writes tab-separated values, escaping tabs and newlines.
+	FormatTSV Format = "tsv"
+
+	// FormatMarkdown renders a GitHub-flavored Markdown table.
+	FormatMarkdown Format = "markdown"
+
+	// FormatTemplate renders each row through the --template Go template.
+	FormatTemplate Format = "template"
 )
 
 // Resolve determines the effective format, using TTY detection for "auto".
@@ -41,11 +96,17 @@ func Resolve(f string) Format {
 		return FormatJSON
 	case "csv":
 		return FormatCSV
+	case "tsv":
+		return FormatTSV
+	case "markdown", "md":
+		return FormatMarkdown
+	case "template":
+		return FormatTemplate
 	case "table":
 		return FormatTable
 	default:
 		// auto: table if interactive terminal, json if piped
-		if term.IsTerminal(int(os.Stdout.Fd())) {
+		if IsTTY() {
 			return FormatTable
 		}
 		return FormatJSON
@@ -60,6 +121,8 @@ type Printer struct {
 	// TotalCount is the total number of records available (from API metadata).
 	// Set by the caller before Print() to enable the footer.
 	TotalCount int
+
+	noFooter bool // per-table footer suppression (section summaries)
 }
 
 // NewPrinter creates a printer for the given format string.
@@ -75,36 +138,100 @@ func NewPrinter(format string) *Printer {
 //   - []byte (raw JSON from API — printed directly for JSON format)
 //   - any struct or slice (marshaled to JSON, or rendered as table/csv)
 func (p *Printer) Print(data interface{}) error {
+	if Raw {
+		return p.printRaw(data)
+	}
 	switch p.Format {
 	case FormatJSON:
+		if Envelope {
+			return p.printEnvelope(data)
+		}
+		if TransformsActive() {
+			return p.printTransformedJSON(data)
+		}
 		return p.printJSON(data)
 	case FormatCSV:
 		return p.printCSV(data)
+	case FormatTSV:
+		return p.printTSV(data)
+	case FormatMarkdown:
+		return p.printMarkdown(data)
+	case FormatTemplate:
+		return p.printTemplate(data)
 	default:
 		return p.printTable(data)
 	}
 }
 
+// PrintCount prints a record count (--count): {"count": n} for JSON,
+// else the bare number. Transforms don't apply to it.
+func (p *Printer) PrintCount(n int) error {
+	if p.Format == FormatJSON {
+		return p.printJSON(map[string]int{"count": n})
+	}
+	_, err := fmt.Fprintln(p.Writer, n)
+	return err
+}
+
+// printRaw writes raw API bytes untouched. Anything else (data computed by
+// the CLI) is encoded as compact JSON, the closest thing to a raw form.
+func (p *Printer) printRaw(data interface{}) error {
+	if raw, ok := data.([]byte); ok {
+		_, err := p.Writer.Write(raw)
+		return err
+	}
+	return json.NewEncoder(p.Writer).Encode(data)
+}
+
 func (p *Printer) printJSON(data interface{}) error {
-	// If it's already raw bytes, try to pretty-print
+	// If it's already raw bytes, re-indent without decoding so the API's
+	// key order and number formatting survive
 	if raw, ok := data.([]byte); ok {
-		var buf interface{}
-		if err := json.Unmarshal(raw, &buf); err == nil {
-			enc := json.NewEncoder(p.Writer)
-			enc.SetIndent("", "  ")
-			return enc.Encode(buf)
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, bytes.TrimSpace(raw), "", "  "); err == nil {
+			buf.WriteByte('\n')
+			return p.writeJSON(buf.Bytes())
 		}
 		_, err := p.Writer.Write(raw)
 		return err
 	}
 
-	enc := json.NewEncoder(p.Writer)
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
 	enc.SetIndent("", "  ")
-	return enc.Encode(data)
+	if err := enc.Encode(data); err != nil {
+		return err
+	}
+	return p.writeJSON(buf.Bytes())
+}
+
+// tsvEscaper escapes the characters TSV can't hold literally, as in the
+// IANA text/tab-separated-values convention.
+var tsvEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+func (p *Printer) printTSV(data interface{}) error {
+	rows, err := p.rows(data)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(p.Writer)
+	for _, row := range rows {
+		for i, cell := range row {
+			if i > 0 {
+				w.WriteByte('\t')
+			}
+			w.WriteString(tsvEscaper.Replace(cell))
+		}
+		w.WriteByte('\n')
+	}
+	return w.Flush()
 }
 
 func (p *Printer) printCSV(data interface{}) error {
-	rows := toRows(data)
+	rows, err := p.rows(data)
+	if err != nil {
+		return err
+	}
 	if len(rows) == 0 {
 		return nil
 	}
@@ -119,6 +246,83 @@ func (p *Printer) printCSV(data interface{}) error {
 	return w.Error()
 }
 
+// printMarkdown renders a GitHub-flavored Markdown table. Numbers get
+// thousand separators like the table view; timestamps stay absolute since
+// pasted output outlives "3m ago".
+func (p *Printer) printMarkdown(data interface{}) error {
+	rows, err := p.rows(data)
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		fmt.Fprintln(p.Writer, "_No data._")
+		return nil
+	}
+
+	headers := rows[0]
+	dataRows := rows[1:]
+
+	isNumeric := make([]bool, len(headers))
+	for c, h := range headers {
+		hl := strings.ToLower(h)
+		if isTimestampHeader(hl) && !isDaysColumn(hl) {
+			continue
+		}
+		numericCount, total := 0, 0
+		for _, row := range dataRows {
+			if c >= len(row) || row[c] == "" {
+				continue
+			}
+			total++
+			if _, err := strconv.ParseFloat(row[c], 64); err == nil {
+				numericCount++
+			}
+		}
+		isNumeric[c] = total > 0 && numericCount == total
+	}
+
+	var b strings.Builder
+	b.WriteString("|")
+	for _, h := range headers {
+		b.WriteString(" " + markdownEscape(h) + " |")
+	}
+	b.WriteString("\n|")
+	for c := range headers {
+		if isNumeric[c] {
+			b.WriteString("---:|")
+		} else {
+			b.WriteString("---|")
+		}
+	}
+	b.WriteString("\n")
+
+	for _, row := range dataRows {
+		b.WriteString("|")
+		for c := range headers {
+			cell := ""
+			if c < len(row) {
+				cell = row[c]
+			}
+			if isNumeric[c] && cell != "" {
+				cell = formatNumber(cell)
+			}
+			b.WriteString(" " + markdownEscape(cell) + " |")
+		}
+		b.WriteString("\n")
+	}
+
+	_, err = io.WriteString(p.Writer, b.String())
+	return err
+}
+
+// markdownEscape makes a cell safe inside a Markdown table row.
+func markdownEscape(s string) string {
+	s = strings.ReplaceAll(s, "\\", "\\\\")
+	s = strings.ReplaceAll(s, "|", "\\|")
+	s = strings.ReplaceAll(s, "\r\n", "<br>")
+	return strings.ReplaceAll(s, "\n", "<br>")
+}
+
 // ─── Table styles (lipgloss) ────────────────────────────────────────────────
 
 var (
@@ -140,7 +344,20 @@ var (
 )
 
 func (p *Printer) printTable(data interface{}) error {
-	rows := toRows(data)
+	if !TransformsActive() {
+		if summary, sections, ok := splitSections(data); ok {
+			return p.printSections(summary, sections)
+		}
+	}
+	rows, err := p.rows(data)
+	if err != nil {
+		return err
+	}
+	return p.renderTable(rows)
+}
+
+// renderTable draws a header + data grid as an aligned, colored table.
+func (p *Printer) renderTable(rows [][]string) error {
 	if len(rows) == 0 {
 		fmt.Fprintln(p.Writer, "No data.")
 		return nil
@@ -222,31 +439,45 @@ func (p *Printer) printTable(data interface{}) error {
 		displayHeaders[i] = strings.ToUpper(h)
 	}
 
-	// Calculate column widths from formatted data
+	// Calculate column widths from formatted data, in terminal cells:
+	// localized numbers carry multi-byte separators (NBSP, ’)
 	widths := make([]int, numCols)
 	for i, h := range displayHeaders {
-		widths[i] = len(h)
+		widths[i] = runewidth.StringWidth(h)
 	}
 	for _, row := range displayRows {
 		for i, cell := range row {
-			if len(cell) > widths[i] {
-				widths[i] = len(cell)
+			if w := runewidth.StringWidth(cell); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+
+	// Cap text columns at --max-width (numbers are never cut)
+	if MaxColWidth > 0 {
+		for i := range widths {
+			if !isNumeric[i] && widths[i] > MaxColWidth {
+				widths[i] = max(MaxColWidth, 3)
 			}
 		}
 	}
 
 	// Detect terminal width and truncate if needed
 	termWidth := getTerminalWidth()
+	if iw, ok := p.Writer.(*indentWriter); ok && termWidth > len(iw.prefix) {
+		termWidth -= len(iw.prefix)
+	}
 	totalWidth := calcTotalWidth(widths)
 	if termWidth > 0 && totalWidth > termWidth {
 		truncateColumns(widths, termWidth)
 	}
 
 	// Print header row
+	gap := ColumnGap()
 	var hdr strings.Builder
 	for i, h := range displayHeaders {
 		if i > 0 {
-			hdr.WriteString("  ")
+			hdr.WriteString(gap)
 		}
 		cell := padOrTruncate(h, widths[i], false) // headers left-aligned
 		hdr.WriteString(cell)
@@ -254,48 +485,72 @@ func (p *Printer) printTable(data interface{}) error {
 	fmt.Fprintln(p.Writer, headerStyle.Render(hdr.String()))
 
 	// Print separator
-	var sep strings.Builder
-	for i, w := range widths {
-		if i > 0 {
-			sep.WriteString("  ")
+	if !NoSeparator {
+		var sep strings.Builder
+		for i, w := range widths {
+			if i > 0 {
+				sep.WriteString(gap)
+			}
+			sep.WriteString(strings.Repeat("─", w))
 		}
-		sep.WriteString(strings.Repeat("─", w))
+		fmt.Fprintln(p.Writer, separatorStyle.Render(sep.String()))
 	}
-	fmt.Fprintln(p.Writer, separatorStyle.Render(sep.String()))
 
 	// Print data rows
+	rules := columnRules(headers)
 	for r, row := range displayRows {
-		var line strings.Builder
+		// With --wrap, long text cells span several lines; numbers and
+		// timestamps stay on the first
+		cellLines := make([][]string, len(row))
+		height := 1
 		for c, cell := range row {
-			if c > 0 {
-				line.WriteString("  ")
+			if Wrap && !isNumeric[c] && !isTimestamp[c] && runewidth.StringWidth(cell) > widths[c] {
+				cellLines[c] = wrapCell(cell, widths[c])
+			} else {
+				cellLines[c] = []string{cell}
 			}
-			truncated := padOrTruncate(cell, widths[c], isNumeric[c])
-
-			// Apply color for signed numeric values
-			if isSignedValue[c] && isNumeric[c] && cell != "" {
-				val, err := strconv.ParseFloat(formatted[r][c], 64)
-				if err == nil {
-					if val > 0 {
-						truncated = positiveStyle.Render(truncated)
-					} else if val < 0 {
-						truncated = negativeStyle.Render(truncated)
+			height = max(height, len(cellLines[c]))
+		}
+
+		for l := 0; l < height; l++ {
+			var line strings.Builder
+			for c, cell := range row {
+				if c > 0 {
+					line.WriteString(gap)
+				}
+				part := ""
+				if l < len(cellLines[c]) {
+					part = cellLines[c][l]
+				}
+				truncated := padOrTruncate(part, widths[c], isNumeric[c])
+
+				// User color_rules win; otherwise color signed numeric values
+				if style, ok := matchColorRule(ruleFor(rules, c), formatted[r][c]); ok {
+					truncated = style.Render(truncated)
+				} else if isSignedValue[c] && isNumeric[c] && cell != "" {
+					val, err := strconv.ParseFloat(formatted[r][c], 64)
+					if err == nil {
+						if val > 0 {
+							truncated = positiveStyle.Render(truncated)
+						} else if val < 0 {
+							truncated = negativeStyle.Render(truncated)
+						}
 					}
+				} else if isTimestamp[c] && cell != "" {
+					truncated = dimStyle.Render(truncated)
 				}
-			} else if isTimestamp[c] && cell != "" {
-				truncated = dimStyle.Render(truncated)
-			}
 
-			line.WriteString(truncated)
+				line.WriteString(truncated)
+			}
+			fmt.Fprintln(p.Writer, line.String())
 		}
-		fmt.Fprintln(p.Writer, line.String())
 
 		// Subtle separator every 5 rows
-		if (r+1)%5 == 0 && r < len(displayRows)-1 {
+		if !NoSeparator && !Compact && (r+1)%5 == 0 && r < len(displayRows)-1 {
 			var subtle strings.Builder
 			for i, w := range widths {
 				if i > 0 {
-					subtle.WriteString("  ")
+					subtle.WriteString(gap)
 				}
 				subtle.WriteString(strings.Repeat("·", w))
 			}
@@ -305,13 +560,14 @@ func (p *Printer) printTable(data interface{}) error {
 
 	// Footer
 	shown := len(displayRows)
+	if Quiet || NoFooter || p.noFooter {
+		return nil
+	}
 	if p.TotalCount > 0 && p.TotalCount != shown {
-		fmtr := message.NewPrinter(language.English)
-		footer := fmtr.Sprintf("Showing %d of %d records", shown, p.TotalCount)
+		footer := numberPrinter.Sprintf("Showing %d of %d records", shown, p.TotalCount)
 		fmt.Fprintln(p.Writer, footerStyle.Render(footer))
 	} else if shown > 0 {
-		fmtr := message.NewPrinter(language.English)
-		footer := fmtr.Sprintf("%d records", shown)
+		footer := numberPrinter.Sprintf("%d records", shown)
 		fmt.Fprintln(p.Writer, footerStyle.Render(footer))
 	}
 
@@ -356,6 +612,7 @@ func isSignedHeader(h string) bool {
 
 // ─── Number formatting ──────────────────────────────────────────────────────
 
+// numberPrinter groups digits for Locale (SetLocale replaces it).
 var numberPrinter = message.NewPrinter(language.English)
 
 // FormatNumber formats a numeric string with thousand separators and
@@ -370,6 +627,12 @@ func formatNumber(s string) string {
 		return s
 	}
 
+	if Humanize {
+		if h, ok := humanizeNumber(f); ok {
+			return h
+		}
+	}
+
 	// Integers: thousand separators, no decimals
 	if f == math.Trunc(f) && math.Abs(f) >= 1 {
 		return numberPrinter.Sprintf("%d", int64(f))
@@ -378,16 +641,57 @@ func formatNumber(s string) string {
 	// Small decimals (rates, percentages): keep precision
 	abs := math.Abs(f)
 	if abs < 0.01 {
-		return fmt.Sprintf("%.6f", f)
+		return numberPrinter.Sprintf("%.6f", f)
 	}
 	if abs < 1 {
-		return fmt.Sprintf("%.4f", f)
+		return numberPrinter.Sprintf("%.4f", f)
 	}
 
 	// Large decimals: 2 decimal places with thousand separators
 	return numberPrinter.Sprintf("%.2f", f)
 }
 
+// humanUnits are the --humanize suffixes, smallest first.
+var humanUnits = []struct {
+	size   float64
+	suffix string
+}{{1e3, "K"}, {1e6, "M"}, {1e9, "B"}, {1e12, "T"}}
+
+// humanizeNumber renders |f| >= 10,000 with three significant digits and a
+// unit suffix: 12.3K, 4.5M, -1.23B. Smaller values are left to formatNumber.
+func humanizeNumber(f float64) (string, bool) {
+	abs := math.Abs(f)
+	if abs < 1e4 || math.IsInf(f, 0) {
+		return "", false
+	}
+	unit := 0
+	for unit+1 < len(humanUnits) && abs >= humanUnits[unit+1].size {
+		unit++
+	}
+	v := abs / humanUnits[unit].size
+	// 999.6K rounds to 1000K — step up to 1M instead
+	if math.Round(v) >= 1000 && unit+1 < len(humanUnits) {
+		unit++
+		v = abs / humanUnits[unit].size
+	}
+
+	decimals := 0
+	switch {
+	case v < 10:
+		decimals = 2
+	case v < 100:
+		decimals = 1
+	}
+	s := strconv.FormatFloat(v, 'f', decimals, 64)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	if f < 0 {
+		s = "-" + s
+	}
+	return strings.Replace(s, ".", decimalSep, 1) + humanUnits[unit].suffix, true
+}
+
 // ─── Relative time formatting ───────────────────────────────────────────────
 
 // isoTimestampRe matches common ISO 8601 formats.
@@ -433,14 +737,19 @@ func formatRelativeTime(s string) string {
 	case diff < 24*time.Hour:
 		return t.Format("15:04") // e.g. "16:09"
 	case diff < 7*24*time.Hour:
-		return t.Format("Mon 15:04") // e.g. "Mon 16:09"
+		return formatDate(t, "Mon 15:04") // e.g. "Mon 16:09"
 	case diff < 365*24*time.Hour:
-		return t.Format("Jan 02 15:04") // e.g. "Feb 24 16:09"
+		return formatDate(t, "Jan 02 15:04") // e.g. "Feb 24 16:09"
 	default:
 		return t.Format("2006-01-02") // e.g. "2025-02-24"
 	}
 }
 
+// HumanDuration formats a duration compactly (e.g. "45s", "3h", "2w").
+func HumanDuration(d time.Duration) string {
+	return humanDuration(d)
+}
+
 func humanDuration(d time.Duration) string {
 	switch {
 	case d < time.Minute:
@@ -476,7 +785,7 @@ func getTerminalWidth() int {
 		return WidthOverride // --width N
 	}
 	// Default: auto-detect
-	w, _, err := term.GetSize(int(os.Stdout.Fd()))
+	w, _, err := term.GetSize(int(Terminal.Fd()))
 	if err != nil || w <= 0 {
 		return 0 // unknown — don't truncate
 	}
@@ -487,7 +796,7 @@ func calcTotalWidth(widths []int) int {
 	total := 0
 	for i, w := range widths {
 		if i > 0 {
-			total += 2 // column spacing
+			total += len(ColumnGap())
 		}
 		total += w
 	}
@@ -515,19 +824,47 @@ func truncateColumns(widths []int, termWidth int) {
 	}
 }
 
-// padOrTruncate pads a cell to the given width, or truncates with ellipsis.
+// padOrTruncate pads a cell to the given display width, or truncates it
+// on a character boundary with an ellipsis.
 // If rightAlign is true, the value is right-aligned (for numbers).
 func padOrTruncate(s string, width int, rightAlign bool) string {
-	if len(s) > width {
+	if runewidth.StringWidth(s) > width {
+		tail := "…"
 		if width <= 3 {
-			return s[:width]
+			tail = ""
 		}
-		return s[:width-1] + "…"
+		s = runewidth.Truncate(s, width, tail)
 	}
 	if rightAlign {
-		return fmt.Sprintf("%*s", width, s)
+		return runewidth.FillLeft(s, width)
 	}
-	return fmt.Sprintf("%-*s", width, s)
+	return runewidth.FillRight(s, width)
+}
+
+// wrapCell splits s into lines of at most width bytes, breaking after
+// spaces, hyphens or underscores where possible (so slugs wrap at word
+// boundaries) and mid-word otherwise.
+func wrapCell(s string, width int) []string {
+	if width < 1 {
+		return []string{s}
+	}
+	var lines []string
+	for len(s) > width {
+		// Break at the last space that fits, or after the last - or _
+		cut := strings.LastIndex(s[:width+1], " ")
+		if i := strings.LastIndexAny(s[:width], "-_"); i+1 > cut {
+			cut = i + 1
+		}
+		if cut <= 0 {
+			cut = width
+			for cut > 1 && !utf8.RuneStart(s[cut]) {
+				cut-- // don't split a multi-byte character
+			}
+		}
+		lines = append(lines, strings.TrimRight(s[:cut], " "))
+		s = strings.TrimLeft(s[cut:], " ")
+	}
+	return append(lines, s)
 }
 
 // ─── Data extraction helpers ────────────────────────────────────────────────
@@ -572,6 +909,10 @@ func toRows(data interface{}) [][]string {
 				}
 				return sliceToRows(inner)
 			}
+			// A single object under "data": list its fields, not "data"
+			if inner.Kind() == reflect.Map {
+				return mapToRows(inner)
+			}
 		}
 		return mapToRows(v)
 	default:
@@ -597,11 +938,14 @@ func sliceToRows(v reflect.Value) [][]string {
 var columnPriorities = map[string]int{
 	// ── Identity / time — always first ──────────────────────────────────
 	"date": 1, "minute": 1, "timestamp": 2,
-	"exchange": 5, "currency": 6,
+	"market": 4, "exchange": 5, "currency": 6,
 	"instrument_name": 10, "instrument_type": 11,
 	"maturity": 12, "tenor": 13,
 	"days_to_expiry": 14,
 
+	// ── --describe ──────────────────────────────────────────────────────
+	"field": 3, "type": 4, "priority": 7, "example": 8,
+
 	// ── OHLCV core ──────────────────────────────────────────────────────
 	"open": 20, "high": 21, "low": 22, "close": 23,
 	"volume": 24, "vwap": 25,
@@ -663,12 +1007,23 @@ var columnPriorities = map[string]int{
 	// ── Vol surface ─────────────────────────────────────────────────────
 	"atm_iv": 160, "skew_25d": 161, "butterfly_25d": 162,
 	"call_25d_iv": 163, "put_25d_iv": 164,
+	"iv_current": 165, "iv_rank": 166, "iv_percentile": 167,
+	"iv_min": 168, "iv_max": 169, "observations": 190,
+
+	// ── Options: derived (pcr / max-pain / expiry-calendar) ─────────────
+	"instruments": 15, "notional_usd": 125,
+	"max_pain": 170, "payout_at_pain": 171,
+	"put_oi": 172, "call_oi": 173, "oi_pcr": 174,
+	"put_volume": 175, "call_volume": 176, "volume_pcr": 177,
 
 	// ── Options trade changes (secondary) ───────────────────────────────
 	"bid_price_change": 200, "ask_price_change": 201,
 	"bid_size_change": 202, "ask_size_change": 203,
 	"bid_iv_change": 204, "ask_iv_change": 205,
 
+	// ── Perps: spread ───────────────────────────────────────────────────
+	"price_a": 180, "price_b": 181, "spread": 182, "ratio": 183,
+
 	// ── Predictions ─────────────────────────────────────────────────────
 	"category": 250, "event_slug": 251,
 
@@ -723,15 +1078,27 @@ var columnPriorities = map[string]int{
 	"oi_before": 915, "strategy": 916,
 }
 
+// ColumnPriority is the table ordering weight of a column: lower sorts
+// further left and is the last to be dropped on narrow terminals.
+func ColumnPriority(name string) int {
+	return columnWeight(name)
+}
+
 func columnWeight(name string) int {
 	if w, ok := columnPriorities[name]; ok {
 		return w
 	}
+	// Flattened columns (greeks.delta) sort like their leaf field
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		return columnWeight(name[i+1:])
+	}
 	return 500
 }
 
 func sliceOfMapsToRows(v reflect.Value) [][]string {
-	// Collect all unique keys in order of first appearance
+	// Flatten nested objects into dotted columns (greeks.delta) and
+	// collect all unique keys in order of first appearance
+	records := make([]map[string]interface{}, v.Len())
 	keyOrder := []string{}
 	keySet := map[string]bool{}
 
@@ -743,8 +1110,16 @@ func sliceOfMapsToRows(v reflect.Value) [][]string {
 		if item.Kind() != reflect.Map {
 			continue
 		}
-		for _, key := range item.MapKeys() {
-			k := fmt.Sprintf("%v", key.Interface())
+		flat := map[string]interface{}{}
+		flattenMap("", item, flat)
+		records[i] = flat
+
+		keys := make([]string, 0, len(flat))
+		for k := range flat {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
 			if !keySet[k] {
 				keySet[k] = true
 				keyOrder = append(keyOrder, k)
@@ -774,16 +1149,11 @@ func sliceOfMapsToRows(v reflect.Value) [][]string {
 
 	rows := [][]string{keyOrder}
 
-	for i := 0; i < v.Len(); i++ {
-		item := v.Index(i)
-		for item.Kind() == reflect.Interface {
-			item = item.Elem()
-		}
+	for _, rec := range records {
 		row := make([]string, len(keyOrder))
 		for j, key := range keyOrder {
-			val := item.MapIndex(reflect.ValueOf(key))
-			if val.IsValid() {
-				row[j] = formatValue(val.Interface())
+			if val, ok := rec[key]; ok {
+				row[j] = formatValue(val)
 			}
 		}
 		rows = append(rows, row)
@@ -792,12 +1162,59 @@ func sliceOfMapsToRows(v reflect.Value) [][]string {
 	return rows
 }
 
+// flattenMap copies m into out, expanding nested objects into
+// "parent.child" keys so they render as their own columns.
+func flattenMap(prefix string, m reflect.Value, out map[string]interface{}) {
+	for _, key := range m.MapKeys() {
+		k := fmt.Sprintf("%v", key.Interface())
+		if prefix != "" {
+			k = prefix + "." + k
+		}
+		val := m.MapIndex(key)
+		for val.Kind() == reflect.Interface && !val.IsNil() {
+			val = val.Elem()
+		}
+		if val.Kind() == reflect.Map {
+			if val.Len() == 0 {
+				out[k] = nil
+			} else {
+				flattenMap(k, val, out)
+			}
+			continue
+		}
+		if !val.IsValid() || (val.Kind() == reflect.Interface && val.IsNil()) {
+			out[k] = nil
+			continue
+		}
+		out[k] = val.Interface()
+	}
+}
+
+// mapToRows lists a single object as Key/Value rows, nested objects
+// flattened into dotted keys, in column priority order.
 func mapToRows(v reflect.Value) [][]string {
+	flat := map[string]interface{}{}
+	flattenMap("", v, flat)
+	return keyValueRows(flat)
+}
+
+// keyValueRows sorts flat's keys like table columns and pairs them with
+// their formatted values.
+func keyValueRows(flat map[string]interface{}) [][]string {
+	keys := make([]string, 0, len(flat))
+	for k := range flat {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		wi, wj := columnWeight(keys[i]), columnWeight(keys[j])
+		if wi != wj {
+			return wi < wj
+		}
+		return keys[i] < keys[j]
+	})
 	rows := [][]string{{"Key", "Value"}}
-	for _, key := range v.MapKeys() {
-		k := fmt.Sprintf("%v", key.Interface())
-		val := v.MapIndex(key)
-		rows = append(rows, []string{k, formatValue(val.Interface())})
+	for _, k := range keys {
+		rows = append(rows, []string{k, formatValue(flat[k])})
 	}
 	return rows
 }
@@ -812,9 +1229,16 @@ func formatValue(v interface{}) string {
 		if val == float64(int64(val)) {
 			return fmt.Sprintf("%.0f", val)
 		}
-		return fmt.Sprintf("%g", val)
+		return strconv.FormatFloat(val, 'f', -1, 64)
 	case nil:
 		return ""
+	case map[string]interface{}, []interface{}:
+		// Nested values that were not flattened (arrays, or a single
+		// object's fields) render as compact JSON rather than map[...]
+		if b, err := json.Marshal(val); err == nil {
+			return string(b)
+		}
+		return fmt.Sprintf("%v", val)
 	default:
 		return fmt.Sprintf("%v", val)
 	}
@@ -838,23 +1262,59 @@ func Errorf(format string, a ...interface{}) {
 
 // Successf prints a green ✓ prefixed success message to stderr.
 func Successf(format string, a ...interface{}) {
+	if Quiet {
+		return
+	}
 	msg := fmt.Sprintf(format, a...)
 	fmt.Fprintf(os.Stderr, "%s%s✓ %s%s\n", ansiBold, ansiGreen, msg, ansiReset)
 }
 
 // Warnf prints a yellow warning message to stderr.
 func Warnf(format string, a ...interface{}) {
+	if Quiet {
+		return
+	}
 	msg := fmt.Sprintf(format, a...)
 	fmt.Fprintf(os.Stderr, "%s%s⚠ %s%s\n", ansiBold, ansiYellow, msg, ansiReset)
 }
 
-// PrintError outputs a structured error.
+// PrintError outputs a structured error. In JSON mode, API errors also
+// carry status_code and endpoint.
 func PrintError(format Format, err error) {
 	if format == FormatJSON {
-		errObj := map[string]string{"error": err.Error()}
+		errObj := map[string]interface{}{"error": err.Error()}
+		var apiErr *api.APIError
+		if errors.As(err, &apiErr) {
+			errObj["error"] = apiErr.Message
+			errObj["status_code"] = apiErr.StatusCode
+			if apiErr.Endpoint != "" {
+				errObj["endpoint"] = apiErr.Endpoint
+			}
+			if apiErr.RequestID != "" {
+				errObj["request_id"] = apiErr.RequestID
+			}
+		}
+		var netErr *api.NetworkError
+		if errors.As(err, &netErr) {
+			errObj["network"] = true
+		}
+		var timeoutErr *api.TimeoutError
+		if errors.As(err, &timeoutErr) {
+			errObj["timeout"] = true
+		}
 		data, _ := json.Marshal(errObj)
 		fmt.Fprintln(os.Stderr, string(data))
 	} else {
 		Errorf("%s", err.Error())
 	}
 }
+
+// Columns returns the column headers data would be printed with, in
+// display order. Empty if data isn't tabular.
+func Columns(data interface{}) []string {
+	rows := toRows(data)
+	if len(rows) == 0 {
+		return nil
+	}
+	return rows[0]
+}
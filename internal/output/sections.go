@@ -0,0 +1,147 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─── Sectioned summaries ────────────────────────────────────────────────────
+//
+// Summary endpoints (the flow commands) return one object that mixes scalar
+// metrics with arrays of records such as notable trades and most active
+// instruments. As a key/value dump those arrays collapse into long JSON
+// cells, so tables show them as sections instead: the scalars as one
+// Key/Value block, then an indented, titled sub-table per array.
+
+var sectionStyle = lipgloss.NewStyle().Bold(true)
+
+// section is an array of records found inside a summary object.
+type section struct {
+	name    string // dotted path, e.g. notable_trades or buys.top
+	records []interface{}
+}
+
+// splitSections reports whether data is a single object (bare or under
+// "data") holding at least one non-empty array of objects, and if so
+// returns its scalar fields as Key/Value rows plus the arrays.
+// Nested objects are flattened into dotted metric names.
+func splitSections(data interface{}) ([][]string, []section, bool) {
+	if raw, ok := data.([]byte); ok {
+		var parsed interface{}
+		if json.Unmarshal(raw, &parsed) != nil {
+			return nil, nil, false
+		}
+		data = parsed
+	}
+	obj, ok := data.(map[string]interface{})
+	if !ok {
+		return nil, nil, false
+	}
+	if inner, ok := obj["data"].(map[string]interface{}); ok {
+		obj = inner
+	}
+
+	flat := map[string]interface{}{}
+	var sections []section
+	collectSections("", obj, flat, &sections)
+	if len(sections) == 0 {
+		return nil, nil, false
+	}
+
+	summary := keyValueRows(flat)
+	sort.SliceStable(sections, func(i, j int) bool { return sections[i].name < sections[j].name })
+	return summary, sections, true
+}
+
+// collectSections walks m, moving arrays of objects into sections and
+// everything else into flat under its dotted name.
+func collectSections(prefix string, m map[string]interface{}, flat map[string]interface{}, sections *[]section) {
+	for k, v := range m {
+		if prefix != "" {
+			k = prefix + "." + k
+		}
+		switch v := v.(type) {
+		case map[string]interface{}:
+			if len(v) > 0 {
+				collectSections(k, v, flat, sections)
+				continue
+			}
+		case []interface{}:
+			if len(v) > 0 {
+				if _, ok := v[0].(map[string]interface{}); ok {
+					*sections = append(*sections, section{name: k, records: v})
+					continue
+				}
+			}
+		}
+		flat[k] = v
+	}
+}
+
+// printSections renders a split summary: the Key/Value block, then each
+// section under its title, indented, with its own record count.
+func (p *Printer) printSections(summary [][]string, sections []section) error {
+	// TotalCount describes the whole response, not any one section
+	total := p.TotalCount
+	p.TotalCount = 0
+	defer func() { p.TotalCount = total }()
+
+	if len(summary) > 1 {
+		p.noFooter = true
+		err := p.renderTable(summary)
+		p.noFooter = false
+		if err != nil {
+			return err
+		}
+	}
+	for i, s := range sections {
+		if i > 0 || len(summary) > 1 {
+			fmt.Fprintln(p.Writer)
+		}
+		title := strings.ToUpper(strings.NewReplacer("_", " ", ".", " › ").Replace(s.name))
+		fmt.Fprintln(p.Writer, sectionStyle.Render(title))
+		sub := *p
+		sub.Writer = &indentWriter{w: p.Writer, prefix: sectionIndent}
+		if err := sub.renderTable(toRows(s.records)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const sectionIndent = "  "
+
+// indentWriter prefixes every line written through it.
+type indentWriter struct {
+	w       io.Writer
+	prefix  string
+	midLine bool
+}
+
+func (iw *indentWriter) Write(b []byte) (int, error) {
+	n := len(b)
+	for len(b) > 0 {
+		if !iw.midLine {
+			if _, err := io.WriteString(iw.w, iw.prefix); err != nil {
+				return 0, err
+			}
+			iw.midLine = true
+		}
+		line := b
+		if i := bytes.IndexByte(b, '\n'); i >= 0 {
+			line = b[:i+1]
+			iw.midLine = false
+		}
+		if _, err := iw.w.Write(line); err != nil {
+			return 0, err
+		}
+		b = b[len(line):]
+	}
+	return n, nil
+}
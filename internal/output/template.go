@@ -0,0 +1,89 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// rowTemplate is the parsed --template used by -o template.
+var rowTemplate *template.Template
+
+// SetTemplate parses text as the Go text/template applied to each row for
+// -o template, e.g. '{{.instrument_name}} {{.funding_rate_close}}'. Fields
+// are the column names of the table view (nested objects flattened with
+// dots, so use {{index . "greeks.delta"}}); missing fields render empty.
+// Numeric cells are numbers, so {{printf "%.4f" .funding_rate_close}} and
+// {{if gt .mark_price 0.0}} work.
+func SetTemplate(text string) error {
+	if text == "" {
+		rowTemplate = nil
+		return nil
+	}
+	t, err := template.New("row").Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return fmt.Errorf("invalid --template: %w", err)
+	}
+	rowTemplate = t
+	return nil
+}
+
+// printTemplate renders each row as a column → value map through the
+// template, one line per row. Client-side filters and sorting apply as for
+// the other formats.
+func (p *Printer) printTemplate(data interface{}) error {
+	if rowTemplate == nil {
+		return fmt.Errorf("-o template needs --template '{{.column}} ...'")
+	}
+	rows, err := p.rows(data)
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	headers := rows[0]
+	var buf bytes.Buffer
+	for _, row := range rows[1:] {
+		rec := make(map[string]interface{}, len(headers))
+		for c, h := range headers {
+			rec[h] = ""
+			if c >= len(row) || row[c] == "" {
+				continue
+			}
+			if f, err := strconv.ParseFloat(row[c], 64); err == nil && json.Valid([]byte(row[c])) {
+				rec[h] = templateNumber(f)
+			} else {
+				rec[h] = row[c]
+			}
+		}
+		buf.Reset()
+		if err := rowTemplate.Execute(&buf, rec); err != nil {
+			return fmt.Errorf("--template: %w", err)
+		}
+		// A missing key in a map of interface values renders as
+		// "<no value>" even with missingkey=zero; show it empty instead
+		line := strings.ReplaceAll(buf.String(), "<no value>", "")
+		if !strings.HasSuffix(line, "\n") {
+			line += "\n"
+		}
+		if _, err := io.WriteString(p.Writer, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// templateNumber is a numeric cell as seen by a template. It compares and
+// formats as a float64 but prints in plain decimal, so {{.timestamp}} reads
+// 1709000000000 rather than 1.709e+12.
+type templateNumber float64
+
+func (n templateNumber) String() string {
+	return strconv.FormatFloat(float64(n), 'f', -1, 64)
+}
@@ -0,0 +1,27 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestTemplateTypedValues checks numeric cells reach the template as
+// numbers, so printf verbs and comparisons work on them.
+func TestTemplateTypedValues(t *testing.T) {
+	if err := SetTemplate(`{{.instrument_name}} {{printf "%.4f" .funding_rate_close}} {{if gt .value 0.0}}up{{else}}down{{end}} {{.timestamp}}{{.missing}}`); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { SetTemplate("") })
+
+	var buf bytes.Buffer
+	p := &Printer{Format: FormatTemplate, Writer: &buf}
+	data := []byte(`[{"instrument_name":"BTC-PERPETUAL","funding_rate_close":0.000123456,"value":5.1,"timestamp":1709000000000},
+		{"instrument_name":"ETH-PERPETUAL","funding_rate_close":-0.0002,"value":-1,"timestamp":1709000000000}]`)
+	if err := p.Print(data); err != nil {
+		t.Fatal(err)
+	}
+	want := "BTC-PERPETUAL 0.0001 up 1709000000000\nETH-PERPETUAL -0.0002 down 1709000000000\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,495 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Client-side row transforms, applied to the toRows grid before any format
+// renders it, so they work on every endpoint without server support.
+
+// Filters keep only rows matching every predicate (--filter, ANDed).
+var Filters []Filter
+
+// AggregateBy collapses rows sharing a value of this column (--aggregate-by).
+var AggregateBy string
+
+// SortKeys order the final rows (--sort-by), first key first.
+var SortKeys []SortKey
+
+// Aggs are the aggregates computed per group (--agg). With no AggregateBy they
+// collapse the whole result into a single row.
+var Aggs []Agg
+
+// Agg is one parsed --agg term such as sum:amount_usd or count:*.
+type Agg struct {
+	Func   string // sum, avg, min, max, count
+	Column string // "*" for count:*
+}
+
+// Name is the output column for the aggregate, e.g. sum_amount_usd.
+func (a Agg) Name() string {
+	if a.Column == "*" {
+		return a.Func
+	}
+	return a.Func + "_" + a.Column
+}
+
+var aggFuncs = []string{"sum", "avg", "min", "max", "count"}
+
+// ParseAggs parses a comma-separated --agg spec: "sum:amount_usd,count:*".
+func ParseAggs(spec string) ([]Agg, error) {
+	var aggs []Agg
+	for _, term := range strings.Split(spec, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		fn, col, ok := strings.Cut(term, ":")
+		fn = strings.ToLower(strings.TrimSpace(fn))
+		col = strings.TrimSpace(col)
+		if !ok || col == "" {
+			return nil, fmt.Errorf("invalid --agg %q — use func:column, e.g. sum:amount_usd or count:*", term)
+		}
+		known := false
+		for _, f := range aggFuncs {
+			known = known || f == fn
+		}
+		if !known {
+			return nil, fmt.Errorf("invalid --agg function %q (use: %s)", fn, strings.Join(aggFuncs, ", "))
+		}
+		if col == "*" && fn != "count" {
+			return nil, fmt.Errorf("invalid --agg %q — only count accepts *", term)
+		}
+		aggs = append(aggs, Agg{Func: fn, Column: col})
+	}
+	return aggs, nil
+}
+
+// TransformsActive reports whether any client-side transform is set.
+func TransformsActive() bool {
+	return len(Filters) > 0 || AggregateBy != "" || len(Aggs) > 0 || len(SortKeys) > 0
+}
+
+// RowSetChanged reports whether a transform changes which rows are shown
+// (filters, grouping), not just their order, so the response's row count
+// and series no longer describe the output.
+func RowSetChanged() bool {
+	return len(Filters) > 0 || AggregateBy != "" || len(Aggs) > 0
+}
+
+// RowCount is the number of rows data has once the transforms are applied.
+func RowCount(data interface{}) (int, error) {
+	rows, err := (&Printer{}).rows(data)
+	if err != nil || len(rows) == 0 {
+		return 0, err
+	}
+	return len(rows) - 1, nil
+}
+
+// rows converts data to a grid and applies the client-side transforms:
+// filter first, then aggregate, then sort.
+func (p *Printer) rows(data interface{}) ([][]string, error) {
+	rows := toRows(data)
+	if !TransformsActive() || len(rows) == 0 {
+		return rows, nil
+	}
+	rows, err := filterRows(rows, Filters)
+	if err != nil {
+		return nil, err
+	}
+	if AggregateBy != "" || len(Aggs) > 0 {
+		if rows, err = aggregateRows(rows, AggregateBy, Aggs); err != nil {
+			return nil, err
+		}
+	}
+	return sortRows(rows, SortKeys)
+}
+
+// printTransformedJSON prints the transformed grid as an array of objects,
+// keeping numeric cells numeric. Used instead of re-encoding the response
+// when a transform changed its shape.
+func (p *Printer) printTransformedJSON(data interface{}) error {
+	records, err := p.transformedRecords(data)
+	if err != nil {
+		return err
+	}
+	return p.printJSON(records)
+}
+
+// transformedRecords turns the transformed rows back into JSON records.
+func (p *Printer) transformedRecords(data interface{}) ([]map[string]interface{}, error) {
+	rows, err := p.rows(data)
+	if err != nil {
+		return nil, err
+	}
+	records := make([]map[string]interface{}, 0, len(rows))
+	if len(rows) > 0 {
+		headers := rows[0]
+		for _, row := range rows[1:] {
+			rec := make(map[string]interface{}, len(headers))
+			for c, h := range headers {
+				if c >= len(row) || row[c] == "" {
+					continue
+				}
+				if _, err := strconv.ParseFloat(row[c], 64); err == nil && json.Valid([]byte(row[c])) {
+					rec[h] = json.Number(row[c])
+				} else {
+					rec[h] = row[c]
+				}
+			}
+			records = append(records, rec)
+		}
+	}
+	return records, nil
+}
+
+// ─── Filtering ──────────────────────────────────────────────────────────────
+
+// Filter is one parsed --filter predicate such as days_to_expiry<7.
+type Filter struct {
+	Expr   string
+	Column string
+	Op     string
+	Value  string
+}
+
+var filterPattern = regexp.MustCompile(`^\s*([A-Za-z0-9_.]+)\s*(>=|<=|==|!=|>|<)\s*(.*?)\s*$`)
+
+// ParseFilter parses "column OP value" with OP one of > < >= <= == !=.
+// Quotes around the value are optional.
+func ParseFilter(expr string) (Filter, error) {
+	m := filterPattern.FindStringSubmatch(expr)
+	if m == nil || m[3] == "" {
+		return Filter{}, fmt.Errorf("invalid --filter %q — use column OP value with OP one of > < >= <= == !=", expr)
+	}
+	value := m[3]
+	if len(value) >= 2 && (value[0] == '\'' || value[0] == '"') && value[len(value)-1] == value[0] {
+		value = value[1 : len(value)-1]
+	}
+	return Filter{Expr: strings.TrimSpace(expr), Column: m[1], Op: m[2], Value: value}, nil
+}
+
+// Matches compares cell against the filter value, numerically when both
+// sides are numbers and as case-insensitive strings otherwise. Empty cells
+// only match != filters.
+func (f Filter) Matches(cell string) bool {
+	if cell == "" {
+		return f.Op == "!="
+	}
+	var cmp int
+	a, errA := strconv.ParseFloat(cell, 64)
+	b, errB := strconv.ParseFloat(f.Value, 64)
+	if errA == nil && errB == nil {
+		switch {
+		case a < b:
+			cmp = -1
+		case a > b:
+			cmp = 1
+		}
+	} else {
+		cmp = strings.Compare(strings.ToLower(cell), strings.ToLower(f.Value))
+	}
+	switch f.Op {
+	case ">":
+		return cmp > 0
+	case "<":
+		return cmp < 0
+	case ">=":
+		return cmp >= 0
+	case "<=":
+		return cmp <= 0
+	case "==":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	}
+	return false
+}
+
+// filterRows keeps the data rows matching all filters.
+func filterRows(rows [][]string, filters []Filter) ([][]string, error) {
+	if len(filters) == 0 {
+		return rows, nil
+	}
+	headers := rows[0]
+	cols := make([]int, len(filters))
+	for i, f := range filters {
+		cols[i] = -1
+		for c, h := range headers {
+			if h == f.Column {
+				cols[i] = c
+				break
+			}
+		}
+		if cols[i] < 0 {
+			return nil, fmt.Errorf("--filter %s: column %q not found", f.Expr, f.Column)
+		}
+	}
+
+	out := [][]string{headers}
+	for _, row := range rows[1:] {
+		keep := true
+		for i, f := range filters {
+			cell := ""
+			if cols[i] < len(row) {
+				cell = row[cols[i]]
+			}
+			if !f.Matches(cell) {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			out = append(out, row)
+		}
+	}
+	return out, nil
+}
+
+// ─── Sorting ────────────────────────────────────────────────────────────────
+
+// SortKey is one --sort-by term: a column and its direction.
+type SortKey struct {
+	Column string
+	Desc   bool
+}
+
+// ParseSortKeys parses "a,b:desc" into sort keys. Direction defaults to
+// ascending; asc and desc are accepted.
+func ParseSortKeys(spec string) ([]SortKey, error) {
+	var keys []SortKey
+	for _, term := range strings.Split(spec, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		col, dir, _ := strings.Cut(term, ":")
+		key := SortKey{Column: strings.TrimSpace(col)}
+		switch strings.ToLower(strings.TrimSpace(dir)) {
+		case "", "asc":
+		case "desc":
+			key.Desc = true
+		default:
+			return nil, fmt.Errorf("invalid --sort-by %q — direction must be asc or desc", term)
+		}
+		if key.Column == "" {
+			return nil, fmt.Errorf("invalid --sort-by %q — missing column", term)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// sortRows stable-sorts the data rows by keys. A column compares
+// numerically when all its non-empty cells are numbers, lexically
+// otherwise; empty cells always sort last.
+func sortRows(rows [][]string, keys []SortKey) ([][]string, error) {
+	if len(keys) == 0 || len(rows) < 3 {
+		return rows, nil
+	}
+	headers, dataRows := rows[0], rows[1:]
+
+	cols := make([]int, len(keys))
+	numeric := make([]bool, len(keys))
+	for i, k := range keys {
+		cols[i] = -1
+		for c, h := range headers {
+			if h == k.Column {
+				cols[i] = c
+				break
+			}
+		}
+		if cols[i] < 0 {
+			return nil, fmt.Errorf("--sort-by: column %q not found", k.Column)
+		}
+		numeric[i] = columnIsNumeric(dataRows, cols[i])
+	}
+
+	cell := func(row []string, c int) string {
+		if c < len(row) {
+			return row[c]
+		}
+		return ""
+	}
+	sort.SliceStable(dataRows, func(a, b int) bool {
+		for i, k := range keys {
+			x, y := cell(dataRows[a], cols[i]), cell(dataRows[b], cols[i])
+			if x == y {
+				continue
+			}
+			if x == "" || y == "" {
+				return y == "" // empty last regardless of direction
+			}
+			var cmp int
+			if numeric[i] {
+				fx, _ := strconv.ParseFloat(x, 64)
+				fy, _ := strconv.ParseFloat(y, 64)
+				switch {
+				case fx < fy:
+					cmp = -1
+				case fx > fy:
+					cmp = 1
+				}
+			} else {
+				cmp = strings.Compare(x, y)
+			}
+			if cmp == 0 {
+				continue
+			}
+			if k.Desc {
+				return cmp > 0
+			}
+			return cmp < 0
+		}
+		return false
+	})
+	return rows, nil
+}
+
+// columnIsNumeric reports whether every non-empty cell in column c parses
+// as a number, the same test the table view uses for alignment.
+func columnIsNumeric(dataRows [][]string, c int) bool {
+	seen := false
+	for _, row := range dataRows {
+		if c >= len(row) || row[c] == "" {
+			continue
+		}
+		if _, err := strconv.ParseFloat(row[c], 64); err != nil {
+			return false
+		}
+		seen = true
+	}
+	return seen
+}
+
+// ─── Aggregation ────────────────────────────────────────────────────────────
+
+// aggState accumulates one aggregate for one group.
+type aggState struct {
+	sum, min, max float64
+	n             int // numeric values seen (count for count:col)
+}
+
+// aggregateRows groups the grid by groupBy (all rows form one group when
+// empty) and computes aggs per group, in order of first appearance. Only
+// count:* applies when no aggregates are given.
+func aggregateRows(rows [][]string, groupBy string, aggs []Agg) ([][]string, error) {
+	headers, dataRows := rows[0], rows[1:]
+	index := make(map[string]int, len(headers))
+	for i, h := range headers {
+		index[h] = i
+	}
+
+	groupCol := -1
+	if groupBy != "" {
+		c, ok := index[groupBy]
+		if !ok {
+			return nil, fmt.Errorf("--aggregate-by: column %q not found", groupBy)
+		}
+		groupCol = c
+	}
+	if len(aggs) == 0 {
+		aggs = []Agg{{Func: "count", Column: "*"}}
+	}
+	for _, a := range aggs {
+		if _, ok := index[a.Column]; !ok && a.Column != "*" {
+			return nil, fmt.Errorf("--agg: column %q not found", a.Column)
+		}
+	}
+
+	var order []string
+	groups := map[string][]aggState{}
+	for _, row := range dataRows {
+		key := ""
+		if groupCol >= 0 && groupCol < len(row) {
+			key = row[groupCol]
+		}
+		states, seen := groups[key]
+		if !seen {
+			order = append(order, key)
+			states = make([]aggState, len(aggs))
+		}
+		for i, a := range aggs {
+			if a.Column == "*" {
+				states[i].n++
+				continue
+			}
+			c := index[a.Column]
+			if c >= len(row) || row[c] == "" {
+				continue
+			}
+			if a.Func == "count" {
+				states[i].n++
+				continue
+			}
+			v, err := strconv.ParseFloat(row[c], 64)
+			if err != nil {
+				continue
+			}
+			s := &states[i]
+			if s.n == 0 || v < s.min {
+				s.min = v
+			}
+			if s.n == 0 || v > s.max {
+				s.max = v
+			}
+			s.sum += v
+			s.n++
+		}
+		groups[key] = states
+	}
+
+	var outHeaders []string
+	if groupCol >= 0 {
+		outHeaders = append(outHeaders, groupBy)
+	}
+	for _, a := range aggs {
+		outHeaders = append(outHeaders, a.Name())
+	}
+
+	out := [][]string{outHeaders}
+	for _, key := range order {
+		var row []string
+		if groupCol >= 0 {
+			row = append(row, key)
+		}
+		for i, a := range aggs {
+			row = append(row, aggValue(a, groups[key][i]))
+		}
+		out = append(out, row)
+	}
+	return out, nil
+}
+
+func aggValue(a Agg, s aggState) string {
+	if a.Func == "count" {
+		return strconv.Itoa(s.n)
+	}
+	if s.n == 0 {
+		return ""
+	}
+	var v float64
+	switch a.Func {
+	case "sum":
+		v = s.sum
+	case "avg":
+		v = s.sum / float64(s.n)
+	case "min":
+		v = s.min
+	case "max":
+		v = s.max
+	}
+	return strconv.FormatFloat(RoundNoise(v), 'f', -1, 64)
+}
+
+// RoundNoise rounds v to 12 significant digits, hiding float summation
+// noise such as 5.1000000000000005.
+func RoundNoise(v float64) float64 {
+	r, _ := strconv.ParseFloat(strconv.FormatFloat(v, 'g', 12, 64), 64)
+	return r
+}
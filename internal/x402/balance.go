@@ -0,0 +1,94 @@
+package x402
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// BaseRPCURL is the public Base mainnet JSON-RPC endpoint.
+	BaseRPCURL = "https://mainnet.base.org"
+
+	// BaseUSDCAddress is the native USDC contract on Base, the token x402
+	// payments are made in.
+	BaseUSDCAddress = "0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913"
+
+	usdcDecimals = 6
+
+	// balanceOfSelector is the ERC-20 balanceOf(address) function selector.
+	balanceOfSelector = "0x70a08231"
+)
+
+// USDCBalance returns the USDC balance of address on Base, in token units
+// (6 decimals), via an eth_call to rpcURL.
+func USDCBalance(ctx context.Context, rpcURL, address string) (*big.Int, error) {
+	addr := strings.TrimPrefix(strings.ToLower(address), "0x")
+	if len(addr) != 40 {
+		return nil, fmt.Errorf("invalid wallet address %q", address)
+	}
+
+	reqBody, _ := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "eth_call",
+		"params": []interface{}{
+			map[string]string{
+				"to":   BaseUSDCAddress,
+				"data": balanceOfSelector + strings.Repeat("0", 24) + addr,
+			},
+			"latest",
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rpcURL, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("querying %s: %w", rpcURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("RPC %s returned HTTP %d", rpcURL, resp.StatusCode)
+	}
+
+	var rpcResp struct {
+		Result string `json:"result"`
+		Error  *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&rpcResp); err != nil {
+		return nil, fmt.Errorf("parsing RPC response: %w", err)
+	}
+	if rpcResp.Error != nil {
+		return nil, fmt.Errorf("RPC error: %s", rpcResp.Error.Message)
+	}
+
+	balance, ok := new(big.Int).SetString(strings.TrimPrefix(rpcResp.Result, "0x"), 16)
+	if !ok {
+		if rpcResp.Result == "0x" {
+			return new(big.Int), nil
+		}
+		return nil, fmt.Errorf("unexpected balanceOf result %q", rpcResp.Result)
+	}
+	return balance, nil
+}
+
+// FormatUSDC renders a USDC amount in token units as a decimal string,
+// e.g. 12345678 → "12.345678".
+func FormatUSDC(units *big.Int) string {
+	return new(big.Rat).SetFrac(units, new(big.Int).Exp(big.NewInt(10), big.NewInt(usdcDecimals), nil)).FloatString(usdcDecimals)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"net/http"
 
 	x402sdk "github.com/coinbase/x402/go"
@@ -43,9 +44,27 @@ func (pc *PaymentClient) Address() string {
 	return pc.address
 }
 
+// Payment describes a payment the server asked for, before it is signed.
+type Payment struct {
+	Amount   *big.Int // in token units
+	Asset    string   // token contract address
+	Network  string   // CAIP-2 chain ID, e.g. "eip155:8453"
+	PayTo    string   // recipient address
+	Resource string   // URL of the resource being paid for
+}
+
+// USD returns the amount in dollars. x402 prices are quoted in USDC, so
+// token units convert at 6 decimals.
+func (p Payment) USD() float64 {
+	f, _ := new(big.Rat).SetFrac(p.Amount, new(big.Int).Exp(big.NewInt(10), big.NewInt(usdcDecimals), nil)).Float64()
+	return f
+}
+
 // HandlePaymentRequired parses a 402 response and creates a signed payment.
-// Returns the headers to include on the retry request.
-func (pc *PaymentClient) HandlePaymentRequired(resp *http.Response, body []byte) (map[string]string, error) {
+// If approve is non-nil it is called with the selected payment before
+// anything is signed; an error from it aborts the payment and is returned
+// as-is. Returns the headers to include on the retry request.
+func (pc *PaymentClient) HandlePaymentRequired(resp *http.Response, body []byte, approve func(Payment) error) (map[string]string, error) {
 	// Extract headers from response
 	headers := make(map[string]string)
 	for k, v := range resp.Header {
@@ -66,6 +85,25 @@ func (pc *PaymentClient) HandlePaymentRequired(resp *http.Response, body []byte)
 		return nil, fmt.Errorf("no supported payment scheme: %w", err)
 	}
 
+	if approve != nil {
+		amount, ok := new(big.Int).SetString(selected.Amount, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid payment amount %q", selected.Amount)
+		}
+		payment := Payment{
+			Amount:  amount,
+			Asset:   selected.Asset,
+			Network: selected.Network,
+			PayTo:   selected.PayTo,
+		}
+		if paymentRequired.Resource != nil {
+			payment.Resource = paymentRequired.Resource.URL
+		}
+		if err := approve(payment); err != nil {
+			return nil, err
+		}
+	}
+
 	// Create and sign the payment payload
 	ctx := context.Background()
 	payload, err := pc.x402Client.CreatePaymentPayload(
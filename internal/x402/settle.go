@@ -0,0 +1,31 @@
+package x402
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+)
+
+// SettlementTx returns the on-chain transaction hash from the settlement
+// header of a paid response (PAYMENT-RESPONSE, or X-PAYMENT-RESPONSE from
+// v1 servers), or "" if there is none.
+func SettlementTx(h http.Header) string {
+	encoded := h.Get("PAYMENT-RESPONSE")
+	if encoded == "" {
+		encoded = h.Get("X-PAYMENT-RESPONSE")
+	}
+	if encoded == "" {
+		return ""
+	}
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return ""
+	}
+	var settle struct {
+		Transaction string `json:"transaction"`
+	}
+	if json.Unmarshal(data, &settle) != nil {
+		return ""
+	}
+	return settle.Transaction
+}
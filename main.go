@@ -4,10 +4,11 @@ import (
 	"os"
 
 	"github.com/laevitas/cli/cmd"
+	"github.com/laevitas/cli/internal/cmdutil"
 )
 
 func main() {
 	if err := cmd.Execute(); err != nil {
-		os.Exit(1)
+		os.Exit(cmdutil.ExitCode(err))
 	}
 }